@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"CalorieCompass/internal/importer"
+	"CalorieCompass/internal/pkg/config"
+	"CalorieCompass/internal/repository/postgres"
+	pg "CalorieCompass/pkg/postgres"
+)
+
+// Imports USDA FoodData Central and Open Food Facts exports into the local catalog schema.
+//
+//	go run ./cmd/importer -format usda-csv -path ./data/FoodData_Central_csv
+//	go run ./cmd/importer -format usda-json -path ./data/FoodData_Central_foundation_food_json.json
+//	go run ./cmd/importer -format off -path ./data/openfoodfacts-products.jsonl.gz
+func main() {
+	configPath := flag.String("config", "./config/config.yml", "path to config file")
+	format := flag.String("format", "", "export format: usda-csv, usda-json or off")
+	path := flag.String("path", "", "export file, or directory for usda-csv")
+	batchSize := flag.Int("batch", 1000, "records per COPY batch")
+	force := flag.Bool("force", false, "re-import a file that was already imported completely")
+	flag.Parse()
+
+	var reader importer.Reader
+	switch *format {
+	case "usda-csv":
+		reader = importer.NewUSDACSVReader(*path)
+	case "usda-json":
+		reader = importer.NewUSDAJSONReader(*path)
+	case "off":
+		reader = importer.NewOpenFoodFactsReader(*path)
+	default:
+		log.Fatalf("unknown format %q, expected usda-csv, usda-json or off", *format)
+	}
+
+	cfg, err := config.NewConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Config error: %s", err)
+	}
+
+	postgresDB, err := pg.New(cfg.Postgres.URL, pg.MaxPoolSize(cfg.Postgres.PoolMax))
+	if err != nil {
+		log.Fatalf("Postgres error: %s", err)
+	}
+	defer postgresDB.Close()
+
+	// Stop cleanly between batches on Ctrl+C; the next run resumes from the last checkpoint
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	catalogRepo := postgres.NewCatalogRepo(postgresDB.DB, "")
+	stats, err := importer.New(catalogRepo, *batchSize, *force).Import(ctx, reader)
+	if err != nil {
+		log.Fatalf("Import error: %s", err)
+	}
+
+	log.Printf("import finished: %d read, %d resumed past, %d invalid, %d inserted or updated",
+		stats.Read, stats.Skipped, stats.Invalid, stats.Changed)
+}
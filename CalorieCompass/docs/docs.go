@@ -118,39 +118,92 @@ const docTemplate = `{
                 }
             }
         },
-        "/food/search": {
+        "/body/composition": {
             "get": {
-                "description": "Search for foods by name",
-                "consumes": [
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The latest weight and measurements on or before a date with BMI, waist-to-height ratio, U.S. Navy body fat estimate, lean mass and FFMI. Metrics whose inputs are missing are omitted and the inputs listed.",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "body"
+                ],
+                "summary": "Body composition",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date (YYYY-MM-DD), defaults to today (UTC)",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.BodyComposition"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/body/measurements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the body measurements recorded by the current user in a date range, in cm, % or kg",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "food"
+                    "body"
                 ],
-                "summary": "Search foods",
+                "summary": "List measurements",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search query",
-                        "name": "query",
-                        "in": "query",
-                        "required": true
+                        "description": "Comma-separated measurement types (waist, hips, neck, chest, arm, thigh, body_fat, lean_mass), defaults to all",
+                        "name": "type",
+                        "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Page number (0-based)",
-                        "name": "page",
+                        "type": "string",
+                        "description": "First date (YYYY-MM-DD), defaults to 365 days before to",
+                        "name": "from",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "default": 50,
-                        "description": "Results per page",
-                        "name": "limit",
+                        "type": "string",
+                        "description": "Last date (YYYY-MM-DD), defaults to today (UTC)",
+                        "name": "to",
                         "in": "query"
                     }
                 ],
@@ -158,7 +211,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.FoodSearchResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Measurement"
+                            }
                         }
                     },
                     "400": {
@@ -168,6 +224,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -176,11 +239,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/food/{food_id}": {
-            "get": {
-                "description": "Get detailed information about a specific food",
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record body measurements taken on a date. Measurements of the same types already recorded that day are replaced.",
                 "consumes": [
                     "application/json"
                 ],
@@ -188,24 +254,78 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "food"
+                    "body"
                 ],
-                "summary": "Get food details",
+                "summary": "Record measurements",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Food ID",
-                        "name": "food_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Measurements",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.MeasurementInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/entity.FoodDetails"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Measurement"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
+                    }
+                }
+            }
+        },
+        "/body/measurements/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete one of the current user's body measurements",
+                "tags": [
+                    "body"
+                ],
+                "summary": "Delete measurement",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Measurement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -231,14 +351,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/user": {
+        "/body/profile": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get user information by ID",
+                "description": "Get the current user's sex, birth date and height (cm) used by body composition formulas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "body"
+                ],
+                "summary": "Get body profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Profile"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the current user's sex, birth date and height",
                 "consumes": [
                     "application/json"
                 ],
@@ -246,14 +403,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "body"
+                ],
+                "summary": "Update body profile",
+                "parameters": [
+                    {
+                        "description": "Profile",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.ProfileInput"
+                        }
+                    }
                 ],
-                "summary": "Get user by ID",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.UserResponse"
+                            "$ref": "#/definitions/entity.Profile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "401": {
@@ -272,96 +447,7694 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "entity.AuthResponse": {
-            "type": "object",
-            "properties": {
-                "token": {
+        },
+        "/body/series": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Time series of a measurement type, weight or a derived metric (bmi, waist_to_height, navy_body_fat, ffmi, normalized_ffmi) for charting. Series longer than max_points are downsampled by averaging consecutive values.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "body"
+                ],
+                "summary": "Body metric series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Measurement type or metric",
+                        "name": "metric",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First date (YYYY-MM-DD), defaults to 365 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last date (YYYY-MM-DD), defaults to today (UTC)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 200,
+                        "description": "Maximum number of points (2-1000)",
+                        "name": "max_points",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.BodySeries"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/diary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the current user's diary for a date, grouped by meal with per-meal and daily totals, with the day's exercise and hydration, a summary of calories eaten and burned with the day's net carbs (carbs minus fiber) and average food quality, and progress against the targets active on the date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "diary"
+                ],
+                "summary": "Get diary day",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date (YYYY-MM-DD), defaults to today (UTC)",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.DiaryDay"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a food to the diary. Nutrients are scaled to the amount and stored as a snapshot. eaten_at must fall on date (UTC); it defaults to now for today and to the meal slot's usual time on other dates.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "diary"
+                ],
+                "summary": "Log food",
+                "parameters": [
+                    {
+                        "description": "Diary entry",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.DiaryEntryInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.DiaryEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/diary/copy": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Copy a meal slot, or a whole day when meal is omitted, to another date. Amounts and nutrients can be scaled; to_meal moves a copied slot to a different slot. All entries are copied or none are.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "diary"
+                ],
+                "summary": "Copy meal or day",
+                "parameters": [
+                    {
+                        "description": "Copy request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.DiaryCopyInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.DiaryDay"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/diary/parse": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Turn free text such as \"2 scrambled eggs, a slice of whole wheat toast and a coffee with milk\" into suggested diary entries. Each food phrase is matched through food search and given a serving that fits its quantity; candidates are ranked by confidence. Nothing is logged: post the confirmed entries to /diary.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "diary"
+                ],
+                "summary": "Parse meal description",
+                "parameters": [
+                    {
+                        "description": "Meal description",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.MealParseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.MealParseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/diary/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an entry from the current user's diary",
+                "tags": [
+                    "diary"
+                ],
+                "summary": "Delete diary entry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Diary entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/exercise": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Search the exercise catalog, with MET values from the Compendium of Physical Activities, and the current user's own exercises",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exercise"
+                ],
+                "summary": "Search exercises",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name contains",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of exercises (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Exercise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a user-defined exercise with its MET values",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exercise"
+                ],
+                "summary": "Create exercise",
+                "parameters": [
+                    {
+                        "description": "Exercise",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.ExerciseInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Exercise"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/exercise/log": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the exercise logged by the current user in a date range",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exercise"
+                ],
+                "summary": "List logged exercise",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First date (YYYY-MM-DD), defaults to 7 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last date (YYYY-MM-DD), defaults to today (UTC)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.ExerciseEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Log an exercise by duration, distance or both. Calories burned are MET × body weight (kg) × hours, using the latest weight recorded on or before the date.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exercise"
+                ],
+                "summary": "Log exercise",
+                "parameters": [
+                    {
+                        "description": "Exercise entry",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.ExerciseEntryInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ExerciseEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/exercise/log/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an exercise from the current user's log",
+                "tags": [
+                    "exercise"
+                ],
+                "summary": "Delete logged exercise",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exercise entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/exercise/settings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get whether calories burned are added back to the daily budget in the diary summary",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exercise"
+                ],
+                "summary": "Get exercise settings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ExerciseSettings"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Choose whether calories burned are added back to the daily budget in the diary summary",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exercise"
+                ],
+                "summary": "Update exercise settings",
+                "parameters": [
+                    {
+                        "description": "Settings",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.ExerciseSettingsInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ExerciseSettings"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/exercise/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace one of the current user's exercises. Catalog exercises cannot be changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exercise"
+                ],
+                "summary": "Update exercise",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exercise ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Exercise",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.ExerciseInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Exercise"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete one of the current user's exercises. Logged entries keep their snapshot.",
+                "tags": [
+                    "exercise"
+                ],
+                "summary": "Delete exercise",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exercise ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/fasting": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the fasts the current user started in a date range with their status and eating-window violations: diary entries over 10 kcal eaten during the fast, by their eaten_at time",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fasting"
+                ],
+                "summary": "List fasts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First date (YYYY-MM-DD), defaults to 30 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last date (YYYY-MM-DD), defaults to today (UTC)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.FastingSession"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/fasting/current": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the current user's fast in progress with its progress and violations so far",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fasting"
+                ],
+                "summary": "Get current fast",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.FastingSession"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/fasting/end": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "End the current user's fast in progress, now or at an earlier time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fasting"
+                ],
+                "summary": "End fast",
+                "parameters": [
+                    {
+                        "description": "End",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/entity.FastingEndInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.FastingSession"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/fasting/protocols": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the preset fasting schedules such as 16:8 and one meal a day",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fasting"
+                ],
+                "summary": "List fasting protocols",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.FastingProtocol"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/fasting/start": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start a fast following a protocol, now or at an earlier time. Only one fast can be in progress.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fasting"
+                ],
+                "summary": "Start fast",
+                "parameters": [
+                    {
+                        "description": "Fast",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.FastingStartInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.FastingSession"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/fasting/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get completion rate, average and longest fast, violations and streaks of completed fasts for the fasts the current user started in a date range",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fasting"
+                ],
+                "summary": "Get fasting statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First date (YYYY-MM-DD), defaults to 90 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last date (YYYY-MM-DD), defaults to today (UTC)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.FastingStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/fasting/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete one of the current user's fasts",
+                "tags": [
+                    "fasting"
+                ],
+                "summary": "Delete fast",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fasting session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/food/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Resolve up to 100 food IDs concurrently. Duplicate IDs are returned once; foods that cannot be resolved carry a per-item error instead of failing the batch. Foods are rated and flagged as by GET /food/{food_id}.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "food"
+                ],
+                "summary": "Get details for several foods",
+                "parameters": [
+                    {
+                        "description": "Food IDs",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.FoodBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.FoodBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/food/custom": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the current user's custom foods",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "custom-food"
+                ],
+                "summary": "List custom foods",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.FoodDetails"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a food with user-defined servings and nutrients",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "custom-food"
+                ],
+                "summary": "Create custom food",
+                "parameters": [
+                    {
+                        "description": "Custom food",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.CustomFoodInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.FoodDetails"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/food/custom/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get one of the current user's custom foods",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "custom-food"
+                ],
+                "summary": "Get custom food",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Custom food ID (123 or custom:123)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.FoodDetails"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace a custom food's name, brand and servings. Servings keep their IDs: a serving sent with the ID of one of the food's servings updates it, others update the remaining servings in order, and only extra servings are added or missing ones removed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "custom-food"
+                ],
+                "summary": "Update custom food",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Custom food ID (123 or custom:123)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Custom food",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.CustomFoodInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.FoodDetails"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete one of the current user's custom foods",
+                "tags": [
+                    "custom-food"
+                ],
+                "summary": "Delete custom food",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Custom food ID (123 or custom:123)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/food/favorites": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the current user's favorite foods with their default amounts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorite-food"
+                ],
+                "summary": "List favorite foods",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Resolve full food details",
+                        "name": "details",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.FavoriteFood"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark a food as favorite with a default serving and amount (1 serving if omitted). Adding an existing favorite replaces its defaults.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorite-food"
+                ],
+                "summary": "Add favorite food",
+                "parameters": [
+                    {
+                        "description": "Favorite food",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.FavoriteFoodInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.FavoriteFood"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/food/favorites/{food_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a food from the current user's favorites",
+                "tags": [
+                    "favorite-food"
+                ],
+                "summary": "Remove favorite food",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Food ID",
+                        "name": "food_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/food/recent": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Foods from the current user's diary ranked by how often and how recently they were logged, favouring foods usually eaten around the current time of day",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorite-food"
+                ],
+                "summary": "List recent foods",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of foods (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client time with UTC offset (RFC 3339), defaults to now",
+                        "name": "at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Resolve full food details",
+                        "name": "details",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.RecentFood"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/food/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Search for foods by name across the configured providers; signed-in users also find their matching custom foods. Results are merged into one list that is paged through limit foods at a time, up to the first 500 results. Results whose per-100 g values are known (catalog and custom foods, and provider foods fetched for nutrient filters) carry their Nutri-Score and nutrient density. Results are flagged against the user's restriction profile: provider foods from their details where these were fetched, and otherwise by name only with flags_unverified set. Strict profiles leave flagged and unverified foods out, and the total counts only the foods kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "food"
+                ],
+                "summary": "Search foods",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "query",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page number (0-based)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Results per page (1-50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "brand",
+                            "generic"
+                        ],
+                        "type": "string",
+                        "description": "Food type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum calories per 100 g",
+                        "name": "min_calories",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum calories per 100 g",
+                        "name": "max_calories",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum protein per 100 g",
+                        "name": "min_protein",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum protein per 100 g",
+                        "name": "max_protein",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum carbs per 100 g",
+                        "name": "min_carbs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum carbs per 100 g",
+                        "name": "max_carbs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum fat per 100 g",
+                        "name": "min_fat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum fat per 100 g",
+                        "name": "max_fat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated dietary tags (high_protein, high_fiber, low_calorie, low_carb, low_fat, low_sugar, low_sodium)",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "relevance",
+                            "calories",
+                            "protein_density"
+                        ],
+                        "type": "string",
+                        "default": "relevance",
+                        "description": "Sort order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.FoodSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/food/{food_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get detailed information about a specific food, with its Nutri-Score (2023 algorithm) and nutrient density index per 100 g/ml when a serving has a metric amount. Signed-in users get flags for the restrictions in their profile that the food's declared allergens and diets, ingredients or name conflict with.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "food"
+                ],
+                "summary": "Get food details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Food ID (custom:123, fs:456 or a bare FatSecret ID)",
+                        "name": "food_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.FoodDetails"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/food/{food_id}/alternatives": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Find catalog foods in the same category and with similar names that have fewer calories per gram, less sugar or sodium, or more fiber or protein. Each alternative is compared with the food over the same serving, nutrient by nutrient, with an explanation of what improves and what gets worse. Foods containing one of the user's allergens are left out, as are diet conflicts under a strict restriction profile; other diet conflicts are flagged.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "food"
+                ],
+                "summary": "Get healthier alternatives",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Food ID",
+                        "name": "food_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Serving to compare over (defaults to the first serving with a metric amount)",
+                        "name": "serving_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Maximum number of alternatives (max 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.FoodAlternatives"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/food/{food_id}/nutrition": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Scale a food's nutrients to an arbitrary amount, e.g. 37 g, 1.5 cup or 2 serving",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "food"
+                ],
+                "summary": "Get food nutrition for an amount",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Food ID",
+                        "name": "food_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Amount in the given unit",
+                        "name": "amount",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unit (g, kg, oz, lb, ml, l, tsp, tbsp, cup, fl oz or serving)",
+                        "name": "unit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Serving to scale from (defaults to the first compatible serving)",
+                        "name": "serving_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.FoodNutritionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/glucose": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the blood glucose readings the current user took in a date range, in mg/dL and mmol/L",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "glucose"
+                ],
+                "summary": "List glucose readings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First date (YYYY-MM-DD), defaults to 14 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last date (YYYY-MM-DD), defaults to today (UTC)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.GlucoseReading"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record a blood glucose reading in mg/dL or mmol/L, now or at an earlier time. Readings must be between 20 and 600 mg/dL.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "glucose"
+                ],
+                "summary": "Log glucose reading",
+                "parameters": [
+                    {
+                        "description": "Reading",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.GlucoseReadingInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.GlucoseReading"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/glucose/meals": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Relate the meals the current user ate in a date range to the blood glucose readings taken after them. Diary entries eaten less than 30 minutes apart form one meal. Each meal gets its net carbs and glycemic load, a baseline from the last reading in the hour before it, and the peak and rise of the readings in the post-meal window, which ends early when the next meal starts. With at least three meals that have a rise and a known glycemic load, the correlation between load and rise is reported.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "glucose"
+                ],
+                "summary": "Glucose response to meals",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First date (YYYY-MM-DD), defaults to 7 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last date (YYYY-MM-DD), defaults to today (UTC)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 120,
+                        "description": "Post-meal window in minutes (30-240)",
+                        "name": "window",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.GlucoseMealReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/glucose/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete one of the current user's blood glucose readings",
+                "tags": [
+                    "glucose"
+                ],
+                "summary": "Delete glucose reading",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reading ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/glycemic": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the net carbs (carbs minus fiber) and glycemic load of the current user's diary for a date, per entry, per meal and for the day. The glycemic index of a food comes from a reference table matched on its name or, for catalog foods, its dataset category; foods matching neither count towards net carbs only. The day's load is rated low below 80 and high above 120, an entry's or meal's low up to 10 and high from 20.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "glycemic"
+                ],
+                "summary": "Get glycemic load of a day",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date (YYYY-MM-DD), defaults to today (UTC)",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.GlycemicDay"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/goals": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the current user's goal history, latest first. Each goal applies from its effective date until the next one starts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goals"
+                ],
+                "summary": "List goals",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.NutritionGoal"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set calorie and macronutrient targets from a date on, replacing a goal starting the same date. Schedules give some weekdays, such as training days, their own targets.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goals"
+                ],
+                "summary": "Create goal",
+                "parameters": [
+                    {
+                        "description": "Goal",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.NutritionGoalInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.NutritionGoal"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/goals/presets": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the built-in splits of calories between carbs, protein and fat",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goals"
+                ],
+                "summary": "List macro presets",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.MacroPreset"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/goals/target": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the targets that were active on a date: the goal in effect then and its target for the weekday",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goals"
+                ],
+                "summary": "Get targets for a date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date (YYYY-MM-DD), defaults to today (UTC)",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.NutritionTarget"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/goals/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete one of the current user's goals; the previous goal then applies to its dates",
+                "tags": [
+                    "goals"
+                ],
+                "summary": "Delete goal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Goal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/meal": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the current user's saved meals with their totals",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "meal"
+                ],
+                "summary": "List saved meals",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.SavedMeal"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Save a named group of foods that can be logged in one call",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "meal"
+                ],
+                "summary": "Create saved meal",
+                "parameters": [
+                    {
+                        "description": "Saved meal",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.SavedMealInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SavedMeal"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/meal/from-diary": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Save the foods logged in a meal slot on a date as a saved meal",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "meal"
+                ],
+                "summary": "Save meal from diary",
+                "parameters": [
+                    {
+                        "description": "Diary meal to save",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.SavedMealFromDiaryInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SavedMeal"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/meal/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get one of the current user's saved meals",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "meal"
+                ],
+                "summary": "Get saved meal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Saved meal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SavedMeal"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace a saved meal's name, default slot and foods",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "meal"
+                ],
+                "summary": "Update saved meal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Saved meal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Saved meal",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.SavedMealInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SavedMeal"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a saved meal. Diary entries logged from it are kept.",
+                "tags": [
+                    "meal"
+                ],
+                "summary": "Delete saved meal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Saved meal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/meal/{id}/log": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add every food of a saved meal to the diary in one transaction, optionally scaled, and return the updated day. eaten_at must fall on date (UTC) and defaults as for POST /diary.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "meal"
+                ],
+                "summary": "Log saved meal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Saved meal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Where to log the meal",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.LogSavedMealInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.DiaryDay"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/nutrients/adequacy": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compare the current user's average daily intake over the logged days of the 7 days ending on a date with the reference intakes for their age and sex from the body profile. Nutrients below their RDA or AI, or above their upper limit (such as sodium), list the foods contributing most to them. Vitamins and minerals (calcium, iron, potassium, vitamins A, C and D) only count what the food sources report.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "nutrients"
+                ],
+                "summary": "Get nutrient adequacy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Last date of the window (YYYY-MM-DD), defaults to today (UTC)",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.NutrientAnalysis"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/nutrients/references": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the Dietary Reference Intakes (RDA or AI, upper limits and acceptable macronutrient distribution ranges) for the tracked nutrients, for a sex and age or, by default, the current user's body profile",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "nutrients"
+                ],
+                "summary": "Get reference intakes",
+                "parameters": [
+                    {
+                        "enum": [
+                            "male",
+                            "female"
+                        ],
+                        "type": "string",
+                        "description": "Sex",
+                        "name": "sex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Age in years (1-120)",
+                        "name": "age",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.NutrientReferences"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/recipe": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the current user's recipes, flagged against their restriction profile by ingredient names",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipe"
+                ],
+                "summary": "List recipes",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Recipe"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a recipe from foods. Total and per-serving nutrition are computed from the ingredients. The recipe can be logged to the diary with its ID, e.g. recipe:12.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipe"
+                ],
+                "summary": "Create recipe",
+                "parameters": [
+                    {
+                        "description": "Recipe",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.RecipeInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Recipe"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/recipe/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Draft a recipe from schema.org Recipe JSON-LD, an HTML page containing it, or a plain-text ingredient list (one ingredient per line). Each line is parsed into quantity, unit and food and matched to a food with a confidence score. Nothing is saved: review the draft and submit its recipe to POST /recipe.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipe"
+                ],
+                "summary": "Import recipe",
+                "parameters": [
+                    {
+                        "description": "Recipe content",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.RecipeImportRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.RecipeImportDraft"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/recipe/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get one of the current user's recipes, flagged against their restriction profile by ingredient names",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipe"
+                ],
+                "summary": "Get recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID (12 or recipe:12)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Recipe"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace a recipe and recompute its nutrition",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipe"
+                ],
+                "summary": "Update recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID (12 or recipe:12)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Recipe",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.RecipeInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Recipe"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete one of the current user's recipes",
+                "tags": [
+                    "recipe"
+                ],
+                "summary": "Delete recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID (12 or recipe:12)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/recipe/{id}/recompute": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Re-resolve every ingredient to pick up changes to the underlying foods",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipe"
+                ],
+                "summary": "Recompute recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID (12 or recipe:12)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Recipe"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reports": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Review the current user's diary over up to 366 days: daily averages and macro distribution overall and per day, week or month, adherence to the goals active on each day (within 10% of target), the days closest to and furthest from the calorie target, the foods contributing most to each nutrient and how completely the diary was kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get nutrition report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First date (YYYY-MM-DD), defaults to 30 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last date (YYYY-MM-DD), defaults to today (UTC)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "week",
+                        "description": "Period length",
+                        "name": "granularity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Top foods per nutrient (1-20)",
+                        "name": "top",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.NutritionReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/restrictions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the allergens and diets the current user avoids or follows; empty lists when none are set",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restrictions"
+                ],
+                "summary": "Get restriction profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.RestrictionProfile"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the current user's allergens and diets. Foods and recipes are then flagged in search results and details when their declared attributes, ingredients or name conflict with the profile. In strict mode flagged foods are left out of search results entirely.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restrictions"
+                ],
+                "summary": "Update restriction profile",
+                "parameters": [
+                    {
+                        "description": "Restriction profile",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.RestrictionProfileInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.RestrictionProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Clear the current user's allergens and diets so that foods are no longer flagged",
+                "tags": [
+                    "restrictions"
+                ],
+                "summary": "Delete restriction profile",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/restrictions/options": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the allergen codes (the 14 allergens EU food law requires to be declared) and diet codes a restriction profile accepts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restrictions"
+                ],
+                "summary": "List restriction options",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.RestrictionOptions"
+                        }
+                    }
+                }
+            }
+        },
+        "/suggestions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rank the current user's favorites, recently logged foods and catalog foods by how well a scaled serving of each fills the calories and macros left of the day's nutrition goal. Each suggestion carries the amount to log, its nutrition, a fit score from 0 to 100 and what would be left afterwards. Dietary preferences are given as food search filters. Foods flagged for an allergen in the user's restriction profile are never suggested, nor, in strict mode, foods flagged for a diet; other diet conflicts are listed in flags.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suggestions"
+                ],
+                "summary": "Suggest foods for the remaining targets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date (YYYY-MM-DD), defaults to today (UTC)",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of suggestions (max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "brand",
+                            "generic"
+                        ],
+                        "type": "string",
+                        "description": "Food type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum calories per 100 g",
+                        "name": "min_calories",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum calories per 100 g",
+                        "name": "max_calories",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum protein per 100 g",
+                        "name": "min_protein",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum protein per 100 g",
+                        "name": "max_protein",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum carbs per 100 g",
+                        "name": "min_carbs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum carbs per 100 g",
+                        "name": "max_carbs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum fat per 100 g",
+                        "name": "min_fat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum fat per 100 g",
+                        "name": "max_fat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated dietary tags (high_protein, high_fiber, low_calorie, low_carb, low_fat, low_sugar, low_sodium)",
+                        "name": "tags",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SuggestionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/user": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get user information by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get user by ID",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.UserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/water": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the water logged by the current user on a date with the day's hydration: water, water from drinks and high-water foods logged in the diary, and the target derived from body weight (35 ml/kg) plus 500 ml per hour of exercise",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "water"
+                ],
+                "summary": "Get water day",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date (YYYY-MM-DD), defaults to today (UTC)",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.HydrationDay"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Log water as an amount in a volume unit or as a number of containers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "water"
+                ],
+                "summary": "Log water",
+                "parameters": [
+                    {
+                        "description": "Water entry",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.WaterEntryInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.WaterEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/water/containers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the built-in drink containers followed by the current user's own containers (ml)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "water"
+                ],
+                "summary": "List containers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.WaterContainer"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a drink container size for the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "water"
+                ],
+                "summary": "Create container",
+                "parameters": [
+                    {
+                        "description": "Container",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.WaterContainerInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.WaterContainer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/water/containers/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete one of the current user's containers. Logged water keeps its amount.",
+                "tags": [
+                    "water"
+                ],
+                "summary": "Delete container",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/water/settings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the current user's fixed daily water target, if any",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "water"
+                ],
+                "summary": "Get water settings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.WaterSettings"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set a fixed daily water target, or clear it to derive the target from body weight",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "water"
+                ],
+                "summary": "Update water settings",
+                "parameters": [
+                    {
+                        "description": "Settings",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.WaterSettingsInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.WaterSettings"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/water/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an entry from the current user's water log",
+                "tags": [
+                    "water"
+                ],
+                "summary": "Delete water entry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Water entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/weight": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the weights recorded by the current user in a date range (kg)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "weight"
+                ],
+                "summary": "List weights",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First date (YYYY-MM-DD), defaults to 90 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last date (YYYY-MM-DD), defaults to today (UTC)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.WeightEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record the current user's weight for a date. A weight already recorded that day is replaced.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "weight"
+                ],
+                "summary": "Record weight",
+                "parameters": [
+                    {
+                        "description": "Weight",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.WeightEntryInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.WeightEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/weight/goal": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the current user's target weight",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "weight"
+                ],
+                "summary": "Set goal weight",
+                "parameters": [
+                    {
+                        "description": "Goal weight",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.WeightGoalInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.WeightGoal"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove the current user's target weight",
+                "tags": [
+                    "weight"
+                ],
+                "summary": "Delete goal weight",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/weight/trend": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Exponentially smoothed trend weight that filters out day-to-day water fluctuations, the weekly rate of change fitted over the last 4 weeks, the projected date the goal weight is reached, and the TDEE implied by diary intake and trend change over the last window days",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "weight"
+                ],
+                "summary": "Weight trend",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First date (YYYY-MM-DD), defaults to 90 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last date (YYYY-MM-DD), defaults to today (UTC)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 28,
+                        "description": "TDEE window in days (7-90)",
+                        "name": "window",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.WeightTrend"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/weight/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete one of the current user's weight entries",
+                "tags": [
+                    "weight"
+                ],
+                "summary": "Delete weight",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Weight entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entity.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsI..."
+                },
+                "user": {
+                    "$ref": "#/definitions/entity.UserResponse"
+                }
+            }
+        },
+        "entity.BodyComposition": {
+            "type": "object",
+            "properties": {
+                "bmi": {
+                    "type": "number",
+                    "example": 25.4
+                },
+                "bmi_category": {
+                    "type": "string",
+                    "example": "overweight"
+                },
+                "body_fat": {
+                    "type": "number",
+                    "example": 18.2
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "ffmi": {
+                    "type": "number",
+                    "example": 20.8
+                },
+                "height": {
+                    "type": "number",
+                    "example": 180
+                },
+                "lean_mass": {
+                    "type": "number",
+                    "example": 67.4
+                },
+                "measurements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Measurement"
+                    }
+                },
+                "missing": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "navy_body_fat": {
+                    "type": "number",
+                    "example": 18.2
+                },
+                "normalized_ffmi": {
+                    "type": "number",
+                    "example": 20.8
+                },
+                "waist_to_height": {
+                    "type": "number",
+                    "example": 0.47
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 82.4
+                }
+            }
+        },
+        "entity.BodySeries": {
+            "type": "object",
+            "properties": {
+                "metric": {
+                    "type": "string",
+                    "example": "waist"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.BodySeriesPoint"
+                    }
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "cm"
+                }
+            }
+        },
+        "entity.BodySeriesPoint": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 84.5
+                }
+            }
+        },
+        "entity.CustomFoodInput": {
+            "type": "object",
+            "required": [
+                "name",
+                "servings"
+            ],
+            "properties": {
+                "brand_name": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Grandma's lentil soup"
+                },
+                "servings": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/entity.Serving"
+                    }
+                }
+            }
+        },
+        "entity.DailyTargets": {
+            "type": "object",
+            "properties": {
+                "calories": {
+                    "$ref": "#/definitions/entity.TargetProgress"
+                },
+                "carbs": {
+                    "$ref": "#/definitions/entity.TargetProgress"
+                },
+                "fat": {
+                    "$ref": "#/definitions/entity.TargetProgress"
+                },
+                "protein": {
+                    "$ref": "#/definitions/entity.TargetProgress"
+                },
+                "target": {
+                    "$ref": "#/definitions/entity.NutritionTarget"
+                }
+            }
+        },
+        "entity.DiaryCopyInput": {
+            "type": "object",
+            "required": [
+                "from_date",
+                "to_date"
+            ],
+            "properties": {
+                "from_date": {
+                    "type": "string",
+                    "example": "2026-01-30"
+                },
+                "meal": {
+                    "type": "string",
+                    "enum": [
+                        "breakfast",
+                        "lunch",
+                        "dinner",
+                        "snack"
+                    ],
+                    "example": "breakfast"
+                },
+                "scale": {
+                    "type": "number",
+                    "example": 1
+                },
+                "to_date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "to_meal": {
+                    "type": "string",
+                    "enum": [
+                        "breakfast",
+                        "lunch",
+                        "dinner",
+                        "snack"
+                    ],
+                    "example": "lunch"
+                }
+            }
+        },
+        "entity.DiaryDay": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "exercises": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ExerciseEntry"
+                    }
+                },
+                "hydration": {
+                    "$ref": "#/definitions/entity.HydrationSummary"
+                },
+                "meals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.DiaryMeal"
+                    }
+                },
+                "summary": {
+                    "$ref": "#/definitions/entity.DiarySummary"
+                },
+                "targets": {
+                    "$ref": "#/definitions/entity.DailyTargets"
+                },
+                "totals": {
+                    "$ref": "#/definitions/entity.Serving"
+                }
+            }
+        },
+        "entity.DiaryEntry": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "brand_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "eaten_at": {
+                    "type": "string"
+                },
+                "food_id": {
+                    "type": "string",
+                    "example": "fs:33691"
+                },
+                "food_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "meal": {
+                    "type": "string",
+                    "example": "breakfast"
+                },
+                "nutrition": {
+                    "$ref": "#/definitions/entity.Serving"
+                },
+                "serving_id": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.DiaryEntryInput": {
+            "type": "object",
+            "required": [
+                "amount",
+                "date",
+                "food_id",
+                "meal",
+                "unit"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "eaten_at": {
+                    "type": "string"
+                },
+                "food_id": {
+                    "type": "string",
+                    "example": "fs:33691"
+                },
+                "meal": {
+                    "type": "string",
+                    "enum": [
+                        "breakfast",
+                        "lunch",
+                        "dinner",
+                        "snack"
+                    ],
+                    "example": "breakfast"
+                },
+                "serving_id": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "serving"
+                }
+            }
+        },
+        "entity.DiaryMeal": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.DiaryEntry"
+                    }
+                },
+                "meal": {
+                    "type": "string"
+                },
+                "totals": {
+                    "$ref": "#/definitions/entity.Serving"
+                }
+            }
+        },
+        "entity.DiarySummary": {
+            "type": "object",
+            "properties": {
+                "burned": {
+                    "type": "number",
+                    "example": 404
+                },
+                "burned_added_back": {
+                    "type": "boolean"
+                },
+                "consumed": {
+                    "type": "number",
+                    "example": 2150
+                },
+                "net": {
+                    "type": "number",
+                    "example": 1746
+                },
+                "net_carbs": {
+                    "type": "number",
+                    "example": 182
+                },
+                "quality": {
+                    "$ref": "#/definitions/entity.DietQuality"
+                }
+            }
+        },
+        "entity.DietQuality": {
+            "type": "object",
+            "properties": {
+                "grade": {
+                    "type": "string",
+                    "example": "b"
+                },
+                "grade_average": {
+                    "type": "number",
+                    "example": 2.3
+                },
+                "nutrient_density": {
+                    "type": "number",
+                    "example": 35.2
+                },
+                "scored_share": {
+                    "type": "number",
+                    "example": 92.5
+                }
+            }
+        },
+        "entity.Exercise": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "running"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "12050"
+                },
+                "custom": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "met": {
+                    "type": "number",
+                    "example": 9.8
+                },
+                "met_light": {
+                    "type": "number",
+                    "example": 7
+                },
+                "met_vigorous": {
+                    "type": "number",
+                    "example": 11.8
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Running"
+                },
+                "speed_kmh": {
+                    "type": "number",
+                    "example": 9.7
+                }
+            }
+        },
+        "entity.ExerciseEntry": {
+            "type": "object",
+            "properties": {
+                "calories": {
+                    "type": "number",
+                    "example": 404
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "distance": {
+                    "type": "number",
+                    "example": 5
+                },
+                "duration": {
+                    "type": "number",
+                    "example": 30
+                },
+                "exercise_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "intensity": {
+                    "type": "string",
+                    "example": "moderate"
+                },
+                "met": {
+                    "type": "number",
+                    "example": 9.8
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Running"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 82.4
+                }
+            }
+        },
+        "entity.ExerciseEntryInput": {
+            "type": "object",
+            "required": [
+                "date",
+                "exercise_id"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "distance": {
+                    "type": "number",
+                    "example": 5
+                },
+                "distance_unit": {
+                    "type": "string",
+                    "enum": [
+                        "km",
+                        "mi",
+                        "m"
+                    ],
+                    "example": "km"
+                },
+                "duration": {
+                    "type": "number",
+                    "maximum": 1440,
+                    "example": 30
+                },
+                "exercise_id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "intensity": {
+                    "type": "string",
+                    "enum": [
+                        "light",
+                        "moderate",
+                        "vigorous"
+                    ],
+                    "example": "moderate"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "entity.ExerciseInput": {
+            "type": "object",
+            "required": [
+                "met",
+                "name"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "cycling"
+                },
+                "met": {
+                    "type": "number",
+                    "maximum": 25,
+                    "example": 8.5
+                },
+                "met_light": {
+                    "type": "number",
+                    "maximum": 25
+                },
+                "met_vigorous": {
+                    "type": "number",
+                    "maximum": 25
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Spin class"
+                },
+                "speed_kmh": {
+                    "type": "number",
+                    "maximum": 100
+                }
+            }
+        },
+        "entity.ExerciseSettings": {
+            "type": "object",
+            "properties": {
+                "add_burned_calories": {
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ExerciseSettingsInput": {
+            "type": "object",
+            "properties": {
+                "add_burned_calories": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "entity.FastingEndInput": {
+            "type": "object",
+            "properties": {
+                "ended_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.FastingProtocol": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "16:8"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "eating_hours": {
+                    "type": "number",
+                    "example": 8
+                },
+                "fasting_hours": {
+                    "type": "number",
+                    "example": 16
+                },
+                "name": {
+                    "type": "string",
+                    "example": "16:8"
+                }
+            }
+        },
+        "entity.FastingSession": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "ended_at": {
+                    "type": "string"
+                },
+                "hours": {
+                    "type": "number",
+                    "example": 14.5
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "planned_end": {
+                    "type": "string"
+                },
+                "planned_hours": {
+                    "type": "number",
+                    "example": 16
+                },
+                "progress": {
+                    "type": "number",
+                    "example": 90.6
+                },
+                "protocol": {
+                    "type": "string",
+                    "example": "16:8"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.FastingViolation"
+                    }
+                }
+            }
+        },
+        "entity.FastingStartInput": {
+            "type": "object",
+            "required": [
+                "protocol"
+            ],
+            "properties": {
+                "hours": {
+                    "type": "number",
+                    "maximum": 168,
+                    "example": 16
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "protocol": {
+                    "type": "string",
+                    "example": "16:8"
+                },
+                "started_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.FastingStats": {
+            "type": "object",
+            "properties": {
+                "average_hours": {
+                    "type": "number",
+                    "example": 16.3
+                },
+                "completed": {
+                    "type": "integer",
+                    "example": 24
+                },
+                "completion_rate": {
+                    "type": "number",
+                    "example": 85.7
+                },
+                "current_streak": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2026-01-01"
+                },
+                "longest_hours": {
+                    "type": "number",
+                    "example": 20.1
+                },
+                "longest_streak": {
+                    "type": "integer",
+                    "example": 11
+                },
+                "sessions": {
+                    "type": "integer",
+                    "example": 28
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "violations": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "entity.FastingViolation": {
+            "type": "object",
+            "properties": {
+                "calories": {
+                    "type": "number",
+                    "example": 180
+                },
+                "eaten_at": {
+                    "type": "string"
+                },
+                "entry_id": {
+                    "type": "integer"
+                },
+                "food_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.FavoriteFood": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "brand_name": {
+                    "type": "string"
+                },
+                "calories": {
+                    "type": "number"
+                },
+                "carbs": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "fat": {
+                    "type": "number"
+                },
+                "food": {
+                    "$ref": "#/definitions/entity.FoodDetails"
+                },
+                "food_id": {
+                    "type": "string",
+                    "example": "fs:33691"
+                },
+                "food_name": {
+                    "type": "string"
+                },
+                "protein": {
+                    "type": "number"
+                },
+                "serving_id": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.FavoriteFoodInput": {
+            "type": "object",
+            "required": [
+                "food_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1
+                },
+                "food_id": {
+                    "type": "string",
+                    "example": "fs:33691"
+                },
+                "serving_id": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "serving"
+                }
+            }
+        },
+        "entity.Food": {
+            "type": "object",
+            "properties": {
+                "brand_name": {
+                    "type": "string"
+                },
+                "calories": {
+                    "type": "number"
+                },
+                "carbs": {
+                    "type": "number"
+                },
+                "fat": {
+                    "type": "number"
+                },
+                "flags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.FoodFlag"
+                    }
+                },
+                "flags_unverified": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "protein": {
+                    "type": "number"
+                },
+                "quality": {
+                    "$ref": "#/definitions/entity.FoodQuality"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.FoodAlternative": {
+            "type": "object",
+            "properties": {
+                "brand_name": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "comparison": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.NutrientComparison"
+                    }
+                },
+                "explanation": {
+                    "type": "string",
+                    "example": "Per 150 g: 67% less sugar and 2.5 g more fiber"
+                },
+                "flags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.FoodFlag"
+                    }
+                },
+                "food_id": {
+                    "type": "string",
+                    "example": "catalog:1042"
+                },
+                "food_name": {
+                    "type": "string"
+                },
+                "improvements": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "sugar",
+                        "fiber"
+                    ]
+                },
+                "nutrition": {
+                    "$ref": "#/definitions/entity.Serving"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 0.93
+                },
+                "similarity": {
+                    "type": "number",
+                    "example": 0.62
+                }
+            }
+        },
+        "entity.FoodAlternatives": {
+            "type": "object",
+            "properties": {
+                "alternatives": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.FoodAlternative"
+                    }
+                },
+                "brand_name": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "food_id": {
+                    "type": "string",
+                    "example": "fs:33691"
+                },
+                "food_name": {
+                    "type": "string"
+                },
+                "serving": {
+                    "$ref": "#/definitions/entity.Serving"
+                }
+            }
+        },
+        "entity.FoodAttributes": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "milk"
+                    ]
+                },
+                "free_from": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "peanuts",
+                        "gluten"
+                    ]
+                },
+                "suitable_diets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vegetarian"
+                    ]
+                },
+                "unsuitable_diets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vegan"
+                    ]
+                }
+            }
+        },
+        "entity.FoodBatchItem": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "food": {
+                    "$ref": "#/definitions/entity.FoodDetails"
+                },
+                "food_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.FoodBatchRequest": {
+            "type": "object",
+            "required": [
+                "food_ids"
+            ],
+            "properties": {
+                "food_ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "fs:33691",
+                        "custom:12"
+                    ]
+                }
+            }
+        },
+        "entity.FoodBatchResponse": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.FoodBatchItem"
+                    }
+                }
+            }
+        },
+        "entity.FoodDetails": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/entity.FoodAttributes"
+                },
+                "brand_name": {
+                    "type": "string"
+                },
+                "flags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.FoodFlag"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ingredients": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quality": {
+                    "$ref": "#/definitions/entity.FoodQuality"
+                },
+                "servings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Serving"
+                    }
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.FoodFlag": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "example": "allergen"
+                },
+                "match": {
+                    "type": "string",
+                    "example": "whey"
+                },
+                "restriction": {
+                    "type": "string",
+                    "example": "milk"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "ingredients"
+                }
+            }
+        },
+        "entity.FoodNutritionResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "base_serving_id": {
+                    "type": "string"
+                },
+                "brand_name": {
+                    "type": "string"
+                },
+                "density": {
+                    "type": "number"
+                },
+                "food_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nutrition": {
+                    "$ref": "#/definitions/entity.Serving"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.FoodQuality": {
+            "type": "object",
+            "properties": {
+                "nutri_score": {
+                    "$ref": "#/definitions/entity.NutriScore"
+                },
+                "nutrient_density": {
+                    "type": "number",
+                    "example": 42.5
+                }
+            }
+        },
+        "entity.FoodSearchResponse": {
+            "type": "object",
+            "properties": {
+                "foods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Food"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total_results": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.FoodSuggestion": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1.5
+                },
+                "brand_name": {
+                    "type": "string"
+                },
+                "fit": {
+                    "type": "number",
+                    "example": 87.5
+                },
+                "flags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.FoodFlag"
+                    }
+                },
+                "food_id": {
+                    "type": "string",
+                    "example": "fs:33691"
+                },
+                "food_name": {
+                    "type": "string"
+                },
+                "nutrition": {
+                    "$ref": "#/definitions/entity.Serving"
+                },
+                "remaining": {
+                    "$ref": "#/definitions/entity.MacroBudget"
+                },
+                "serving_id": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "favorite"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "serving"
+                }
+            }
+        },
+        "entity.GlucoseMealReport": {
+            "type": "object",
+            "properties": {
+                "correlation": {
+                    "type": "number",
+                    "example": 0.62
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2026-01-01"
+                },
+                "meals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.MealGlucose"
+                    }
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "window_minutes": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "entity.GlucoseReading": {
+            "type": "object",
+            "properties": {
+                "context": {
+                    "type": "string",
+                    "example": "after_meal"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "measured_at": {
+                    "type": "string"
+                },
+                "mg_dl": {
+                    "type": "number",
+                    "example": 112
+                },
+                "mmol_l": {
+                    "type": "number",
+                    "example": 6.2
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.GlucoseReadingInput": {
+            "type": "object",
+            "required": [
+                "value"
+            ],
+            "properties": {
+                "context": {
+                    "type": "string",
+                    "enum": [
+                        "fasting",
+                        "before_meal",
+                        "after_meal",
+                        "bedtime",
+                        "random"
+                    ],
+                    "example": "after_meal"
+                },
+                "measured_at": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "unit": {
+                    "type": "string",
+                    "enum": [
+                        "mg/dL",
+                        "mmol/L"
+                    ],
+                    "example": "mg/dL"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 112
+                }
+            }
+        },
+        "entity.GlycemicDay": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.GlycemicEntry"
+                    }
+                },
+                "meals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.GlycemicMeal"
+                    }
+                },
+                "totals": {
+                    "$ref": "#/definitions/entity.GlycemicTotals"
+                }
+            }
+        },
+        "entity.GlycemicEntry": {
+            "type": "object",
+            "properties": {
+                "carbs": {
+                    "type": "number",
+                    "example": 27
+                },
+                "eaten_at": {
+                    "type": "string"
+                },
+                "entry_id": {
+                    "type": "integer"
+                },
+                "fiber": {
+                    "type": "number",
+                    "example": 3.1
+                },
+                "food_id": {
+                    "type": "string",
+                    "example": "fs:33691"
+                },
+                "food_name": {
+                    "type": "string"
+                },
+                "glycemic_index": {
+                    "$ref": "#/definitions/entity.GlycemicIndexMatch"
+                },
+                "glycemic_load": {
+                    "type": "number",
+                    "example": 12.2
+                },
+                "load_class": {
+                    "type": "string",
+                    "example": "medium"
+                },
+                "meal": {
+                    "type": "string",
+                    "example": "breakfast"
+                },
+                "net_carbs": {
+                    "type": "number",
+                    "example": 23.9
+                }
+            }
+        },
+        "entity.GlycemicIndexMatch": {
+            "type": "object",
+            "properties": {
+                "class": {
+                    "type": "string",
+                    "example": "low"
+                },
+                "glycemic_index": {
+                    "type": "integer",
+                    "example": 51
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "Banana"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "name"
+                }
+            }
+        },
+        "entity.GlycemicMeal": {
+            "type": "object",
+            "properties": {
+                "meal": {
+                    "type": "string",
+                    "example": "breakfast"
+                },
+                "totals": {
+                    "$ref": "#/definitions/entity.GlycemicTotals"
+                }
+            }
+        },
+        "entity.GlycemicTotals": {
+            "type": "object",
+            "properties": {
+                "average_index": {
+                    "type": "number",
+                    "example": 56.8
+                },
+                "carbs": {
+                    "type": "number",
+                    "example": 210
+                },
+                "coverage": {
+                    "type": "number",
+                    "example": 91.5
+                },
+                "fiber": {
+                    "type": "number",
+                    "example": 28
+                },
+                "glycemic_load": {
+                    "type": "number",
+                    "example": 96.4
+                },
+                "load_class": {
+                    "type": "string",
+                    "example": "medium"
+                },
+                "net_carbs": {
+                    "type": "number",
+                    "example": 182
+                }
+            }
+        },
+        "entity.GoalDay": {
+            "type": "object",
+            "properties": {
+                "target": {
+                    "$ref": "#/definitions/entity.NutritionTarget"
+                },
+                "weekday": {
+                    "type": "string",
+                    "example": "monday"
+                }
+            }
+        },
+        "entity.GoalScheduleInput": {
+            "type": "object",
+            "required": [
+                "weekdays"
+            ],
+            "properties": {
+                "label": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "example": "training"
+                },
+                "target": {
+                    "$ref": "#/definitions/entity.MacroTargetInput"
+                },
+                "weekdays": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "monday",
+                        "wednesday",
+                        "friday"
+                    ]
+                }
+            }
+        },
+        "entity.HydrationDay": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.WaterEntry"
+                    }
+                },
+                "summary": {
+                    "$ref": "#/definitions/entity.HydrationSummary"
+                }
+            }
+        },
+        "entity.HydrationSummary": {
+            "type": "object",
+            "properties": {
+                "beverages": {
+                    "type": "number",
+                    "example": 450
+                },
+                "exercise": {
+                    "type": "number",
+                    "example": 250
+                },
+                "foods": {
+                    "type": "number",
+                    "example": 220
+                },
+                "progress": {
+                    "type": "number",
+                    "example": 69.3
+                },
+                "remaining": {
+                    "type": "number",
+                    "example": 960
+                },
+                "target": {
+                    "type": "number",
+                    "example": 3130
+                },
+                "target_source": {
+                    "type": "string",
+                    "example": "weight"
+                },
+                "total": {
+                    "type": "number",
+                    "example": 2170
+                },
+                "water": {
+                    "type": "number",
+                    "example": 1500
+                }
+            }
+        },
+        "entity.ImportedIngredient": {
+            "type": "object",
+            "properties": {
+                "alternatives": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Food"
+                    }
+                },
+                "confidence": {
+                    "type": "number"
+                },
+                "food": {
+                    "type": "string",
+                    "example": "milk"
+                },
+                "ingredient": {
+                    "$ref": "#/definitions/entity.RecipeIngredientInput"
+                },
+                "line": {
+                    "type": "string",
+                    "example": "1 1/2 cups milk"
+                },
+                "match": {
+                    "$ref": "#/definitions/entity.Food"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.LogSavedMealInput": {
+            "type": "object",
+            "required": [
+                "date"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "eaten_at": {
+                    "type": "string"
+                },
+                "meal": {
+                    "type": "string",
+                    "enum": [
+                        "breakfast",
+                        "lunch",
+                        "dinner",
+                        "snack"
+                    ],
+                    "example": "breakfast"
+                },
+                "scale": {
+                    "type": "number",
+                    "example": 1
+                }
+            }
+        },
+        "entity.LoggingCompleteness": {
+            "type": "object",
+            "properties": {
+                "average_meals": {
+                    "type": "number",
+                    "example": 3.2
+                },
+                "complete_days": {
+                    "type": "integer",
+                    "example": 22
+                },
+                "complete_pct": {
+                    "type": "number",
+                    "example": 71
+                },
+                "days": {
+                    "type": "integer",
+                    "example": 31
+                },
+                "days_logged": {
+                    "type": "integer",
+                    "example": 28
+                },
+                "logged_pct": {
+                    "type": "number",
+                    "example": 90.3
+                }
+            }
+        },
+        "entity.MacroBudget": {
+            "type": "object",
+            "properties": {
+                "calories": {
+                    "type": "number",
+                    "example": 300
+                },
+                "carbs": {
+                    "type": "number",
+                    "example": 10
+                },
+                "fat": {
+                    "type": "number",
+                    "example": 8
+                },
+                "protein": {
+                    "type": "number",
+                    "example": 40
+                }
+            }
+        },
+        "entity.MacroDistribution": {
+            "type": "object",
+            "properties": {
+                "carbs_pct": {
+                    "type": "number",
+                    "example": 45.2
+                },
+                "fat_pct": {
+                    "type": "number",
+                    "example": 32.7
+                },
+                "protein_pct": {
+                    "type": "number",
+                    "example": 22.1
+                }
+            }
+        },
+        "entity.MacroPreset": {
+            "type": "object",
+            "properties": {
+                "carbs_pct": {
+                    "type": "number",
+                    "example": 40
+                },
+                "code": {
+                    "type": "string",
+                    "example": "high_protein"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "fat_pct": {
+                    "type": "number",
+                    "example": 30
+                },
+                "name": {
+                    "type": "string",
+                    "example": "High protein"
+                },
+                "net_carbs": {
+                    "type": "boolean"
+                },
+                "protein_pct": {
+                    "type": "number",
+                    "example": 30
+                }
+            }
+        },
+        "entity.MacroRange": {
+            "type": "object",
+            "properties": {
+                "max": {
+                    "type": "number",
+                    "example": 35
+                },
+                "min": {
+                    "type": "number",
+                    "example": 20
+                },
+                "nutrient": {
+                    "type": "string",
+                    "example": "fat"
+                }
+            }
+        },
+        "entity.MacroRangeCheck": {
+            "type": "object",
+            "properties": {
+                "max": {
+                    "type": "number",
+                    "example": 35
+                },
+                "min": {
+                    "type": "number",
+                    "example": 20
+                },
+                "nutrient": {
+                    "type": "string",
+                    "example": "fat"
+                },
+                "percent": {
+                    "type": "number",
+                    "example": 38.4
+                },
+                "status": {
+                    "type": "string",
+                    "example": "above"
+                }
+            }
+        },
+        "entity.MacroTargetInput": {
+            "type": "object",
+            "required": [
+                "preset"
+            ],
+            "properties": {
+                "calories": {
+                    "type": "number",
+                    "maximum": 10000,
+                    "example": 2200
+                },
+                "carbs": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 40
+                },
+                "fat": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 30
+                },
+                "net_carbs": {
+                    "type": "boolean"
+                },
+                "preset": {
+                    "type": "string",
+                    "enum": [
+                        "balanced",
+                        "high_protein",
+                        "low_carb",
+                        "keto",
+                        "custom"
+                    ],
+                    "example": "high_protein"
+                },
+                "protein": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 30
+                },
+                "split": {
+                    "type": "string",
+                    "enum": [
+                        "percent",
+                        "grams"
+                    ],
+                    "example": "percent"
+                }
+            }
+        },
+        "entity.MealGlucose": {
+            "type": "object",
+            "properties": {
+                "baseline": {
+                    "type": "number",
+                    "example": 95
+                },
+                "eaten_at": {
+                    "type": "string"
+                },
+                "foods": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "meal": {
+                    "type": "string",
+                    "example": "lunch"
+                },
+                "minutes_to_peak": {
+                    "type": "integer",
+                    "example": 45
+                },
+                "peak": {
+                    "type": "number",
+                    "example": 148
+                },
+                "readings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.GlucoseReading"
+                    }
+                },
+                "rise": {
+                    "type": "number",
+                    "example": 53
+                },
+                "totals": {
+                    "$ref": "#/definitions/entity.GlycemicTotals"
+                }
+            }
+        },
+        "entity.MealParseCandidate": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "confidence": {
+                    "type": "number"
+                },
+                "food": {
+                    "$ref": "#/definitions/entity.Food"
+                },
+                "nutrition": {
+                    "$ref": "#/definitions/entity.Serving"
+                },
+                "serving": {
+                    "$ref": "#/definitions/entity.Serving"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "serving"
+                }
+            }
+        },
+        "entity.MealParseItem": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.MealParseCandidate"
+                    }
+                },
+                "entry": {
+                    "$ref": "#/definitions/entity.DiaryEntryInput"
+                },
+                "food": {
+                    "type": "string",
+                    "example": "whole wheat toast"
+                },
+                "phrase": {
+                    "type": "string",
+                    "example": "a slice of whole wheat toast"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "size": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.MealParseRequest": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "meal": {
+                    "type": "string",
+                    "enum": [
+                        "breakfast",
+                        "lunch",
+                        "dinner",
+                        "snack"
+                    ],
+                    "example": "breakfast"
+                },
+                "text": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "2 scrambled eggs, a slice of whole wheat toast and a coffee with milk"
+                }
+            }
+        },
+        "entity.MealParseResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.MealParseItem"
+                    }
+                },
+                "unresolved": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.Measurement": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "waist"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "cm"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 84.5
+                }
+            }
+        },
+        "entity.MeasurementInput": {
+            "type": "object",
+            "required": [
+                "date",
+                "values"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "values": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/entity.MeasurementValueInput"
+                    }
+                }
+            }
+        },
+        "entity.MeasurementValueInput": {
+            "type": "object",
+            "required": [
+                "type",
+                "value"
+            ],
+            "properties": {
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "waist",
+                        "hips",
+                        "neck",
+                        "chest",
+                        "arm",
+                        "thigh",
+                        "body_fat",
+                        "lean_mass"
+                    ],
+                    "example": "waist"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "in"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 33.3
+                }
+            }
+        },
+        "entity.NutriScore": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "general"
+                },
+                "grade": {
+                    "type": "string",
+                    "example": "b"
+                },
+                "negative_points": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "positive_points": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "entity.NutrientAdequacy": {
+            "type": "object",
+            "properties": {
+                "excess": {
+                    "type": "number",
+                    "example": 820
+                },
+                "gap": {
+                    "type": "number"
+                },
+                "intake": {
+                    "type": "number",
+                    "example": 3120
+                },
+                "nutrient": {
+                    "type": "string",
+                    "example": "sodium"
+                },
+                "percent": {
+                    "type": "number",
+                    "example": 208
+                },
+                "reference": {
+                    "type": "number",
+                    "example": 1500
+                },
+                "status": {
+                    "type": "string",
+                    "example": "over_limit"
+                },
+                "top_foods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.TopFood"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "example": "ai"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "mg"
+                },
+                "upper_limit": {
+                    "type": "number",
+                    "example": 2300
+                }
+            }
+        },
+        "entity.NutrientAnalysis": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer",
+                    "example": 35
+                },
+                "days_logged": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2026-01-25"
+                },
+                "group": {
+                    "type": "string",
+                    "example": "31-50"
+                },
+                "nutrients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.NutrientAdequacy"
+                    }
+                },
+                "ranges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.MacroRangeCheck"
+                    }
+                },
+                "sex": {
+                    "type": "string",
+                    "example": "female"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                }
+            }
+        },
+        "entity.NutrientComparison": {
+            "type": "object",
+            "properties": {
+                "alternative": {
+                    "type": "number",
+                    "example": 4.1
+                },
+                "change": {
+                    "type": "number",
+                    "example": -66.9
+                },
+                "effect": {
+                    "type": "string",
+                    "example": "better"
+                },
+                "nutrient": {
+                    "type": "string",
+                    "example": "sugar"
+                },
+                "original": {
+                    "type": "number",
+                    "example": 12.4
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "g"
+                }
+            }
+        },
+        "entity.NutrientReference": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1500
+                },
+                "nutrient": {
+                    "type": "string",
+                    "example": "sodium"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "ai"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "mg"
+                },
+                "upper_limit": {
+                    "type": "number",
+                    "example": 2300
+                }
+            }
+        },
+        "entity.NutrientReferences": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer",
+                    "example": 35
+                },
+                "group": {
+                    "type": "string",
+                    "example": "31-50"
+                },
+                "ranges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.MacroRange"
+                    }
+                },
+                "references": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.NutrientReference"
+                    }
+                },
+                "sex": {
+                    "type": "string",
+                    "example": "female"
+                }
+            }
+        },
+        "entity.NutrientTopFoods": {
+            "type": "object",
+            "properties": {
+                "foods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.TopFood"
+                    }
+                },
+                "nutrient": {
+                    "type": "string",
+                    "example": "protein"
+                }
+            }
+        },
+        "entity.NutritionGoal": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.GoalDay"
+                    }
+                },
+                "effective_from": {
+                    "type": "string",
+                    "example": "2026-02-01"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.NutritionGoalInput": {
+            "type": "object",
+            "required": [
+                "effective_from"
+            ],
+            "properties": {
+                "effective_from": {
+                    "type": "string",
+                    "example": "2026-02-01"
+                },
+                "label": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "example": "rest"
+                },
+                "schedules": {
+                    "type": "array",
+                    "maxItems": 7,
+                    "items": {
+                        "$ref": "#/definitions/entity.GoalScheduleInput"
+                    }
+                },
+                "target": {
+                    "$ref": "#/definitions/entity.MacroTargetInput"
+                }
+            }
+        },
+        "entity.NutritionReport": {
+            "type": "object",
+            "properties": {
+                "adherence": {
+                    "$ref": "#/definitions/entity.ReportAdherence"
+                },
+                "average": {
+                    "$ref": "#/definitions/entity.Serving"
+                },
+                "best_days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ReportDay"
+                    }
+                },
+                "completeness": {
+                    "$ref": "#/definitions/entity.LoggingCompleteness"
+                },
+                "distribution": {
+                    "$ref": "#/definitions/entity.MacroDistribution"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2026-01-01"
+                },
+                "granularity": {
+                    "type": "string",
+                    "example": "week"
+                },
+                "periods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ReportPeriod"
+                    }
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "top_foods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.NutrientTopFoods"
+                    }
+                },
+                "worst_days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ReportDay"
+                    }
+                }
+            }
+        },
+        "entity.NutritionTarget": {
+            "type": "object",
+            "properties": {
+                "calories": {
+                    "type": "number",
+                    "example": 2200
+                },
+                "carbs": {
+                    "type": "number",
+                    "example": 220
+                },
+                "carbs_pct": {
+                    "type": "number",
+                    "example": 40
+                },
+                "fat": {
+                    "type": "number",
+                    "example": 73.3
+                },
+                "fat_pct": {
+                    "type": "number",
+                    "example": 30
+                },
+                "label": {
+                    "type": "string",
+                    "example": "training"
+                },
+                "net_carbs": {
+                    "type": "boolean"
+                },
+                "preset": {
+                    "type": "string",
+                    "example": "high_protein"
+                },
+                "protein": {
+                    "type": "number",
+                    "example": 165
+                },
+                "protein_pct": {
+                    "type": "number",
+                    "example": 30
+                }
+            }
+        },
+        "entity.Profile": {
+            "type": "object",
+            "properties": {
+                "birth_date": {
+                    "type": "string",
+                    "example": "1990-04-12"
+                },
+                "height": {
+                    "type": "number",
+                    "example": 168
+                },
+                "sex": {
+                    "type": "string",
+                    "example": "female"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ProfileInput": {
+            "type": "object",
+            "properties": {
+                "birth_date": {
+                    "type": "string",
+                    "example": "1990-04-12"
+                },
+                "height": {
+                    "type": "number",
+                    "example": 168
+                },
+                "height_unit": {
+                    "type": "string",
+                    "enum": [
+                        "cm",
+                        "in"
+                    ],
+                    "example": "cm"
+                },
+                "sex": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female"
+                    ],
+                    "example": "female"
+                }
+            }
+        },
+        "entity.RecentFood": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "brand_name": {
+                    "type": "string"
+                },
+                "calories": {
+                    "type": "number"
+                },
+                "food": {
+                    "$ref": "#/definitions/entity.FoodDetails"
+                },
+                "food_id": {
+                    "type": "string",
+                    "example": "fs:33691"
+                },
+                "food_name": {
+                    "type": "string"
+                },
+                "last_logged_at": {
+                    "type": "string"
+                },
+                "log_count": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "serving_id": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Recipe": {
+            "type": "object",
+            "properties": {
+                "computed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "flags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.FoodFlag"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "recipe:12"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.RecipeIngredient"
+                    }
+                },
+                "instructions": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "per_serving": {
+                    "$ref": "#/definitions/entity.Serving"
+                },
+                "servings": {
+                    "type": "number"
+                },
+                "total": {
+                    "$ref": "#/definitions/entity.Serving"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "yield_amount": {
+                    "type": "number"
+                },
+                "yield_unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.RecipeImportDraft": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "type": "string"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ImportedIngredient"
+                    }
+                },
+                "recipe": {
+                    "$ref": "#/definitions/entity.RecipeInput"
+                },
+                "unmatched": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.RecipeImportRequest": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "auto",
+                        "jsonld",
+                        "html",
+                        "text"
+                    ],
+                    "example": "auto"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Pancakes"
+                },
+                "servings": {
+                    "type": "number",
+                    "example": 4
+                }
+            }
+        },
+        "entity.RecipeIngredient": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "brand_name": {
+                    "type": "string"
+                },
+                "food_id": {
+                    "type": "string",
+                    "example": "fs:33691"
+                },
+                "food_name": {
+                    "type": "string"
+                },
+                "nutrition": {
+                    "$ref": "#/definitions/entity.Serving"
+                },
+                "serving_id": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.RecipeIngredientInput": {
+            "type": "object",
+            "required": [
+                "amount",
+                "food_id",
+                "unit"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 200
+                },
+                "food_id": {
+                    "type": "string",
+                    "example": "fs:33691"
+                },
+                "serving_id": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "g"
+                }
+            }
+        },
+        "entity.RecipeInput": {
+            "type": "object",
+            "required": [
+                "ingredients",
+                "name",
+                "servings"
+            ],
+            "properties": {
+                "ingredients": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/entity.RecipeIngredientInput"
+                    }
+                },
+                "instructions": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Chili con carne"
+                },
+                "servings": {
+                    "type": "number",
+                    "example": 4
+                },
+                "yield_amount": {
+                    "type": "number",
+                    "example": 1.2
+                },
+                "yield_unit": {
+                    "type": "string",
+                    "example": "kg"
+                }
+            }
+        },
+        "entity.ReportAdherence": {
+            "type": "object",
+            "properties": {
+                "calories": {
+                    "type": "number",
+                    "example": 73.1
+                },
+                "carbs": {
+                    "type": "number",
+                    "example": 57.7
+                },
+                "days": {
+                    "type": "integer",
+                    "example": 26
+                },
+                "fat": {
+                    "type": "number",
+                    "example": 61.5
+                },
+                "protein": {
+                    "type": "number",
+                    "example": 65.4
+                }
+            }
+        },
+        "entity.ReportDay": {
+            "type": "object",
+            "properties": {
+                "calories": {
+                    "type": "number",
+                    "example": 2080
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-14"
+                },
+                "deviation": {
+                    "type": "number",
+                    "example": -1
+                },
+                "target": {
+                    "type": "number",
+                    "example": 2100
+                }
+            }
+        },
+        "entity.ReportPeriod": {
+            "type": "object",
+            "properties": {
+                "adherence": {
+                    "$ref": "#/definitions/entity.ReportAdherence"
+                },
+                "average": {
+                    "$ref": "#/definitions/entity.Serving"
+                },
+                "days": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "days_logged": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "distribution": {
+                    "$ref": "#/definitions/entity.MacroDistribution"
+                },
+                "end": {
+                    "type": "string",
+                    "example": "2026-01-11"
+                },
+                "start": {
+                    "type": "string",
+                    "example": "2026-01-05"
+                }
+            }
+        },
+        "entity.RestrictionOptions": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "diets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "entity.RestrictionProfile": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "peanuts",
+                        "milk"
+                    ]
+                },
+                "diets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vegetarian"
+                    ]
+                },
+                "strict": {
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.RestrictionProfileInput": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "maxItems": 14,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "peanuts",
+                        "milk"
+                    ]
+                },
+                "diets": {
+                    "type": "array",
+                    "maxItems": 5,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vegetarian"
+                    ]
+                },
+                "strict": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "entity.SavedMeal": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.SavedMealItem"
+                    }
+                },
+                "meal": {
+                    "type": "string",
+                    "example": "breakfast"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Usual breakfast"
+                },
+                "totals": {
+                    "$ref": "#/definitions/entity.Serving"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.SavedMealFromDiaryInput": {
+            "type": "object",
+            "required": [
+                "date",
+                "meal",
+                "name"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "meal": {
+                    "type": "string",
+                    "enum": [
+                        "breakfast",
+                        "lunch",
+                        "dinner",
+                        "snack"
+                    ],
+                    "example": "breakfast"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Usual breakfast"
+                }
+            }
+        },
+        "entity.SavedMealInput": {
+            "type": "object",
+            "required": [
+                "items",
+                "name"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/entity.SavedMealItemInput"
+                    }
+                },
+                "meal": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsI..."
+                    "enum": [
+                        "breakfast",
+                        "lunch",
+                        "dinner",
+                        "snack"
+                    ],
+                    "example": "breakfast"
                 },
-                "user": {
-                    "$ref": "#/definitions/entity.UserResponse"
+                "name": {
+                    "type": "string",
+                    "example": "Usual breakfast"
                 }
             }
         },
-        "entity.Food": {
+        "entity.SavedMealItem": {
             "type": "object",
             "properties": {
-                "brand_name": {
-                    "type": "string"
-                },
-                "calories": {
-                    "type": "number"
-                },
-                "carbs": {
-                    "type": "number"
-                },
-                "fat": {
+                "amount": {
                     "type": "number"
                 },
-                "id": {
-                    "type": "string"
-                },
-                "name": {
+                "brand_name": {
                     "type": "string"
                 },
-                "protein": {
-                    "type": "number"
+                "food_id": {
+                    "type": "string",
+                    "example": "fs:33691"
                 },
-                "type": {
+                "food_name": {
                     "type": "string"
                 },
-                "url": {
-                    "type": "string"
-                }
-            }
-        },
-        "entity.FoodDetails": {
-            "type": "object",
-            "properties": {
-                "brand_name": {
-                    "type": "string"
+                "nutrition": {
+                    "$ref": "#/definitions/entity.Serving"
                 },
-                "id": {
+                "serving_id": {
                     "type": "string"
                 },
-                "name": {
+                "unit": {
                     "type": "string"
-                },
-                "servings": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.Serving"
-                    }
                 }
             }
         },
-        "entity.FoodSearchResponse": {
+        "entity.SavedMealItemInput": {
             "type": "object",
+            "required": [
+                "amount",
+                "food_id",
+                "unit"
+            ],
             "properties": {
-                "foods": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.Food"
-                    }
+                "amount": {
+                    "type": "number",
+                    "example": 1
                 },
-                "limit": {
-                    "type": "integer"
+                "food_id": {
+                    "type": "string",
+                    "example": "fs:33691"
                 },
-                "page": {
-                    "type": "integer"
+                "serving_id": {
+                    "type": "string"
                 },
-                "total_results": {
-                    "type": "integer"
+                "unit": {
+                    "type": "string",
+                    "example": "serving"
                 }
             }
         },
         "entity.Serving": {
             "type": "object",
             "properties": {
+                "calcium": {
+                    "type": "number"
+                },
                 "calories": {
                     "type": "number"
                 },
@@ -383,6 +8156,9 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
+                "iron": {
+                    "type": "number"
+                },
                 "measurement_description": {
                     "type": "string"
                 },
@@ -395,6 +8171,9 @@ const docTemplate = `{
                 "number_of_units": {
                     "type": "number"
                 },
+                "potassium": {
+                    "type": "number"
+                },
                 "protein": {
                     "type": "number"
                 },
@@ -409,6 +8188,106 @@ const docTemplate = `{
                 },
                 "url": {
                     "type": "string"
+                },
+                "vitamin_a": {
+                    "type": "number"
+                },
+                "vitamin_c": {
+                    "type": "number"
+                },
+                "vitamin_d": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.SuggestionResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "remaining": {
+                    "$ref": "#/definitions/entity.MacroBudget"
+                },
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.FoodSuggestion"
+                    }
+                },
+                "target": {
+                    "$ref": "#/definitions/entity.NutritionTarget"
+                }
+            }
+        },
+        "entity.TDEEEstimate": {
+            "type": "object",
+            "properties": {
+                "average_intake": {
+                    "type": "number",
+                    "example": 2100
+                },
+                "calories": {
+                    "type": "number",
+                    "example": 2450
+                },
+                "logged_days": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "trend_change": {
+                    "type": "number",
+                    "example": -1.27
+                },
+                "window_days": {
+                    "type": "integer",
+                    "example": 28
+                }
+            }
+        },
+        "entity.TargetProgress": {
+            "type": "object",
+            "properties": {
+                "consumed": {
+                    "type": "number",
+                    "example": 120
+                },
+                "progress": {
+                    "type": "number",
+                    "example": 72.7
+                },
+                "remaining": {
+                    "type": "number",
+                    "example": 45
+                },
+                "target": {
+                    "type": "number",
+                    "example": 165
+                }
+            }
+        },
+        "entity.TopFood": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 2340
+                },
+                "entries": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "food_id": {
+                    "type": "string",
+                    "example": "fs:33691"
+                },
+                "food_name": {
+                    "type": "string"
+                },
+                "share": {
+                    "type": "number",
+                    "example": 14.8
                 }
             }
         },
@@ -468,6 +8347,263 @@ const docTemplate = `{
                     "example": "password123"
                 }
             }
+        },
+        "entity.WaterContainer": {
+            "type": "object",
+            "properties": {
+                "custom": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "bottle"
+                },
+                "volume": {
+                    "type": "number",
+                    "example": 500
+                }
+            }
+        },
+        "entity.WaterContainerInput": {
+            "type": "object",
+            "required": [
+                "name",
+                "volume"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "sports bottle"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "ml"
+                },
+                "volume": {
+                    "type": "number",
+                    "example": 750
+                }
+            }
+        },
+        "entity.WaterEntry": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 500
+                },
+                "container": {
+                    "type": "string",
+                    "example": "bottle"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "drunk_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.WaterEntryInput": {
+            "type": "object",
+            "required": [
+                "date"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 330
+                },
+                "container": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "bottle"
+                },
+                "count": {
+                    "type": "number",
+                    "maximum": 20,
+                    "example": 1
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "drunk_at": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "ml"
+                }
+            }
+        },
+        "entity.WaterSettings": {
+            "type": "object",
+            "properties": {
+                "target": {
+                    "type": "number",
+                    "example": 2500
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.WaterSettingsInput": {
+            "type": "object",
+            "properties": {
+                "target": {
+                    "type": "number",
+                    "example": 2500
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "ml"
+                }
+            }
+        },
+        "entity.WeightEntry": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 82.4
+                }
+            }
+        },
+        "entity.WeightEntryInput": {
+            "type": "object",
+            "required": [
+                "date",
+                "weight"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "unit": {
+                    "type": "string",
+                    "enum": [
+                        "kg",
+                        "lb"
+                    ],
+                    "example": "kg"
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 82.4
+                }
+            }
+        },
+        "entity.WeightGoal": {
+            "type": "object",
+            "properties": {
+                "target_weight": {
+                    "type": "number",
+                    "example": 75
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.WeightGoalInput": {
+            "type": "object",
+            "required": [
+                "target_weight"
+            ],
+            "properties": {
+                "target_weight": {
+                    "type": "number",
+                    "example": 75
+                },
+                "unit": {
+                    "type": "string",
+                    "enum": [
+                        "kg",
+                        "lb"
+                    ],
+                    "example": "kg"
+                }
+            }
+        },
+        "entity.WeightTrend": {
+            "type": "object",
+            "properties": {
+                "current_trend": {
+                    "type": "number",
+                    "example": 82.1
+                },
+                "goal": {
+                    "$ref": "#/definitions/entity.WeightGoal"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.WeightTrendPoint"
+                    }
+                },
+                "projected_date": {
+                    "type": "string",
+                    "example": "2026-05-12"
+                },
+                "tdee": {
+                    "$ref": "#/definitions/entity.TDEEEstimate"
+                },
+                "weekly_rate": {
+                    "type": "number",
+                    "example": -0.45
+                }
+            }
+        },
+        "entity.WeightTrendPoint": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "trend": {
+                    "type": "number",
+                    "example": 82.1
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 82.4
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -2,8 +2,10 @@ package app
 
 import (
 	"log"
+	"math"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"github.com/gin-gonic/gin"
@@ -11,11 +13,13 @@ import (
 	// Import required packages
 	"CalorieCompass/internal/controller/html"
 	v1 "CalorieCompass/internal/controller/http/v1"
+	"CalorieCompass/internal/entity"
 	"CalorieCompass/internal/pkg/config"
 	"CalorieCompass/internal/pkg/hash"
 	"CalorieCompass/internal/pkg/httpserver"
 	"CalorieCompass/internal/repository/fatsecret"
 	"CalorieCompass/internal/repository/postgres"
+	"CalorieCompass/internal/repository/registry"
 	"CalorieCompass/internal/repository/token"
 	"CalorieCompass/internal/service"
 	"CalorieCompass/internal/usecase"
@@ -47,12 +51,34 @@ func Run(configPath string) {
 		cfg.FatSecret.ClientSecret,
 		cfg.FatSecret.ConsumerKey,
 		cfg.FatSecret.ConsumerSecret,
+		cfg.FatSecret.FoodAttributes,
 	)
 
 	// Repositories
 	userRepo := postgres.NewUserRepo(postgresDB.DB)
 	jwtRepo := token.NewJWTRepo(cfg.JWT.Secret, cfg.JWT.ExpirationHour)
-	foodRepo := fatsecret.NewFoodRepository(fatSecretService)
+	customFoodRepo := postgres.NewCustomFoodRepo(postgresDB.DB)
+	foodRepo := newFoodRegistry(cfg.Food.Providers, customFoodRepo, map[string]registry.FoodSource{
+		entity.FoodSourceFatSecret:        fatsecret.NewFoodRepository(fatSecretService),
+		entity.FoodSourceCatalog:          postgres.NewCatalogRepo(postgresDB.DB, ""),
+		entity.CatalogSourceUSDA:          postgres.NewCatalogRepo(postgresDB.DB, entity.CatalogSourceUSDA),
+		entity.CatalogSourceOpenFoodFacts: postgres.NewCatalogRepo(postgresDB.DB, entity.CatalogSourceOpenFoodFacts),
+	})
+	diaryRepo := postgres.NewDiaryRepo(postgresDB.DB)
+	favoriteRepo := postgres.NewFavoriteRepo(postgresDB.DB)
+	recipeRepo := postgres.NewRecipeRepo(postgresDB.DB)
+	savedMealRepo := postgres.NewSavedMealRepo(postgresDB.DB)
+	weightRepo := postgres.NewWeightRepo(postgresDB.DB)
+	profileRepo := postgres.NewProfileRepo(postgresDB.DB)
+	measurementRepo := postgres.NewMeasurementRepo(postgresDB.DB)
+	exerciseRepo := postgres.NewExerciseRepo(postgresDB.DB)
+	waterRepo := postgres.NewWaterRepo(postgresDB.DB)
+	fastingRepo := postgres.NewFastingRepo(postgresDB.DB)
+	goalRepo := postgres.NewGoalRepo(postgresDB.DB)
+	reportRepo := postgres.NewReportRepo(postgresDB.DB)
+	catalogRepo := postgres.NewCatalogRepo(postgresDB.DB, "")
+	restrictionRepo := postgres.NewRestrictionRepo(postgresDB.DB)
+	glucoseRepo := postgres.NewGlucoseRepo(postgresDB.DB)
 
 	// Hasher
 	hasher := hash.NewHasher(14)
@@ -60,7 +86,27 @@ func Run(configPath string) {
 	// Use cases
 	authUseCase := usecase.NewAuthUseCase(userRepo, jwtRepo, hasher)
 	userUseCase := usecase.NewUserUseCase(userRepo)
-	foodUseCase := usecase.NewFoodUseCase(foodRepo)
+	foodUseCase := usecase.NewFoodUseCase(foodRepo, customFoodRepo, recipeRepo, restrictionRepo)
+	recipeUseCase := usecase.NewRecipeUseCase(recipeRepo, foodUseCase, restrictionRepo)
+	restrictionUseCase := usecase.NewRestrictionUseCase(restrictionRepo)
+	recipeImportUseCase := usecase.NewRecipeImportUseCase(foodUseCase)
+	customFoodUseCase := usecase.NewCustomFoodUseCase(customFoodRepo, recipeUseCase)
+	waterUseCase := usecase.NewWaterUseCase(waterRepo, weightRepo, profileRepo, diaryRepo, exerciseRepo)
+	goalUseCase := usecase.NewGoalUseCase(goalRepo)
+	diaryUseCase := usecase.NewDiaryUseCase(diaryRepo, foodUseCase, exerciseRepo, waterUseCase, goalUseCase)
+	fastingUseCase := usecase.NewFastingUseCase(fastingRepo, diaryRepo)
+	reportUseCase := usecase.NewReportUseCase(reportRepo, diaryRepo, exerciseRepo, goalUseCase)
+	nutrientUseCase := usecase.NewNutrientUseCase(profileRepo, diaryRepo, reportRepo)
+	mealParseUseCase := usecase.NewMealParseUseCase(foodUseCase)
+	favoriteUseCase := usecase.NewFavoriteUseCase(favoriteRepo, diaryRepo, foodUseCase)
+	suggestionUseCase := usecase.NewSuggestionUseCase(diaryUseCase, favoriteRepo, diaryRepo, catalogRepo, foodUseCase, restrictionRepo)
+	alternativeUseCase := usecase.NewAlternativeUseCase(foodUseCase, catalogRepo, restrictionRepo)
+	savedMealUseCase := usecase.NewSavedMealUseCase(savedMealRepo, diaryRepo, diaryUseCase, foodUseCase)
+	weightUseCase := usecase.NewWeightUseCase(weightRepo, diaryRepo)
+	bodyUseCase := usecase.NewBodyUseCase(profileRepo, measurementRepo, weightRepo)
+	exerciseUseCase := usecase.NewExerciseUseCase(exerciseRepo, weightRepo)
+	glycemicUseCase := usecase.NewGlycemicUseCase(diaryRepo, catalogRepo)
+	glucoseUseCase := usecase.NewGlucoseUseCase(glucoseRepo, diaryRepo, glycemicUseCase)
 
 	// HTTP Server
 	router := gin.Default()
@@ -77,7 +123,29 @@ func Run(configPath string) {
 	authController := v1.NewAuthController(authUseCase)
 	userController := v1.NewUserController(userUseCase)
 	foodController := v1.NewFoodController(foodUseCase)
-	v1.NewRouter(router, authController, userController, foodController, jwtRepo)
+	customFoodController := v1.NewCustomFoodController(customFoodUseCase)
+	favoriteController := v1.NewFavoriteController(favoriteUseCase)
+	diaryController := v1.NewDiaryController(diaryUseCase, mealParseUseCase)
+	recipeController := v1.NewRecipeController(recipeUseCase, recipeImportUseCase)
+	savedMealController := v1.NewSavedMealController(savedMealUseCase)
+	weightController := v1.NewWeightController(weightUseCase)
+	bodyController := v1.NewBodyController(bodyUseCase)
+	exerciseController := v1.NewExerciseController(exerciseUseCase)
+	waterController := v1.NewWaterController(waterUseCase)
+	fastingController := v1.NewFastingController(fastingUseCase)
+	goalController := v1.NewGoalController(goalUseCase)
+	reportController := v1.NewReportController(reportUseCase)
+	nutrientController := v1.NewNutrientController(nutrientUseCase)
+	suggestionController := v1.NewSuggestionController(suggestionUseCase)
+	alternativeController := v1.NewAlternativeController(alternativeUseCase)
+	restrictionController := v1.NewRestrictionController(restrictionUseCase)
+	glycemicController := v1.NewGlycemicController(glycemicUseCase)
+	glucoseController := v1.NewGlucoseController(glucoseUseCase)
+	v1.NewRouter(router, authController, userController, foodController, customFoodController, favoriteController,
+		diaryController, recipeController, savedMealController, weightController, bodyController, exerciseController,
+		waterController, fastingController, goalController, reportController, nutrientController,
+		suggestionController, alternativeController, restrictionController, glycemicController, glucoseController,
+		jwtRepo)
 
 	// HTML controllers
 	htmlAuthController := html.NewAuthController(authUseCase)
@@ -110,3 +178,29 @@ func Run(configPath string) {
 		log.Printf("server shutdown error: %s", err)
 	}
 }
+
+// newFoodRegistry registers the enabled providers from config, skipping names that have no
+// implementation, and the user's custom foods ahead of them
+func newFoodRegistry(providers []config.FoodProvider, customFoods registry.FoodSearcher, sources map[string]registry.FoodSource) *registry.FoodRegistry {
+	foodRegistry := registry.NewFoodRegistry(entity.FoodSourceFatSecret)
+	for _, p := range providers {
+		if !p.Enabled {
+			continue
+		}
+		source, ok := sources[p.Name]
+		if !ok {
+			log.Printf("food provider %s is not available, skipping", p.Name)
+			continue
+		}
+		foodRegistry.Register(p.Name, p.Priority, p.Timeout, source)
+	}
+
+	if len(foodRegistry.Sources()) == 0 {
+		log.Printf("no food providers enabled, falling back to %s", entity.FoodSourceFatSecret)
+		foodRegistry.Register(entity.FoodSourceFatSecret, 0, 0, sources[entity.FoodSourceFatSecret])
+	}
+
+	foodRegistry.RegisterSearch(entity.FoodSourceCustom, math.MinInt, 0, customFoods)
+	log.Printf("food providers: %s", strings.Join(foodRegistry.Sources(), ", "))
+	return foodRegistry
+}
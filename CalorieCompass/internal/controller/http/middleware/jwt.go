@@ -37,4 +37,17 @@ func JWTAuth(tokenRepo TokenValidator) gin.HandlerFunc {
 		c.Set("userID", userID)
 		c.Next()
 	}
-}
\ No newline at end of file
+}
+
+// OptionalJWTAuth sets userID when a valid bearer token is present but lets anonymous requests through
+func OptionalJWTAuth(tokenRepo TokenValidator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parts := strings.Split(c.GetHeader("Authorization"), " ")
+		if len(parts) == 2 && parts[0] == "Bearer" {
+			if userID, err := tokenRepo.ValidateToken(parts[1]); err == nil {
+				c.Set("userID", userID)
+			}
+		}
+		c.Next()
+	}
+}
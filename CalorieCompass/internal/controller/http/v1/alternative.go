@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"CalorieCompass/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// AlternativeUseCase defines the interface for healthier substitution business logic
+type AlternativeUseCase interface {
+	Alternatives(ctx context.Context, userID int64, foodID, servingID string, limit int) (*entity.FoodAlternatives, error)
+}
+
+// AlternativeController handles HTTP requests for healthier food alternatives
+type AlternativeController struct {
+	alternativeUseCase AlternativeUseCase
+}
+
+// NewAlternativeController creates a new alternative controller
+func NewAlternativeController(alternativeUseCase AlternativeUseCase) *AlternativeController {
+	return &AlternativeController{
+		alternativeUseCase: alternativeUseCase,
+	}
+}
+
+// @Summary Get healthier alternatives
+// @Description Find catalog foods in the same category and with similar names that have fewer calories per gram, less sugar or sodium, or more fiber or protein. Each alternative is compared with the food over the same serving, nutrient by nutrient, with an explanation of what improves and what gets worse. Foods containing one of the user's allergens are left out, as are diet conflicts under a strict restriction profile; other diet conflicts are flagged.
+// @Tags food
+// @Produce json
+// @Security BearerAuth
+// @Param food_id path string true "Food ID"
+// @Param serving_id query string false "Serving to compare over (defaults to the first serving with a metric amount)"
+// @Param limit query int false "Maximum number of alternatives (max 20)" default(5)
+// @Success 200 {object} entity.FoodAlternatives
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /food/{food_id}/alternatives [get]
+func (c *AlternativeController) Alternatives(ctx *gin.Context) {
+	foodID := ctx.Param("food_id")
+	if foodID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "food_id parameter is required"})
+		return
+	}
+
+	limit := 0
+	if limitStr := ctx.Query("limit"); limitStr != "" {
+		limitVal, err := strconv.Atoi(limitStr)
+		if err != nil || limitVal <= 0 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+			return
+		}
+		limit = limitVal
+	}
+
+	alternatives, err := c.alternativeUseCase.Alternatives(ctx.Request.Context(), ctx.GetInt64("userID"), foodID,
+		ctx.Query("serving_id"), limit)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, alternatives)
+}
@@ -0,0 +1,243 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// bodyDefaultDays is the default date range of measurement lists and body series
+const bodyDefaultDays = 365
+
+// BodyUseCase defines the interface for body profile, measurement and composition logic
+type BodyUseCase interface {
+	GetProfile(ctx context.Context, userID int64) (*entity.Profile, error)
+	UpdateProfile(ctx context.Context, userID int64, input entity.ProfileInput) (*entity.Profile, error)
+	RecordMeasurements(ctx context.Context, userID int64, input entity.MeasurementInput) ([]entity.Measurement, error)
+	ListMeasurements(ctx context.Context, userID int64, types []string, from, to time.Time) ([]entity.Measurement, error)
+	DeleteMeasurement(ctx context.Context, userID, id int64) error
+	Composition(ctx context.Context, userID int64, date time.Time) (*entity.BodyComposition, error)
+	Series(ctx context.Context, userID int64, metric string, from, to time.Time, maxPoints int) (*entity.BodySeries, error)
+}
+
+// BodyController handles HTTP requests for body measurements and composition
+type BodyController struct {
+	bodyUseCase BodyUseCase
+}
+
+// NewBodyController creates a new body controller
+func NewBodyController(bodyUseCase BodyUseCase) *BodyController {
+	return &BodyController{
+		bodyUseCase: bodyUseCase,
+	}
+}
+
+// @Summary Get body profile
+// @Description Get the current user's sex, birth date and height (cm) used by body composition formulas
+// @Tags body
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} entity.Profile
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /body/profile [get]
+func (c *BodyController) GetProfile(ctx *gin.Context) {
+	profile, err := c.bodyUseCase.GetProfile(ctx.Request.Context(), ctx.GetInt64("userID"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profile)
+}
+
+// @Summary Update body profile
+// @Description Replace the current user's sex, birth date and height
+// @Tags body
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.ProfileInput true "Profile"
+// @Success 200 {object} entity.Profile
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /body/profile [put]
+func (c *BodyController) UpdateProfile(ctx *gin.Context) {
+	var input entity.ProfileInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile, err := c.bodyUseCase.UpdateProfile(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profile)
+}
+
+// @Summary List measurements
+// @Description List the body measurements recorded by the current user in a date range, in cm, % or kg
+// @Tags body
+// @Produce json
+// @Security BearerAuth
+// @Param type query string false "Comma-separated measurement types (waist, hips, neck, chest, arm, thigh, body_fat, lean_mass), defaults to all"
+// @Param from query string false "First date (YYYY-MM-DD), defaults to 365 days before to"
+// @Param to query string false "Last date (YYYY-MM-DD), defaults to today (UTC)"
+// @Success 200 {array} entity.Measurement
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /body/measurements [get]
+func (c *BodyController) ListMeasurements(ctx *gin.Context) {
+	from, to, ok := queryDateRange(ctx, bodyDefaultDays)
+	if !ok {
+		return
+	}
+
+	var types []string
+	if typeStr := ctx.Query("type"); typeStr != "" {
+		for _, measurementType := range strings.Split(typeStr, ",") {
+			types = append(types, strings.TrimSpace(measurementType))
+		}
+	}
+
+	measurements, err := c.bodyUseCase.ListMeasurements(ctx.Request.Context(), ctx.GetInt64("userID"), types, from, to)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, measurements)
+}
+
+// @Summary Record measurements
+// @Description Record body measurements taken on a date. Measurements of the same types already recorded that day are replaced.
+// @Tags body
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.MeasurementInput true "Measurements"
+// @Success 201 {array} entity.Measurement
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /body/measurements [post]
+func (c *BodyController) RecordMeasurements(ctx *gin.Context) {
+	var input entity.MeasurementInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	measurements, err := c.bodyUseCase.RecordMeasurements(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, measurements)
+}
+
+// @Summary Delete measurement
+// @Description Delete one of the current user's body measurements
+// @Tags body
+// @Security BearerAuth
+// @Param id path int true "Measurement ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /body/measurements/{id} [delete]
+func (c *BodyController) DeleteMeasurement(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.bodyUseCase.DeleteMeasurement(ctx.Request.Context(), ctx.GetInt64("userID"), id); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary Body composition
+// @Description The latest weight and measurements on or before a date with BMI, waist-to-height ratio, U.S. Navy body fat estimate, lean mass and FFMI. Metrics whose inputs are missing are omitted and the inputs listed.
+// @Tags body
+// @Produce json
+// @Security BearerAuth
+// @Param date query string false "Date (YYYY-MM-DD), defaults to today (UTC)"
+// @Success 200 {object} entity.BodyComposition
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /body/composition [get]
+func (c *BodyController) Composition(ctx *gin.Context) {
+	date, ok := queryDate(ctx, "date")
+	if !ok {
+		return
+	}
+
+	composition, err := c.bodyUseCase.Composition(ctx.Request.Context(), ctx.GetInt64("userID"), date)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, composition)
+}
+
+// @Summary Body metric series
+// @Description Time series of a measurement type, weight or a derived metric (bmi, waist_to_height, navy_body_fat, ffmi, normalized_ffmi) for charting. Series longer than max_points are downsampled by averaging consecutive values.
+// @Tags body
+// @Produce json
+// @Security BearerAuth
+// @Param metric query string true "Measurement type or metric"
+// @Param from query string false "First date (YYYY-MM-DD), defaults to 365 days before to"
+// @Param to query string false "Last date (YYYY-MM-DD), defaults to today (UTC)"
+// @Param max_points query int false "Maximum number of points (2-1000)" default(200)
+// @Success 200 {object} entity.BodySeries
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /body/series [get]
+func (c *BodyController) Series(ctx *gin.Context) {
+	metric := ctx.Query("metric")
+	if metric == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "metric is required"})
+		return
+	}
+
+	from, to, ok := queryDateRange(ctx, bodyDefaultDays)
+	if !ok {
+		return
+	}
+
+	maxPoints := 0
+	if maxStr := ctx.Query("max_points"); maxStr != "" {
+		maxVal, err := strconv.Atoi(maxStr)
+		if err != nil || maxVal < 2 || maxVal > 1000 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "max_points must be between 2 and 1000"})
+			return
+		}
+		maxPoints = maxVal
+	}
+
+	series, err := c.bodyUseCase.Series(ctx.Request.Context(), ctx.GetInt64("userID"), metric, from, to, maxPoints)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, series)
+}
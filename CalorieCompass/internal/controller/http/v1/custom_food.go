@@ -0,0 +1,176 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"CalorieCompass/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// CustomFoodUseCase defines the interface for user-defined food business logic
+type CustomFoodUseCase interface {
+	Create(ctx context.Context, userID int64, input entity.CustomFoodInput) (*entity.FoodDetails, error)
+	Update(ctx context.Context, userID, id int64, input entity.CustomFoodInput) (*entity.FoodDetails, error)
+	Delete(ctx context.Context, userID, id int64) error
+	Get(ctx context.Context, userID, id int64) (*entity.FoodDetails, error)
+	List(ctx context.Context, userID int64) ([]entity.FoodDetails, error)
+}
+
+// CustomFoodController handles HTTP requests for user-defined foods
+type CustomFoodController struct {
+	customFoodUseCase CustomFoodUseCase
+}
+
+// NewCustomFoodController creates a new custom food controller
+func NewCustomFoodController(customFoodUseCase CustomFoodUseCase) *CustomFoodController {
+	return &CustomFoodController{
+		customFoodUseCase: customFoodUseCase,
+	}
+}
+
+// @Summary List custom foods
+// @Description List the current user's custom foods
+// @Tags custom-food
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} entity.FoodDetails
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /food/custom [get]
+func (c *CustomFoodController) List(ctx *gin.Context) {
+	foods, err := c.customFoodUseCase.List(ctx.Request.Context(), ctx.GetInt64("userID"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, foods)
+}
+
+// @Summary Create custom food
+// @Description Create a food with user-defined servings and nutrients
+// @Tags custom-food
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.CustomFoodInput true "Custom food"
+// @Success 201 {object} entity.FoodDetails
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /food/custom [post]
+func (c *CustomFoodController) Create(ctx *gin.Context) {
+	var input entity.CustomFoodInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	details, err := c.customFoodUseCase.Create(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, details)
+}
+
+// @Summary Get custom food
+// @Description Get one of the current user's custom foods
+// @Tags custom-food
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Custom food ID (123 or custom:123)"
+// @Success 200 {object} entity.FoodDetails
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /food/custom/{id} [get]
+func (c *CustomFoodController) Get(ctx *gin.Context) {
+	id, ok := customFoodID(ctx)
+	if !ok {
+		return
+	}
+
+	details, err := c.customFoodUseCase.Get(ctx.Request.Context(), ctx.GetInt64("userID"), id)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, details)
+}
+
+// @Summary Update custom food
+// @Description Replace a custom food's name, brand and servings. Servings keep their IDs: a serving sent with the ID of one of the food's servings updates it, others update the remaining servings in order, and only extra servings are added or missing ones removed.
+// @Tags custom-food
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Custom food ID (123 or custom:123)"
+// @Param input body entity.CustomFoodInput true "Custom food"
+// @Success 200 {object} entity.FoodDetails
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /food/custom/{id} [put]
+func (c *CustomFoodController) Update(ctx *gin.Context) {
+	id, ok := customFoodID(ctx)
+	if !ok {
+		return
+	}
+
+	var input entity.CustomFoodInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	details, err := c.customFoodUseCase.Update(ctx.Request.Context(), ctx.GetInt64("userID"), id, input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, details)
+}
+
+// @Summary Delete custom food
+// @Description Delete one of the current user's custom foods
+// @Tags custom-food
+// @Security BearerAuth
+// @Param id path string true "Custom food ID (123 or custom:123)"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /food/custom/{id} [delete]
+func (c *CustomFoodController) Delete(ctx *gin.Context) {
+	id, ok := customFoodID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.customFoodUseCase.Delete(ctx.Request.Context(), ctx.GetInt64("userID"), id); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// customFoodID parses the :id path parameter, accepting both "123" and "custom:123"
+func customFoodID(ctx *gin.Context) (int64, bool) {
+	raw := strings.TrimPrefix(ctx.Param("id"), entity.FoodSourceCustom+":")
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid custom food id"})
+		return 0, false
+	}
+	return id, true
+}
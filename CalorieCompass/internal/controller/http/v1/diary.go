@@ -0,0 +1,220 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// DiaryUseCase defines the interface for food diary business logic
+type DiaryUseCase interface {
+	AddEntry(ctx context.Context, userID int64, input entity.DiaryEntryInput) (*entity.DiaryEntry, error)
+	DeleteEntry(ctx context.Context, userID, id int64) error
+	GetDay(ctx context.Context, userID int64, date time.Time) (*entity.DiaryDay, error)
+	Copy(ctx context.Context, userID int64, input entity.DiaryCopyInput) (*entity.DiaryDay, error)
+}
+
+// MealParseUseCase defines the interface for parsing free-text meal descriptions
+type MealParseUseCase interface {
+	Parse(ctx context.Context, userID int64, request entity.MealParseRequest) (*entity.MealParseResponse, error)
+}
+
+// DiaryController handles HTTP requests for the food diary
+type DiaryController struct {
+	diaryUseCase DiaryUseCase
+	parseUseCase MealParseUseCase
+}
+
+// NewDiaryController creates a new diary controller
+func NewDiaryController(diaryUseCase DiaryUseCase, parseUseCase MealParseUseCase) *DiaryController {
+	return &DiaryController{
+		diaryUseCase: diaryUseCase,
+		parseUseCase: parseUseCase,
+	}
+}
+
+// @Summary Get diary day
+// @Description Get the current user's diary for a date, grouped by meal with per-meal and daily totals, with the day's exercise and hydration, a summary of calories eaten and burned with the day's net carbs (carbs minus fiber) and average food quality, and progress against the targets active on the date
+// @Tags diary
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param date query string false "Date (YYYY-MM-DD), defaults to today (UTC)"
+// @Success 200 {object} entity.DiaryDay
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /diary [get]
+func (c *DiaryController) GetDay(ctx *gin.Context) {
+	date, ok := queryDate(ctx, "date")
+	if !ok {
+		return
+	}
+
+	day, err := c.diaryUseCase.GetDay(ctx.Request.Context(), ctx.GetInt64("userID"), date)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, day)
+}
+
+// @Summary Log food
+// @Description Add a food to the diary. Nutrients are scaled to the amount and stored as a snapshot. eaten_at must fall on date (UTC); it defaults to now for today and to the meal slot's usual time on other dates.
+// @Tags diary
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.DiaryEntryInput true "Diary entry"
+// @Success 201 {object} entity.DiaryEntry
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /diary [post]
+func (c *DiaryController) AddEntry(ctx *gin.Context) {
+	var input entity.DiaryEntryInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := c.diaryUseCase.AddEntry(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, entry)
+}
+
+// @Summary Delete diary entry
+// @Description Remove an entry from the current user's diary
+// @Tags diary
+// @Security BearerAuth
+// @Param id path int true "Diary entry ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /diary/{id} [delete]
+func (c *DiaryController) DeleteEntry(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.diaryUseCase.DeleteEntry(ctx.Request.Context(), ctx.GetInt64("userID"), id); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary Copy meal or day
+// @Description Copy a meal slot, or a whole day when meal is omitted, to another date. Amounts and nutrients can be scaled; to_meal moves a copied slot to a different slot. All entries are copied or none are.
+// @Tags diary
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.DiaryCopyInput true "Copy request"
+// @Success 201 {object} entity.DiaryDay
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /diary/copy [post]
+func (c *DiaryController) Copy(ctx *gin.Context) {
+	var input entity.DiaryCopyInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	day, err := c.diaryUseCase.Copy(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, day)
+}
+
+// @Summary Parse meal description
+// @Description Turn free text such as "2 scrambled eggs, a slice of whole wheat toast and a coffee with milk" into suggested diary entries. Each food phrase is matched through food search and given a serving that fits its quantity; candidates are ranked by confidence. Nothing is logged: post the confirmed entries to /diary.
+// @Tags diary
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.MealParseRequest true "Meal description"
+// @Success 200 {object} entity.MealParseResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /diary/parse [post]
+func (c *DiaryController) Parse(ctx *gin.Context) {
+	var request entity.MealParseRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := c.parseUseCase.Parse(ctx.Request.Context(), ctx.GetInt64("userID"), request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// queryDate parses an optional YYYY-MM-DD query parameter, defaulting to today (UTC)
+func queryDate(ctx *gin.Context, name string) (time.Time, bool) {
+	raw := ctx.Query(name)
+	if raw == "" {
+		return time.Now().UTC().Truncate(24 * time.Hour), true
+	}
+
+	date, err := time.Parse(entity.DateLayout, raw)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": name + " must be formatted as YYYY-MM-DD"})
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// queryDateRange parses optional from and to query parameters. To defaults to today (UTC)
+// and from to defaultDays days up to and including to.
+func queryDateRange(ctx *gin.Context, defaultDays int) (time.Time, time.Time, bool) {
+	to, ok := queryDate(ctx, "to")
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+
+	from := to.AddDate(0, 0, 1-defaultDays)
+	if ctx.Query("from") != "" {
+		if from, ok = queryDate(ctx, "from"); !ok {
+			return time.Time{}, time.Time{}, false
+		}
+	}
+	if from.After(to) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "from must not be after to"})
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to, true
+}
+
+// pathID parses a numeric path parameter
+func pathID(ctx *gin.Context, name string) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param(name), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,286 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// exerciseDefaultDays is the default date range of the exercise log
+const exerciseDefaultDays = 7
+
+// ExerciseUseCase defines the interface for exercise business logic
+type ExerciseUseCase interface {
+	Search(ctx context.Context, userID int64, query, category string, limit int) ([]entity.Exercise, error)
+	Create(ctx context.Context, userID int64, input entity.ExerciseInput) (*entity.Exercise, error)
+	Update(ctx context.Context, userID, id int64, input entity.ExerciseInput) (*entity.Exercise, error)
+	Delete(ctx context.Context, userID, id int64) error
+	Log(ctx context.Context, userID int64, input entity.ExerciseEntryInput) (*entity.ExerciseEntry, error)
+	DeleteEntry(ctx context.Context, userID, id int64) error
+	ListEntries(ctx context.Context, userID int64, from, to time.Time) ([]entity.ExerciseEntry, error)
+	GetSettings(ctx context.Context, userID int64) (*entity.ExerciseSettings, error)
+	UpdateSettings(ctx context.Context, userID int64, input entity.ExerciseSettingsInput) (*entity.ExerciseSettings, error)
+}
+
+// ExerciseController handles HTTP requests for exercises and exercise logging
+type ExerciseController struct {
+	exerciseUseCase ExerciseUseCase
+}
+
+// NewExerciseController creates a new exercise controller
+func NewExerciseController(exerciseUseCase ExerciseUseCase) *ExerciseController {
+	return &ExerciseController{
+		exerciseUseCase: exerciseUseCase,
+	}
+}
+
+// @Summary Search exercises
+// @Description Search the exercise catalog, with MET values from the Compendium of Physical Activities, and the current user's own exercises
+// @Tags exercise
+// @Produce json
+// @Security BearerAuth
+// @Param query query string false "Name contains"
+// @Param category query string false "Category"
+// @Param limit query int false "Maximum number of exercises (1-100)" default(50)
+// @Success 200 {array} entity.Exercise
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /exercise [get]
+func (c *ExerciseController) Search(ctx *gin.Context) {
+	limit := 0
+	if limitStr := ctx.Query("limit"); limitStr != "" {
+		limitVal, err := strconv.Atoi(limitStr)
+		if err != nil || limitVal < 1 || limitVal > 100 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 100"})
+			return
+		}
+		limit = limitVal
+	}
+
+	exercises, err := c.exerciseUseCase.Search(ctx.Request.Context(), ctx.GetInt64("userID"),
+		ctx.Query("query"), ctx.Query("category"), limit)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, exercises)
+}
+
+// @Summary Create exercise
+// @Description Create a user-defined exercise with its MET values
+// @Tags exercise
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.ExerciseInput true "Exercise"
+// @Success 201 {object} entity.Exercise
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /exercise [post]
+func (c *ExerciseController) Create(ctx *gin.Context) {
+	var input entity.ExerciseInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	exercise, err := c.exerciseUseCase.Create(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, exercise)
+}
+
+// @Summary Update exercise
+// @Description Replace one of the current user's exercises. Catalog exercises cannot be changed.
+// @Tags exercise
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Exercise ID"
+// @Param input body entity.ExerciseInput true "Exercise"
+// @Success 200 {object} entity.Exercise
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /exercise/{id} [put]
+func (c *ExerciseController) Update(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var input entity.ExerciseInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	exercise, err := c.exerciseUseCase.Update(ctx.Request.Context(), ctx.GetInt64("userID"), id, input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, exercise)
+}
+
+// @Summary Delete exercise
+// @Description Delete one of the current user's exercises. Logged entries keep their snapshot.
+// @Tags exercise
+// @Security BearerAuth
+// @Param id path int true "Exercise ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /exercise/{id} [delete]
+func (c *ExerciseController) Delete(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.exerciseUseCase.Delete(ctx.Request.Context(), ctx.GetInt64("userID"), id); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary List logged exercise
+// @Description List the exercise logged by the current user in a date range
+// @Tags exercise
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First date (YYYY-MM-DD), defaults to 7 days before to"
+// @Param to query string false "Last date (YYYY-MM-DD), defaults to today (UTC)"
+// @Success 200 {array} entity.ExerciseEntry
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /exercise/log [get]
+func (c *ExerciseController) ListEntries(ctx *gin.Context) {
+	from, to, ok := queryDateRange(ctx, exerciseDefaultDays)
+	if !ok {
+		return
+	}
+
+	entries, err := c.exerciseUseCase.ListEntries(ctx.Request.Context(), ctx.GetInt64("userID"), from, to)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entries)
+}
+
+// @Summary Log exercise
+// @Description Log an exercise by duration, distance or both. Calories burned are MET × body weight (kg) × hours, using the latest weight recorded on or before the date.
+// @Tags exercise
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.ExerciseEntryInput true "Exercise entry"
+// @Success 201 {object} entity.ExerciseEntry
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /exercise/log [post]
+func (c *ExerciseController) Log(ctx *gin.Context) {
+	var input entity.ExerciseEntryInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := c.exerciseUseCase.Log(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, entry)
+}
+
+// @Summary Delete logged exercise
+// @Description Remove an exercise from the current user's log
+// @Tags exercise
+// @Security BearerAuth
+// @Param id path int true "Exercise entry ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /exercise/log/{id} [delete]
+func (c *ExerciseController) DeleteEntry(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.exerciseUseCase.DeleteEntry(ctx.Request.Context(), ctx.GetInt64("userID"), id); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary Get exercise settings
+// @Description Get whether calories burned are added back to the daily budget in the diary summary
+// @Tags exercise
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} entity.ExerciseSettings
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /exercise/settings [get]
+func (c *ExerciseController) GetSettings(ctx *gin.Context) {
+	settings, err := c.exerciseUseCase.GetSettings(ctx.Request.Context(), ctx.GetInt64("userID"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// @Summary Update exercise settings
+// @Description Choose whether calories burned are added back to the daily budget in the diary summary
+// @Tags exercise
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.ExerciseSettingsInput true "Settings"
+// @Success 200 {object} entity.ExerciseSettings
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /exercise/settings [put]
+func (c *ExerciseController) UpdateSettings(ctx *gin.Context) {
+	var input entity.ExerciseSettingsInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := c.exerciseUseCase.UpdateSettings(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
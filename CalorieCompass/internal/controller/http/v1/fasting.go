@@ -0,0 +1,208 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// Default date ranges of the fasting log and statistics
+const (
+	fastingDefaultDays      = 30
+	fastingStatsDefaultDays = 90
+)
+
+// FastingUseCase defines the interface for intermittent fasting business logic
+type FastingUseCase interface {
+	Protocols() []entity.FastingProtocol
+	Start(ctx context.Context, userID int64, input entity.FastingStartInput) (*entity.FastingSession, error)
+	End(ctx context.Context, userID int64, input entity.FastingEndInput) (*entity.FastingSession, error)
+	Current(ctx context.Context, userID int64) (*entity.FastingSession, error)
+	Delete(ctx context.Context, userID, id int64) error
+	List(ctx context.Context, userID int64, from, to time.Time) ([]entity.FastingSession, error)
+	Stats(ctx context.Context, userID int64, from, to time.Time) (*entity.FastingStats, error)
+}
+
+// FastingController handles HTTP requests for intermittent fasting
+type FastingController struct {
+	fastingUseCase FastingUseCase
+}
+
+// NewFastingController creates a new fasting controller
+func NewFastingController(fastingUseCase FastingUseCase) *FastingController {
+	return &FastingController{
+		fastingUseCase: fastingUseCase,
+	}
+}
+
+// @Summary List fasting protocols
+// @Description List the preset fasting schedules such as 16:8 and one meal a day
+// @Tags fasting
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} entity.FastingProtocol
+// @Failure 401 {object} map[string]interface{}
+// @Router /fasting/protocols [get]
+func (c *FastingController) Protocols(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.fastingUseCase.Protocols())
+}
+
+// @Summary List fasts
+// @Description List the fasts the current user started in a date range with their status and eating-window violations: diary entries over 10 kcal eaten during the fast, by their eaten_at time
+// @Tags fasting
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First date (YYYY-MM-DD), defaults to 30 days before to"
+// @Param to query string false "Last date (YYYY-MM-DD), defaults to today (UTC)"
+// @Success 200 {array} entity.FastingSession
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /fasting [get]
+func (c *FastingController) List(ctx *gin.Context) {
+	from, to, ok := queryDateRange(ctx, fastingDefaultDays)
+	if !ok {
+		return
+	}
+
+	sessions, err := c.fastingUseCase.List(ctx.Request.Context(), ctx.GetInt64("userID"), from, to)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, sessions)
+}
+
+// @Summary Get current fast
+// @Description Get the current user's fast in progress with its progress and violations so far
+// @Tags fasting
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} entity.FastingSession
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /fasting/current [get]
+func (c *FastingController) Current(ctx *gin.Context) {
+	session, err := c.fastingUseCase.Current(ctx.Request.Context(), ctx.GetInt64("userID"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, session)
+}
+
+// @Summary Start fast
+// @Description Start a fast following a protocol, now or at an earlier time. Only one fast can be in progress.
+// @Tags fasting
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.FastingStartInput true "Fast"
+// @Success 201 {object} entity.FastingSession
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /fasting/start [post]
+func (c *FastingController) Start(ctx *gin.Context) {
+	var input entity.FastingStartInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := c.fastingUseCase.Start(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, session)
+}
+
+// @Summary End fast
+// @Description End the current user's fast in progress, now or at an earlier time
+// @Tags fasting
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.FastingEndInput false "End"
+// @Success 200 {object} entity.FastingSession
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /fasting/end [post]
+func (c *FastingController) End(ctx *gin.Context) {
+	var input entity.FastingEndInput
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&input); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	session, err := c.fastingUseCase.End(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, session)
+}
+
+// @Summary Delete fast
+// @Description Delete one of the current user's fasts
+// @Tags fasting
+// @Security BearerAuth
+// @Param id path int true "Fasting session ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /fasting/{id} [delete]
+func (c *FastingController) Delete(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.fastingUseCase.Delete(ctx.Request.Context(), ctx.GetInt64("userID"), id); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary Get fasting statistics
+// @Description Get completion rate, average and longest fast, violations and streaks of completed fasts for the fasts the current user started in a date range
+// @Tags fasting
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First date (YYYY-MM-DD), defaults to 90 days before to"
+// @Param to query string false "Last date (YYYY-MM-DD), defaults to today (UTC)"
+// @Success 200 {object} entity.FastingStats
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /fasting/stats [get]
+func (c *FastingController) Stats(ctx *gin.Context) {
+	from, to, ok := queryDateRange(ctx, fastingStatsDefaultDays)
+	if !ok {
+		return
+	}
+
+	stats, err := c.fastingUseCase.Stats(ctx.Request.Context(), ctx.GetInt64("userID"), from, to)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,147 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// FavoriteUseCase defines the interface for favorite and recent food business logic
+type FavoriteUseCase interface {
+	Add(ctx context.Context, userID int64, input entity.FavoriteFoodInput) (*entity.FavoriteFood, error)
+	Remove(ctx context.Context, userID int64, foodID string) error
+	List(ctx context.Context, userID int64, details bool) ([]entity.FavoriteFood, error)
+	Recent(ctx context.Context, userID int64, at time.Time, limit int, details bool) ([]entity.RecentFood, error)
+}
+
+// FavoriteController handles HTTP requests for favorite and recent foods
+type FavoriteController struct {
+	favoriteUseCase FavoriteUseCase
+}
+
+// NewFavoriteController creates a new favorite food controller
+func NewFavoriteController(favoriteUseCase FavoriteUseCase) *FavoriteController {
+	return &FavoriteController{
+		favoriteUseCase: favoriteUseCase,
+	}
+}
+
+// @Summary List favorite foods
+// @Description List the current user's favorite foods with their default amounts
+// @Tags favorite-food
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param details query bool false "Resolve full food details"
+// @Success 200 {array} entity.FavoriteFood
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /food/favorites [get]
+func (c *FavoriteController) List(ctx *gin.Context) {
+	details, _ := strconv.ParseBool(ctx.Query("details"))
+
+	favorites, err := c.favoriteUseCase.List(ctx.Request.Context(), ctx.GetInt64("userID"), details)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, favorites)
+}
+
+// @Summary Add favorite food
+// @Description Mark a food as favorite with a default serving and amount (1 serving if omitted). Adding an existing favorite replaces its defaults.
+// @Tags favorite-food
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.FavoriteFoodInput true "Favorite food"
+// @Success 201 {object} entity.FavoriteFood
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /food/favorites [post]
+func (c *FavoriteController) Add(ctx *gin.Context) {
+	var input entity.FavoriteFoodInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	favorite, err := c.favoriteUseCase.Add(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, favorite)
+}
+
+// @Summary Remove favorite food
+// @Description Remove a food from the current user's favorites
+// @Tags favorite-food
+// @Security BearerAuth
+// @Param food_id path string true "Food ID"
+// @Success 204
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /food/favorites/{food_id} [delete]
+func (c *FavoriteController) Remove(ctx *gin.Context) {
+	if err := c.favoriteUseCase.Remove(ctx.Request.Context(), ctx.GetInt64("userID"), ctx.Param("food_id")); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary List recent foods
+// @Description Foods from the current user's diary ranked by how often and how recently they were logged, favouring foods usually eaten around the current time of day
+// @Tags favorite-food
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Maximum number of foods (max 100)" default(20)
+// @Param at query string false "Client time with UTC offset (RFC 3339), defaults to now"
+// @Param details query bool false "Resolve full food details"
+// @Success 200 {array} entity.RecentFood
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /food/recent [get]
+func (c *FavoriteController) Recent(ctx *gin.Context) {
+	limit := 0
+	if limitStr := ctx.Query("limit"); limitStr != "" {
+		limitVal, err := strconv.Atoi(limitStr)
+		if err != nil || limitVal <= 0 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+			return
+		}
+		limit = limitVal
+	}
+
+	at := time.Now().UTC()
+	if atStr := ctx.Query("at"); atStr != "" {
+		atVal, err := time.Parse(time.RFC3339, atStr)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "at must be an RFC 3339 timestamp"})
+			return
+		}
+		at = atVal
+	}
+
+	details, _ := strconv.ParseBool(ctx.Query("details"))
+
+	recent, err := c.favoriteUseCase.Recent(ctx.Request.Context(), ctx.GetInt64("userID"), at, limit, details)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recent)
+}
@@ -2,17 +2,27 @@ package v1
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/nutrition"
+	"CalorieCompass/internal/pkg/units"
 	"github.com/gin-gonic/gin"
 )
 
+// maxSearchLimit is the largest search page; FatSecret returns at most 50 foods per page
+const maxSearchLimit = 50
+
 // FoodUseCase defines the interface for food business logic
 type FoodUseCase interface {
 	SearchFoods(ctx context.Context, request entity.FoodSearchRequest) (entity.FoodSearchResponse, error)
-	GetFoodDetails(ctx context.Context, foodID string) (*entity.FoodDetails, error)
+	GetFoodDetails(ctx context.Context, userID int64, foodID string) (*entity.FoodDetails, error)
+	GetFoodNutrition(ctx context.Context, request entity.FoodNutritionRequest) (*entity.FoodNutritionResponse, error)
+	GetFoodDetailsBatch(ctx context.Context, userID int64, foodIDs []string) entity.FoodBatchResponse
 }
 
 // FoodController handles HTTP requests for food operations
@@ -28,13 +38,25 @@ func NewFoodController(foodUseCase FoodUseCase) *FoodController {
 }
 
 // @Summary Search foods
-// @Description Search for foods by name
+// @Description Search for foods by name across the configured providers; signed-in users also find their matching custom foods. Results are merged into one list that is paged through limit foods at a time, up to the first 500 results. Results whose per-100 g values are known (catalog and custom foods, and provider foods fetched for nutrient filters) carry their Nutri-Score and nutrient density. Results are flagged against the user's restriction profile: provider foods from their details where these were fetched, and otherwise by name only with flags_unverified set. Strict profiles leave flagged and unverified foods out, and the total counts only the foods kept.
 // @Tags food
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param query query string true "Search query"
 // @Param page query int false "Page number (0-based)" default(0)
-// @Param limit query int false "Results per page" default(50)
+// @Param limit query int false "Results per page (1-50)" default(50)
+// @Param type query string false "Food type" Enums(brand, generic)
+// @Param min_calories query number false "Minimum calories per 100 g"
+// @Param max_calories query number false "Maximum calories per 100 g"
+// @Param min_protein query number false "Minimum protein per 100 g"
+// @Param max_protein query number false "Maximum protein per 100 g"
+// @Param min_carbs query number false "Minimum carbs per 100 g"
+// @Param max_carbs query number false "Maximum carbs per 100 g"
+// @Param min_fat query number false "Minimum fat per 100 g"
+// @Param max_fat query number false "Maximum fat per 100 g"
+// @Param tags query string false "Comma-separated dietary tags (high_protein, high_fiber, low_calorie, low_carb, low_fat, low_sugar, low_sodium)"
+// @Param sort query string false "Sort order" Enums(relevance, calories, protein_density) default(relevance)
 // @Success 200 {object} entity.FoodSearchResponse
 // @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
@@ -62,27 +84,106 @@ func (c *FoodController) SearchFoods(ctx *gin.Context) {
 		}
 	}
 
+	if page < 0 || limit < 1 || limit > maxSearchLimit {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("page must not be negative and limit must be between 1 and %d", maxSearchLimit)})
+		return
+	}
+
+	filters, err := parseSearchFilters(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sort := ctx.DefaultQuery("sort", entity.SortRelevance)
+	switch sort {
+	case entity.SortRelevance, entity.SortCalories, entity.SortProteinDensity:
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "sort must be relevance, calories or protein_density"})
+		return
+	}
+
 	request := entity.FoodSearchRequest{
-		Query: query,
-		Page:  page,
-		Limit: limit,
+		UserID:  ctx.GetInt64("userID"),
+		Query:   query,
+		Page:    page,
+		Limit:   limit,
+		Filters: filters,
+		Sort:    sort,
 	}
 
 	response, err := c.foodUseCase.SearchFoods(ctx.Request.Context(), request)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, response)
 }
 
+// parseSearchFilters reads the type, per-100 g range and tag filters from the query string
+func parseSearchFilters(ctx *gin.Context) (entity.FoodSearchFilters, error) {
+	filters := entity.FoodSearchFilters{
+		Type: strings.ToLower(ctx.Query("type")),
+	}
+	switch filters.Type {
+	case "", entity.FoodTypeBrand, entity.FoodTypeGeneric:
+	default:
+		return filters, errors.New("type must be brand or generic")
+	}
+
+	ranges := map[string]*entity.NutrientRange{
+		"calories": &filters.Calories,
+		"protein":  &filters.Protein,
+		"carbs":    &filters.Carbs,
+		"fat":      &filters.Fat,
+	}
+	for name, r := range ranges {
+		var err error
+		if r.Min, err = queryFloat(ctx, "min_"+name); err != nil {
+			return filters, err
+		}
+		if r.Max, err = queryFloat(ctx, "max_"+name); err != nil {
+			return filters, err
+		}
+		if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+			return filters, fmt.Errorf("min_%s must not exceed max_%s", name, name)
+		}
+	}
+
+	if tags := ctx.Query("tags"); tags != "" {
+		for _, tag := range strings.Split(tags, ",") {
+			tag = strings.TrimSpace(tag)
+			if !nutrition.IsTag(tag) {
+				return filters, fmt.Errorf("unknown tag %q", tag)
+			}
+			filters.Tags = append(filters.Tags, tag)
+		}
+	}
+
+	return filters, nil
+}
+
+// queryFloat parses an optional non-negative number from the query string
+func queryFloat(ctx *gin.Context, name string) (*float64, error) {
+	raw := ctx.Query(name)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || value < 0 {
+		return nil, fmt.Errorf("%s must be a non-negative number", name)
+	}
+	return &value, nil
+}
+
 // @Summary Get food details
-// @Description Get detailed information about a specific food
+// @Description Get detailed information about a specific food, with its Nutri-Score (2023 algorithm) and nutrient density index per 100 g/ml when a serving has a metric amount. Signed-in users get flags for the restrictions in their profile that the food's declared allergens and diets, ingredients or name conflict with.
 // @Tags food
 // @Accept json
 // @Produce json
-// @Param food_id path string true "Food ID"
+// @Security BearerAuth
+// @Param food_id path string true "Food ID (custom:123, fs:456 or a bare FatSecret ID)"
 // @Success 200 {object} entity.FoodDetails
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
@@ -95,7 +196,7 @@ func (c *FoodController) GetFoodDetails(ctx *gin.Context) {
 		return
 	}
 
-	details, err := c.foodUseCase.GetFoodDetails(ctx.Request.Context(), foodID)
+	details, err := c.foodUseCase.GetFoodDetails(ctx.Request.Context(), ctx.GetInt64("userID"), foodID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -108,3 +209,84 @@ func (c *FoodController) GetFoodDetails(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, details)
 }
+
+// @Summary Get details for several foods
+// @Description Resolve up to 100 food IDs concurrently. Duplicate IDs are returned once; foods that cannot be resolved carry a per-item error instead of failing the batch. Foods are rated and flagged as by GET /food/{food_id}.
+// @Tags food
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body entity.FoodBatchRequest true "Food IDs"
+// @Success 200 {object} entity.FoodBatchResponse
+// @Failure 400 {object} map[string]interface{}
+// @Router /food/batch [post]
+func (c *FoodController) GetFoodDetailsBatch(ctx *gin.Context) {
+	var request entity.FoodBatchRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := c.foodUseCase.GetFoodDetailsBatch(ctx.Request.Context(), ctx.GetInt64("userID"), request.FoodIDs)
+	ctx.JSON(http.StatusOK, response)
+}
+
+// @Summary Get food nutrition for an amount
+// @Description Scale a food's nutrients to an arbitrary amount, e.g. 37 g, 1.5 cup or 2 serving
+// @Tags food
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param food_id path string true "Food ID"
+// @Param amount query number true "Amount in the given unit"
+// @Param unit query string true "Unit (g, kg, oz, lb, ml, l, tsp, tbsp, cup, fl oz or serving)"
+// @Param serving_id query string false "Serving to scale from (defaults to the first compatible serving)"
+// @Success 200 {object} entity.FoodNutritionResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /food/{food_id}/nutrition [get]
+func (c *FoodController) GetFoodNutrition(ctx *gin.Context) {
+	foodID := ctx.Param("food_id")
+	if foodID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "food_id parameter is required"})
+		return
+	}
+
+	amount, err := strconv.ParseFloat(ctx.Query("amount"), 64)
+	if err != nil || amount <= 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "amount must be a positive number"})
+		return
+	}
+
+	unit := ctx.Query("unit")
+	if unit == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "unit parameter is required"})
+		return
+	}
+
+	request := entity.FoodNutritionRequest{
+		UserID:    ctx.GetInt64("userID"),
+		FoodID:    foodID,
+		Amount:    amount,
+		Unit:      unit,
+		ServingID: ctx.Query("serving_id"),
+	}
+
+	response, err := c.foodUseCase.GetFoodNutrition(ctx.Request.Context(), request)
+	if err != nil {
+		if errors.Is(err, units.ErrUnknownUnit) || errors.Is(err, units.ErrIncompatibleUnits) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		respondError(ctx, err)
+		return
+	}
+
+	if response == nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "food not found"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
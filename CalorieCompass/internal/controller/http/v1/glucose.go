@@ -0,0 +1,154 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// Default date ranges of the glucose log and the meal report
+const (
+	glucoseDefaultDays      = 14
+	glucoseMealsDefaultDays = 7
+)
+
+// GlucoseUseCase defines the interface for blood glucose business logic
+type GlucoseUseCase interface {
+	Log(ctx context.Context, userID int64, input entity.GlucoseReadingInput) (*entity.GlucoseReading, error)
+	Delete(ctx context.Context, userID, id int64) error
+	List(ctx context.Context, userID int64, from, to time.Time) ([]entity.GlucoseReading, error)
+	Meals(ctx context.Context, userID int64, from, to time.Time, window int) (*entity.GlucoseMealReport, error)
+}
+
+// GlucoseController handles HTTP requests for blood glucose readings
+type GlucoseController struct {
+	glucoseUseCase GlucoseUseCase
+}
+
+// NewGlucoseController creates a new glucose controller
+func NewGlucoseController(glucoseUseCase GlucoseUseCase) *GlucoseController {
+	return &GlucoseController{
+		glucoseUseCase: glucoseUseCase,
+	}
+}
+
+// @Summary List glucose readings
+// @Description List the blood glucose readings the current user took in a date range, in mg/dL and mmol/L
+// @Tags glucose
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First date (YYYY-MM-DD), defaults to 14 days before to"
+// @Param to query string false "Last date (YYYY-MM-DD), defaults to today (UTC)"
+// @Success 200 {array} entity.GlucoseReading
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /glucose [get]
+func (c *GlucoseController) List(ctx *gin.Context) {
+	from, to, ok := queryDateRange(ctx, glucoseDefaultDays)
+	if !ok {
+		return
+	}
+
+	readings, err := c.glucoseUseCase.List(ctx.Request.Context(), ctx.GetInt64("userID"), from, to)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, readings)
+}
+
+// @Summary Log glucose reading
+// @Description Record a blood glucose reading in mg/dL or mmol/L, now or at an earlier time. Readings must be between 20 and 600 mg/dL.
+// @Tags glucose
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.GlucoseReadingInput true "Reading"
+// @Success 201 {object} entity.GlucoseReading
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /glucose [post]
+func (c *GlucoseController) Log(ctx *gin.Context) {
+	var input entity.GlucoseReadingInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reading, err := c.glucoseUseCase.Log(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, reading)
+}
+
+// @Summary Delete glucose reading
+// @Description Delete one of the current user's blood glucose readings
+// @Tags glucose
+// @Security BearerAuth
+// @Param id path int true "Reading ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /glucose/{id} [delete]
+func (c *GlucoseController) Delete(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.glucoseUseCase.Delete(ctx.Request.Context(), ctx.GetInt64("userID"), id); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary Glucose response to meals
+// @Description Relate the meals the current user ate in a date range to the blood glucose readings taken after them. Diary entries eaten less than 30 minutes apart form one meal. Each meal gets its net carbs and glycemic load, a baseline from the last reading in the hour before it, and the peak and rise of the readings in the post-meal window, which ends early when the next meal starts. With at least three meals that have a rise and a known glycemic load, the correlation between load and rise is reported.
+// @Tags glucose
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First date (YYYY-MM-DD), defaults to 7 days before to"
+// @Param to query string false "Last date (YYYY-MM-DD), defaults to today (UTC)"
+// @Param window query int false "Post-meal window in minutes (30-240)" default(120)
+// @Success 200 {object} entity.GlucoseMealReport
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /glucose/meals [get]
+func (c *GlucoseController) Meals(ctx *gin.Context) {
+	from, to, ok := queryDateRange(ctx, glucoseMealsDefaultDays)
+	if !ok {
+		return
+	}
+
+	window := 0
+	if windowStr := ctx.Query("window"); windowStr != "" {
+		windowVal, err := strconv.Atoi(windowStr)
+		if err != nil || windowVal < 30 || windowVal > 240 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "window must be between 30 and 240 minutes"})
+			return
+		}
+		window = windowVal
+	}
+
+	report, err := c.glucoseUseCase.Meals(ctx.Request.Context(), ctx.GetInt64("userID"), from, to, window)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
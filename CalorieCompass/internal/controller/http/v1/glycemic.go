@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// GlycemicUseCase defines the interface for glycemic load business logic
+type GlycemicUseCase interface {
+	Day(ctx context.Context, userID int64, date time.Time) (*entity.GlycemicDay, error)
+}
+
+// GlycemicController handles HTTP requests for the glycemic load of the diary
+type GlycemicController struct {
+	glycemicUseCase GlycemicUseCase
+}
+
+// NewGlycemicController creates a new glycemic controller
+func NewGlycemicController(glycemicUseCase GlycemicUseCase) *GlycemicController {
+	return &GlycemicController{
+		glycemicUseCase: glycemicUseCase,
+	}
+}
+
+// @Summary Get glycemic load of a day
+// @Description Get the net carbs (carbs minus fiber) and glycemic load of the current user's diary for a date, per entry, per meal and for the day. The glycemic index of a food comes from a reference table matched on its name or, for catalog foods, its dataset category; foods matching neither count towards net carbs only. The day's load is rated low below 80 and high above 120, an entry's or meal's low up to 10 and high from 20.
+// @Tags glycemic
+// @Produce json
+// @Security BearerAuth
+// @Param date query string false "Date (YYYY-MM-DD), defaults to today (UTC)"
+// @Success 200 {object} entity.GlycemicDay
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /glycemic [get]
+func (c *GlycemicController) Day(ctx *gin.Context) {
+	date, ok := queryDate(ctx, "date")
+	if !ok {
+		return
+	}
+
+	day, err := c.glycemicUseCase.Day(ctx.Request.Context(), ctx.GetInt64("userID"), date)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, day)
+}
@@ -0,0 +1,145 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// GoalUseCase defines the interface for nutrition goal business logic
+type GoalUseCase interface {
+	Presets() []entity.MacroPreset
+	Create(ctx context.Context, userID int64, input entity.NutritionGoalInput) (*entity.NutritionGoal, error)
+	List(ctx context.Context, userID int64) ([]entity.NutritionGoal, error)
+	Delete(ctx context.Context, userID, id int64) error
+	TargetFor(ctx context.Context, userID int64, date time.Time) (*entity.NutritionTarget, error)
+}
+
+// GoalController handles HTTP requests for calorie and macronutrient goals
+type GoalController struct {
+	goalUseCase GoalUseCase
+}
+
+// NewGoalController creates a new goal controller
+func NewGoalController(goalUseCase GoalUseCase) *GoalController {
+	return &GoalController{
+		goalUseCase: goalUseCase,
+	}
+}
+
+// @Summary List macro presets
+// @Description List the built-in splits of calories between carbs, protein and fat
+// @Tags goals
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} entity.MacroPreset
+// @Failure 401 {object} map[string]interface{}
+// @Router /goals/presets [get]
+func (c *GoalController) Presets(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.goalUseCase.Presets())
+}
+
+// @Summary List goals
+// @Description List the current user's goal history, latest first. Each goal applies from its effective date until the next one starts.
+// @Tags goals
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} entity.NutritionGoal
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /goals [get]
+func (c *GoalController) List(ctx *gin.Context) {
+	goals, err := c.goalUseCase.List(ctx.Request.Context(), ctx.GetInt64("userID"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, goals)
+}
+
+// @Summary Create goal
+// @Description Set calorie and macronutrient targets from a date on, replacing a goal starting the same date. Schedules give some weekdays, such as training days, their own targets.
+// @Tags goals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.NutritionGoalInput true "Goal"
+// @Success 201 {object} entity.NutritionGoal
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /goals [post]
+func (c *GoalController) Create(ctx *gin.Context) {
+	var input entity.NutritionGoalInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	goal, err := c.goalUseCase.Create(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, goal)
+}
+
+// @Summary Get targets for a date
+// @Description Get the targets that were active on a date: the goal in effect then and its target for the weekday
+// @Tags goals
+// @Produce json
+// @Security BearerAuth
+// @Param date query string false "Date (YYYY-MM-DD), defaults to today (UTC)"
+// @Success 200 {object} entity.NutritionTarget
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /goals/target [get]
+func (c *GoalController) Target(ctx *gin.Context) {
+	date, ok := queryDate(ctx, "date")
+	if !ok {
+		return
+	}
+
+	target, err := c.goalUseCase.TargetFor(ctx.Request.Context(), ctx.GetInt64("userID"), date)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	if target == nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "no goal active on " + date.Format(entity.DateLayout)})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, target)
+}
+
+// @Summary Delete goal
+// @Description Delete one of the current user's goals; the previous goal then applies to its dates
+// @Tags goals
+// @Security BearerAuth
+// @Param id path int true "Goal ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /goals/{id} [delete]
+func (c *GoalController) Delete(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.goalUseCase.Delete(ctx.Request.Context(), ctx.GetInt64("userID"), id); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
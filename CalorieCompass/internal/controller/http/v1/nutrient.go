@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// NutrientUseCase defines the interface for nutrient adequacy business logic
+type NutrientUseCase interface {
+	References(ctx context.Context, userID int64, sex string, age *int, date time.Time) (*entity.NutrientReferences, error)
+	Adequacy(ctx context.Context, userID int64, date time.Time) (*entity.NutrientAnalysis, error)
+}
+
+// NutrientController handles HTTP requests for dietary reference intakes
+type NutrientController struct {
+	nutrientUseCase NutrientUseCase
+}
+
+// NewNutrientController creates a new nutrient controller
+func NewNutrientController(nutrientUseCase NutrientUseCase) *NutrientController {
+	return &NutrientController{
+		nutrientUseCase: nutrientUseCase,
+	}
+}
+
+// @Summary Get reference intakes
+// @Description Get the Dietary Reference Intakes (RDA or AI, upper limits and acceptable macronutrient distribution ranges) for the tracked nutrients, for a sex and age or, by default, the current user's body profile
+// @Tags nutrients
+// @Produce json
+// @Security BearerAuth
+// @Param sex query string false "Sex" Enums(male, female)
+// @Param age query int false "Age in years (1-120)"
+// @Success 200 {object} entity.NutrientReferences
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /nutrients/references [get]
+func (c *NutrientController) References(ctx *gin.Context) {
+	sex := ctx.Query("sex")
+	if sex != "" && sex != entity.SexMale && sex != entity.SexFemale {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "sex must be male or female"})
+		return
+	}
+
+	var age *int
+	if ageStr := ctx.Query("age"); ageStr != "" {
+		ageVal, err := strconv.Atoi(ageStr)
+		if err != nil || ageVal < 1 || ageVal > 120 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "age must be between 1 and 120"})
+			return
+		}
+		age = &ageVal
+	}
+
+	references, err := c.nutrientUseCase.References(ctx.Request.Context(), ctx.GetInt64("userID"), sex, age,
+		time.Now().UTC())
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, references)
+}
+
+// @Summary Get nutrient adequacy
+// @Description Compare the current user's average daily intake over the logged days of the 7 days ending on a date with the reference intakes for their age and sex from the body profile. Nutrients below their RDA or AI, or above their upper limit (such as sodium), list the foods contributing most to them. Vitamins and minerals (calcium, iron, potassium, vitamins A, C and D) only count what the food sources report.
+// @Tags nutrients
+// @Produce json
+// @Security BearerAuth
+// @Param date query string false "Last date of the window (YYYY-MM-DD), defaults to today (UTC)"
+// @Success 200 {object} entity.NutrientAnalysis
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /nutrients/adequacy [get]
+func (c *NutrientController) Adequacy(ctx *gin.Context) {
+	date, ok := queryDate(ctx, "date")
+	if !ok {
+		return
+	}
+
+	analysis, err := c.nutrientUseCase.Adequacy(ctx.Request.Context(), ctx.GetInt64("userID"), date)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, analysis)
+}
@@ -0,0 +1,239 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"CalorieCompass/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// RecipeUseCase defines the interface for user recipe business logic
+type RecipeUseCase interface {
+	Create(ctx context.Context, userID int64, input entity.RecipeInput) (*entity.Recipe, error)
+	Update(ctx context.Context, userID, id int64, input entity.RecipeInput) (*entity.Recipe, error)
+	Delete(ctx context.Context, userID, id int64) error
+	Get(ctx context.Context, userID, id int64) (*entity.Recipe, error)
+	List(ctx context.Context, userID int64) ([]entity.Recipe, error)
+	Recompute(ctx context.Context, userID, id int64) (*entity.Recipe, error)
+}
+
+// RecipeImportUseCase defines the interface for drafting recipes from pasted content
+type RecipeImportUseCase interface {
+	Import(ctx context.Context, userID int64, request entity.RecipeImportRequest) (*entity.RecipeImportDraft, error)
+}
+
+// RecipeController handles HTTP requests for user recipes
+type RecipeController struct {
+	recipeUseCase RecipeUseCase
+	importUseCase RecipeImportUseCase
+}
+
+// NewRecipeController creates a new recipe controller
+func NewRecipeController(recipeUseCase RecipeUseCase, importUseCase RecipeImportUseCase) *RecipeController {
+	return &RecipeController{
+		recipeUseCase: recipeUseCase,
+		importUseCase: importUseCase,
+	}
+}
+
+// @Summary List recipes
+// @Description List the current user's recipes, flagged against their restriction profile by ingredient names
+// @Tags recipe
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} entity.Recipe
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /recipe [get]
+func (c *RecipeController) List(ctx *gin.Context) {
+	recipes, err := c.recipeUseCase.List(ctx.Request.Context(), ctx.GetInt64("userID"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recipes)
+}
+
+// @Summary Create recipe
+// @Description Create a recipe from foods. Total and per-serving nutrition are computed from the ingredients. The recipe can be logged to the diary with its ID, e.g. recipe:12.
+// @Tags recipe
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.RecipeInput true "Recipe"
+// @Success 201 {object} entity.Recipe
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /recipe [post]
+func (c *RecipeController) Create(ctx *gin.Context) {
+	var input entity.RecipeInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	recipe, err := c.recipeUseCase.Create(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, recipe)
+}
+
+// @Summary Import recipe
+// @Description Draft a recipe from schema.org Recipe JSON-LD, an HTML page containing it, or a plain-text ingredient list (one ingredient per line). Each line is parsed into quantity, unit and food and matched to a food with a confidence score. Nothing is saved: review the draft and submit its recipe to POST /recipe.
+// @Tags recipe
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.RecipeImportRequest true "Recipe content"
+// @Success 200 {object} entity.RecipeImportDraft
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /recipe/import [post]
+func (c *RecipeController) Import(ctx *gin.Context) {
+	var request entity.RecipeImportRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	draft, err := c.importUseCase.Import(ctx.Request.Context(), ctx.GetInt64("userID"), request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, draft)
+}
+
+// @Summary Get recipe
+// @Description Get one of the current user's recipes, flagged against their restriction profile by ingredient names
+// @Tags recipe
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Recipe ID (12 or recipe:12)"
+// @Success 200 {object} entity.Recipe
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /recipe/{id} [get]
+func (c *RecipeController) Get(ctx *gin.Context) {
+	id, ok := recipeID(ctx)
+	if !ok {
+		return
+	}
+
+	recipe, err := c.recipeUseCase.Get(ctx.Request.Context(), ctx.GetInt64("userID"), id)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recipe)
+}
+
+// @Summary Update recipe
+// @Description Replace a recipe and recompute its nutrition
+// @Tags recipe
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Recipe ID (12 or recipe:12)"
+// @Param input body entity.RecipeInput true "Recipe"
+// @Success 200 {object} entity.Recipe
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /recipe/{id} [put]
+func (c *RecipeController) Update(ctx *gin.Context) {
+	id, ok := recipeID(ctx)
+	if !ok {
+		return
+	}
+
+	var input entity.RecipeInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	recipe, err := c.recipeUseCase.Update(ctx.Request.Context(), ctx.GetInt64("userID"), id, input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recipe)
+}
+
+// @Summary Delete recipe
+// @Description Delete one of the current user's recipes
+// @Tags recipe
+// @Security BearerAuth
+// @Param id path string true "Recipe ID (12 or recipe:12)"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /recipe/{id} [delete]
+func (c *RecipeController) Delete(ctx *gin.Context) {
+	id, ok := recipeID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.recipeUseCase.Delete(ctx.Request.Context(), ctx.GetInt64("userID"), id); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary Recompute recipe
+// @Description Re-resolve every ingredient to pick up changes to the underlying foods
+// @Tags recipe
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Recipe ID (12 or recipe:12)"
+// @Success 200 {object} entity.Recipe
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /recipe/{id}/recompute [post]
+func (c *RecipeController) Recompute(ctx *gin.Context) {
+	id, ok := recipeID(ctx)
+	if !ok {
+		return
+	}
+
+	recipe, err := c.recipeUseCase.Recompute(ctx.Request.Context(), ctx.GetInt64("userID"), id)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recipe)
+}
+
+// recipeID parses the :id path parameter, accepting both "12" and "recipe:12"
+func recipeID(ctx *gin.Context) (int64, bool) {
+	raw := strings.TrimPrefix(ctx.Param("id"), entity.FoodSourceRecipe+":")
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid recipe id"})
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,78 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// reportDefaultDays is the default date range of a report
+const reportDefaultDays = 30
+
+// ReportUseCase defines the interface for nutrition report business logic
+type ReportUseCase interface {
+	Report(ctx context.Context, userID int64, from, to time.Time, granularity string, top int) (*entity.NutritionReport, error)
+}
+
+// ReportController handles HTTP requests for nutrition reports
+type ReportController struct {
+	reportUseCase ReportUseCase
+}
+
+// NewReportController creates a new report controller
+func NewReportController(reportUseCase ReportUseCase) *ReportController {
+	return &ReportController{
+		reportUseCase: reportUseCase,
+	}
+}
+
+// @Summary Get nutrition report
+// @Description Review the current user's diary over up to 366 days: daily averages and macro distribution overall and per day, week or month, adherence to the goals active on each day (within 10% of target), the days closest to and furthest from the calorie target, the foods contributing most to each nutrient and how completely the diary was kept
+// @Tags reports
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First date (YYYY-MM-DD), defaults to 30 days before to"
+// @Param to query string false "Last date (YYYY-MM-DD), defaults to today (UTC)"
+// @Param granularity query string false "Period length" Enums(day, week, month) default(week)
+// @Param top query int false "Top foods per nutrient (1-20)" default(5)
+// @Success 200 {object} entity.NutritionReport
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /reports [get]
+func (c *ReportController) Report(ctx *gin.Context) {
+	from, to, ok := queryDateRange(ctx, reportDefaultDays)
+	if !ok {
+		return
+	}
+
+	granularity := ctx.DefaultQuery("granularity", entity.GranularityWeek)
+	switch granularity {
+	case entity.GranularityDay, entity.GranularityWeek, entity.GranularityMonth:
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "granularity must be day, week or month"})
+		return
+	}
+
+	top := 0
+	if topStr := ctx.Query("top"); topStr != "" {
+		topVal, err := strconv.Atoi(topStr)
+		if err != nil || topVal < 1 || topVal > 20 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "top must be between 1 and 20"})
+			return
+		}
+		top = topVal
+	}
+
+	report, err := c.reportUseCase.Report(ctx.Request.Context(), ctx.GetInt64("userID"), from, to, granularity, top)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
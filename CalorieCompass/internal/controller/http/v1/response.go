@@ -0,0 +1,21 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"CalorieCompass/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// respondError maps use case errors to HTTP status codes
+func respondError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, entity.ErrInvalidInput):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
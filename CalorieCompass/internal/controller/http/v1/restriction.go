@@ -0,0 +1,104 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+
+	"CalorieCompass/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// RestrictionUseCase defines the interface for restriction profile business logic
+type RestrictionUseCase interface {
+	Options() entity.RestrictionOptions
+	Get(ctx context.Context, userID int64) (*entity.RestrictionProfile, error)
+	Update(ctx context.Context, userID int64, input entity.RestrictionProfileInput) (*entity.RestrictionProfile, error)
+	Delete(ctx context.Context, userID int64) error
+}
+
+// RestrictionController handles HTTP requests for allergen and diet restriction profiles
+type RestrictionController struct {
+	restrictionUseCase RestrictionUseCase
+}
+
+// NewRestrictionController creates a new restriction controller
+func NewRestrictionController(restrictionUseCase RestrictionUseCase) *RestrictionController {
+	return &RestrictionController{
+		restrictionUseCase: restrictionUseCase,
+	}
+}
+
+// @Summary List restriction options
+// @Description List the allergen codes (the 14 allergens EU food law requires to be declared) and diet codes a restriction profile accepts
+// @Tags restrictions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} entity.RestrictionOptions
+// @Router /restrictions/options [get]
+func (c *RestrictionController) Options(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.restrictionUseCase.Options())
+}
+
+// @Summary Get restriction profile
+// @Description Get the allergens and diets the current user avoids or follows; empty lists when none are set
+// @Tags restrictions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} entity.RestrictionProfile
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /restrictions [get]
+func (c *RestrictionController) Get(ctx *gin.Context) {
+	profile, err := c.restrictionUseCase.Get(ctx.Request.Context(), ctx.GetInt64("userID"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profile)
+}
+
+// @Summary Update restriction profile
+// @Description Replace the current user's allergens and diets. Foods and recipes are then flagged in search results and details when their declared attributes, ingredients or name conflict with the profile. In strict mode flagged foods are left out of search results entirely.
+// @Tags restrictions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.RestrictionProfileInput true "Restriction profile"
+// @Success 200 {object} entity.RestrictionProfile
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /restrictions [put]
+func (c *RestrictionController) Update(ctx *gin.Context) {
+	var input entity.RestrictionProfileInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile, err := c.restrictionUseCase.Update(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profile)
+}
+
+// @Summary Delete restriction profile
+// @Description Clear the current user's allergens and diets so that foods are no longer flagged
+// @Tags restrictions
+// @Security BearerAuth
+// @Success 204
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /restrictions [delete]
+func (c *RestrictionController) Delete(ctx *gin.Context) {
+	if err := c.restrictionUseCase.Delete(ctx.Request.Context(), ctx.GetInt64("userID")); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -9,7 +9,14 @@ type TokenValidator interface {
 	ValidateToken(token string) (int64, error)
 }
 
-func NewRouter(handler *gin.Engine, authController *AuthController, userController *UserController, foodController *FoodController, tokenRepo TokenValidator) {
+func NewRouter(handler *gin.Engine, authController *AuthController, userController *UserController,
+	foodController *FoodController, customFoodController *CustomFoodController, favoriteController *FavoriteController,
+	diaryController *DiaryController, recipeController *RecipeController, savedMealController *SavedMealController,
+	weightController *WeightController, bodyController *BodyController, exerciseController *ExerciseController,
+	waterController *WaterController, fastingController *FastingController, goalController *GoalController,
+	reportController *ReportController, nutrientController *NutrientController, suggestionController *SuggestionController,
+	alternativeController *AlternativeController, restrictionController *RestrictionController,
+	glycemicController *GlycemicController, glucoseController *GlucoseController, tokenRepo TokenValidator) {
 	// Create two route groups:
 	// 1. Routes for the API with the /api/v1 prefix (for backwards compatibility)
 	apiV1 := handler.Group("/api/v1")
@@ -28,9 +35,181 @@ func NewRouter(handler *gin.Engine, authController *AuthController, userControll
 
 		// Food routes
 		food := apiV1.Group("/food")
+		food.Use(middleware.OptionalJWTAuth(tokenRepo))
 		{
 			food.GET("/search", foodController.SearchFoods)
+			food.POST("/batch", foodController.GetFoodDetailsBatch)
 			food.GET("/:food_id", foodController.GetFoodDetails)
+			food.GET("/:food_id/nutrition", foodController.GetFoodNutrition)
+			food.GET("/:food_id/alternatives", alternativeController.Alternatives)
+		}
+
+		customFood := apiV1.Group("/food/custom")
+		customFood.Use(middleware.JWTAuth(tokenRepo))
+		{
+			customFood.GET("", customFoodController.List)
+			customFood.POST("", customFoodController.Create)
+			customFood.GET("/:id", customFoodController.Get)
+			customFood.PUT("/:id", customFoodController.Update)
+			customFood.DELETE("/:id", customFoodController.Delete)
+		}
+
+		favorites := apiV1.Group("/food")
+		favorites.Use(middleware.JWTAuth(tokenRepo))
+		{
+			favorites.GET("/favorites", favoriteController.List)
+			favorites.POST("/favorites", favoriteController.Add)
+			favorites.DELETE("/favorites/:food_id", favoriteController.Remove)
+			favorites.GET("/recent", favoriteController.Recent)
+		}
+
+		diary := apiV1.Group("/diary")
+		diary.Use(middleware.JWTAuth(tokenRepo))
+		{
+			diary.GET("", diaryController.GetDay)
+			diary.POST("", diaryController.AddEntry)
+			diary.POST("/parse", diaryController.Parse)
+			diary.POST("/copy", diaryController.Copy)
+			diary.DELETE("/:id", diaryController.DeleteEntry)
+		}
+
+		recipe := apiV1.Group("/recipe")
+		recipe.Use(middleware.JWTAuth(tokenRepo))
+		{
+			recipe.GET("", recipeController.List)
+			recipe.POST("", recipeController.Create)
+			recipe.POST("/import", recipeController.Import)
+			recipe.GET("/:id", recipeController.Get)
+			recipe.PUT("/:id", recipeController.Update)
+			recipe.DELETE("/:id", recipeController.Delete)
+			recipe.POST("/:id/recompute", recipeController.Recompute)
+		}
+
+		meal := apiV1.Group("/meal")
+		meal.Use(middleware.JWTAuth(tokenRepo))
+		{
+			meal.GET("", savedMealController.List)
+			meal.POST("", savedMealController.Create)
+			meal.POST("/from-diary", savedMealController.CreateFromDiary)
+			meal.GET("/:id", savedMealController.Get)
+			meal.PUT("/:id", savedMealController.Update)
+			meal.DELETE("/:id", savedMealController.Delete)
+			meal.POST("/:id/log", savedMealController.Log)
+		}
+
+		weight := apiV1.Group("/weight")
+		weight.Use(middleware.JWTAuth(tokenRepo))
+		{
+			weight.GET("", weightController.List)
+			weight.POST("", weightController.Record)
+			weight.GET("/trend", weightController.Trend)
+			weight.PUT("/goal", weightController.SetGoal)
+			weight.DELETE("/goal", weightController.DeleteGoal)
+			weight.DELETE("/:id", weightController.Delete)
+		}
+
+		body := apiV1.Group("/body")
+		body.Use(middleware.JWTAuth(tokenRepo))
+		{
+			body.GET("/profile", bodyController.GetProfile)
+			body.PUT("/profile", bodyController.UpdateProfile)
+			body.GET("/measurements", bodyController.ListMeasurements)
+			body.POST("/measurements", bodyController.RecordMeasurements)
+			body.DELETE("/measurements/:id", bodyController.DeleteMeasurement)
+			body.GET("/composition", bodyController.Composition)
+			body.GET("/series", bodyController.Series)
+		}
+
+		exercise := apiV1.Group("/exercise")
+		exercise.Use(middleware.JWTAuth(tokenRepo))
+		{
+			exercise.GET("", exerciseController.Search)
+			exercise.POST("", exerciseController.Create)
+			exercise.GET("/log", exerciseController.ListEntries)
+			exercise.POST("/log", exerciseController.Log)
+			exercise.DELETE("/log/:id", exerciseController.DeleteEntry)
+			exercise.GET("/settings", exerciseController.GetSettings)
+			exercise.PUT("/settings", exerciseController.UpdateSettings)
+			exercise.PUT("/:id", exerciseController.Update)
+			exercise.DELETE("/:id", exerciseController.Delete)
+		}
+
+		water := apiV1.Group("/water")
+		water.Use(middleware.JWTAuth(tokenRepo))
+		{
+			water.GET("", waterController.Day)
+			water.POST("", waterController.Log)
+			water.GET("/containers", waterController.ListContainers)
+			water.POST("/containers", waterController.CreateContainer)
+			water.DELETE("/containers/:id", waterController.DeleteContainer)
+			water.GET("/settings", waterController.GetSettings)
+			water.PUT("/settings", waterController.UpdateSettings)
+			water.DELETE("/:id", waterController.DeleteEntry)
+		}
+
+		fasting := apiV1.Group("/fasting")
+		fasting.Use(middleware.JWTAuth(tokenRepo))
+		{
+			fasting.GET("", fastingController.List)
+			fasting.GET("/protocols", fastingController.Protocols)
+			fasting.GET("/current", fastingController.Current)
+			fasting.GET("/stats", fastingController.Stats)
+			fasting.POST("/start", fastingController.Start)
+			fasting.POST("/end", fastingController.End)
+			fasting.DELETE("/:id", fastingController.Delete)
+		}
+
+		goals := apiV1.Group("/goals")
+		goals.Use(middleware.JWTAuth(tokenRepo))
+		{
+			goals.GET("", goalController.List)
+			goals.POST("", goalController.Create)
+			goals.GET("/presets", goalController.Presets)
+			goals.GET("/target", goalController.Target)
+			goals.DELETE("/:id", goalController.Delete)
+		}
+
+		reports := apiV1.Group("/reports")
+		reports.Use(middleware.JWTAuth(tokenRepo))
+		{
+			reports.GET("", reportController.Report)
+		}
+
+		nutrients := apiV1.Group("/nutrients")
+		nutrients.Use(middleware.JWTAuth(tokenRepo))
+		{
+			nutrients.GET("/references", nutrientController.References)
+			nutrients.GET("/adequacy", nutrientController.Adequacy)
+		}
+
+		suggestions := apiV1.Group("/suggestions")
+		suggestions.Use(middleware.JWTAuth(tokenRepo))
+		{
+			suggestions.GET("", suggestionController.Suggest)
+		}
+
+		restrictions := apiV1.Group("/restrictions")
+		restrictions.Use(middleware.JWTAuth(tokenRepo))
+		{
+			restrictions.GET("", restrictionController.Get)
+			restrictions.PUT("", restrictionController.Update)
+			restrictions.DELETE("", restrictionController.Delete)
+			restrictions.GET("/options", restrictionController.Options)
+		}
+
+		glycemic := apiV1.Group("/glycemic")
+		glycemic.Use(middleware.JWTAuth(tokenRepo))
+		{
+			glycemic.GET("", glycemicController.Day)
+		}
+
+		glucose := apiV1.Group("/glucose")
+		glucose.Use(middleware.JWTAuth(tokenRepo))
+		{
+			glucose.GET("", glucoseController.List)
+			glucose.POST("", glucoseController.Log)
+			glucose.GET("/meals", glucoseController.Meals)
+			glucose.DELETE("/:id", glucoseController.Delete)
 		}
 	}
 
@@ -49,8 +228,180 @@ func NewRouter(handler *gin.Engine, authController *AuthController, userControll
 
 	// Food routes
 	food := handler.Group("/food")
+	food.Use(middleware.OptionalJWTAuth(tokenRepo))
 	{
 		food.GET("/search", foodController.SearchFoods)
+		food.POST("/batch", foodController.GetFoodDetailsBatch)
 		food.GET("/:food_id", foodController.GetFoodDetails)
+		food.GET("/:food_id/nutrition", foodController.GetFoodNutrition)
+		food.GET("/:food_id/alternatives", alternativeController.Alternatives)
+	}
+
+	customFood := handler.Group("/food/custom")
+	customFood.Use(middleware.JWTAuth(tokenRepo))
+	{
+		customFood.GET("", customFoodController.List)
+		customFood.POST("", customFoodController.Create)
+		customFood.GET("/:id", customFoodController.Get)
+		customFood.PUT("/:id", customFoodController.Update)
+		customFood.DELETE("/:id", customFoodController.Delete)
+	}
+
+	favorites := handler.Group("/food")
+	favorites.Use(middleware.JWTAuth(tokenRepo))
+	{
+		favorites.GET("/favorites", favoriteController.List)
+		favorites.POST("/favorites", favoriteController.Add)
+		favorites.DELETE("/favorites/:food_id", favoriteController.Remove)
+		favorites.GET("/recent", favoriteController.Recent)
+	}
+
+	diary := handler.Group("/diary")
+	diary.Use(middleware.JWTAuth(tokenRepo))
+	{
+		diary.GET("", diaryController.GetDay)
+		diary.POST("", diaryController.AddEntry)
+		diary.POST("/parse", diaryController.Parse)
+		diary.POST("/copy", diaryController.Copy)
+		diary.DELETE("/:id", diaryController.DeleteEntry)
+	}
+
+	recipe := handler.Group("/recipe")
+	recipe.Use(middleware.JWTAuth(tokenRepo))
+	{
+		recipe.GET("", recipeController.List)
+		recipe.POST("", recipeController.Create)
+		recipe.POST("/import", recipeController.Import)
+		recipe.GET("/:id", recipeController.Get)
+		recipe.PUT("/:id", recipeController.Update)
+		recipe.DELETE("/:id", recipeController.Delete)
+		recipe.POST("/:id/recompute", recipeController.Recompute)
+	}
+
+	meal := handler.Group("/meal")
+	meal.Use(middleware.JWTAuth(tokenRepo))
+	{
+		meal.GET("", savedMealController.List)
+		meal.POST("", savedMealController.Create)
+		meal.POST("/from-diary", savedMealController.CreateFromDiary)
+		meal.GET("/:id", savedMealController.Get)
+		meal.PUT("/:id", savedMealController.Update)
+		meal.DELETE("/:id", savedMealController.Delete)
+		meal.POST("/:id/log", savedMealController.Log)
+	}
+
+	weight := handler.Group("/weight")
+	weight.Use(middleware.JWTAuth(tokenRepo))
+	{
+		weight.GET("", weightController.List)
+		weight.POST("", weightController.Record)
+		weight.GET("/trend", weightController.Trend)
+		weight.PUT("/goal", weightController.SetGoal)
+		weight.DELETE("/goal", weightController.DeleteGoal)
+		weight.DELETE("/:id", weightController.Delete)
+	}
+
+	body := handler.Group("/body")
+	body.Use(middleware.JWTAuth(tokenRepo))
+	{
+		body.GET("/profile", bodyController.GetProfile)
+		body.PUT("/profile", bodyController.UpdateProfile)
+		body.GET("/measurements", bodyController.ListMeasurements)
+		body.POST("/measurements", bodyController.RecordMeasurements)
+		body.DELETE("/measurements/:id", bodyController.DeleteMeasurement)
+		body.GET("/composition", bodyController.Composition)
+		body.GET("/series", bodyController.Series)
+	}
+
+	exercise := handler.Group("/exercise")
+	exercise.Use(middleware.JWTAuth(tokenRepo))
+	{
+		exercise.GET("", exerciseController.Search)
+		exercise.POST("", exerciseController.Create)
+		exercise.GET("/log", exerciseController.ListEntries)
+		exercise.POST("/log", exerciseController.Log)
+		exercise.DELETE("/log/:id", exerciseController.DeleteEntry)
+		exercise.GET("/settings", exerciseController.GetSettings)
+		exercise.PUT("/settings", exerciseController.UpdateSettings)
+		exercise.PUT("/:id", exerciseController.Update)
+		exercise.DELETE("/:id", exerciseController.Delete)
+	}
+
+	water := handler.Group("/water")
+	water.Use(middleware.JWTAuth(tokenRepo))
+	{
+		water.GET("", waterController.Day)
+		water.POST("", waterController.Log)
+		water.GET("/containers", waterController.ListContainers)
+		water.POST("/containers", waterController.CreateContainer)
+		water.DELETE("/containers/:id", waterController.DeleteContainer)
+		water.GET("/settings", waterController.GetSettings)
+		water.PUT("/settings", waterController.UpdateSettings)
+		water.DELETE("/:id", waterController.DeleteEntry)
+	}
+
+	fasting := handler.Group("/fasting")
+	fasting.Use(middleware.JWTAuth(tokenRepo))
+	{
+		fasting.GET("", fastingController.List)
+		fasting.GET("/protocols", fastingController.Protocols)
+		fasting.GET("/current", fastingController.Current)
+		fasting.GET("/stats", fastingController.Stats)
+		fasting.POST("/start", fastingController.Start)
+		fasting.POST("/end", fastingController.End)
+		fasting.DELETE("/:id", fastingController.Delete)
+	}
+
+	goals := handler.Group("/goals")
+	goals.Use(middleware.JWTAuth(tokenRepo))
+	{
+		goals.GET("", goalController.List)
+		goals.POST("", goalController.Create)
+		goals.GET("/presets", goalController.Presets)
+		goals.GET("/target", goalController.Target)
+		goals.DELETE("/:id", goalController.Delete)
+	}
+
+	reports := handler.Group("/reports")
+	reports.Use(middleware.JWTAuth(tokenRepo))
+	{
+		reports.GET("", reportController.Report)
+	}
+
+	nutrients := handler.Group("/nutrients")
+	nutrients.Use(middleware.JWTAuth(tokenRepo))
+	{
+		nutrients.GET("/references", nutrientController.References)
+		nutrients.GET("/adequacy", nutrientController.Adequacy)
+	}
+
+	suggestions := handler.Group("/suggestions")
+	suggestions.Use(middleware.JWTAuth(tokenRepo))
+	{
+		suggestions.GET("", suggestionController.Suggest)
+	}
+
+	restrictions := handler.Group("/restrictions")
+	restrictions.Use(middleware.JWTAuth(tokenRepo))
+	{
+		restrictions.GET("", restrictionController.Get)
+		restrictions.PUT("", restrictionController.Update)
+		restrictions.DELETE("", restrictionController.Delete)
+		restrictions.GET("/options", restrictionController.Options)
+	}
+
+	glycemic := handler.Group("/glycemic")
+	glycemic.Use(middleware.JWTAuth(tokenRepo))
+	{
+		glycemic.GET("", glycemicController.Day)
+	}
+
+	glucose := handler.Group("/glucose")
+	glucose.Use(middleware.JWTAuth(tokenRepo))
+	{
+		glucose.GET("", glucoseController.List)
+		glucose.POST("", glucoseController.Log)
+		glucose.GET("/meals", glucoseController.Meals)
+		glucose.DELETE("/:id", glucoseController.Delete)
 	}
 }
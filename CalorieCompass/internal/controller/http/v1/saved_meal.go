@@ -0,0 +1,228 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+
+	"CalorieCompass/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// SavedMealUseCase defines the interface for saved meal business logic
+type SavedMealUseCase interface {
+	Create(ctx context.Context, userID int64, input entity.SavedMealInput) (*entity.SavedMeal, error)
+	CreateFromDiary(ctx context.Context, userID int64, input entity.SavedMealFromDiaryInput) (*entity.SavedMeal, error)
+	Update(ctx context.Context, userID, id int64, input entity.SavedMealInput) (*entity.SavedMeal, error)
+	Delete(ctx context.Context, userID, id int64) error
+	Get(ctx context.Context, userID, id int64) (*entity.SavedMeal, error)
+	List(ctx context.Context, userID int64) ([]entity.SavedMeal, error)
+	Log(ctx context.Context, userID, id int64, input entity.LogSavedMealInput) (*entity.DiaryDay, error)
+}
+
+// SavedMealController handles HTTP requests for saved meals
+type SavedMealController struct {
+	savedMealUseCase SavedMealUseCase
+}
+
+// NewSavedMealController creates a new saved meal controller
+func NewSavedMealController(savedMealUseCase SavedMealUseCase) *SavedMealController {
+	return &SavedMealController{
+		savedMealUseCase: savedMealUseCase,
+	}
+}
+
+// @Summary List saved meals
+// @Description List the current user's saved meals with their totals
+// @Tags meal
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} entity.SavedMeal
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /meal [get]
+func (c *SavedMealController) List(ctx *gin.Context) {
+	meals, err := c.savedMealUseCase.List(ctx.Request.Context(), ctx.GetInt64("userID"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, meals)
+}
+
+// @Summary Create saved meal
+// @Description Save a named group of foods that can be logged in one call
+// @Tags meal
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.SavedMealInput true "Saved meal"
+// @Success 201 {object} entity.SavedMeal
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /meal [post]
+func (c *SavedMealController) Create(ctx *gin.Context) {
+	var input entity.SavedMealInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	meal, err := c.savedMealUseCase.Create(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, meal)
+}
+
+// @Summary Save meal from diary
+// @Description Save the foods logged in a meal slot on a date as a saved meal
+// @Tags meal
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.SavedMealFromDiaryInput true "Diary meal to save"
+// @Success 201 {object} entity.SavedMeal
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /meal/from-diary [post]
+func (c *SavedMealController) CreateFromDiary(ctx *gin.Context) {
+	var input entity.SavedMealFromDiaryInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	meal, err := c.savedMealUseCase.CreateFromDiary(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, meal)
+}
+
+// @Summary Get saved meal
+// @Description Get one of the current user's saved meals
+// @Tags meal
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Saved meal ID"
+// @Success 200 {object} entity.SavedMeal
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /meal/{id} [get]
+func (c *SavedMealController) Get(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	meal, err := c.savedMealUseCase.Get(ctx.Request.Context(), ctx.GetInt64("userID"), id)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, meal)
+}
+
+// @Summary Update saved meal
+// @Description Replace a saved meal's name, default slot and foods
+// @Tags meal
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Saved meal ID"
+// @Param input body entity.SavedMealInput true "Saved meal"
+// @Success 200 {object} entity.SavedMeal
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /meal/{id} [put]
+func (c *SavedMealController) Update(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var input entity.SavedMealInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	meal, err := c.savedMealUseCase.Update(ctx.Request.Context(), ctx.GetInt64("userID"), id, input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, meal)
+}
+
+// @Summary Delete saved meal
+// @Description Delete a saved meal. Diary entries logged from it are kept.
+// @Tags meal
+// @Security BearerAuth
+// @Param id path int true "Saved meal ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /meal/{id} [delete]
+func (c *SavedMealController) Delete(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.savedMealUseCase.Delete(ctx.Request.Context(), ctx.GetInt64("userID"), id); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary Log saved meal
+// @Description Add every food of a saved meal to the diary in one transaction, optionally scaled, and return the updated day. eaten_at must fall on date (UTC) and defaults as for POST /diary.
+// @Tags meal
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Saved meal ID"
+// @Param input body entity.LogSavedMealInput true "Where to log the meal"
+// @Success 201 {object} entity.DiaryDay
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /meal/{id}/log [post]
+func (c *SavedMealController) Log(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var input entity.LogSavedMealInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	day, err := c.savedMealUseCase.Log(ctx.Request.Context(), ctx.GetInt64("userID"), id, input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, day)
+}
@@ -0,0 +1,85 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"CalorieCompass/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// SuggestionUseCase defines the interface for food suggestion business logic
+type SuggestionUseCase interface {
+	Suggest(ctx context.Context, request entity.SuggestionRequest) (*entity.SuggestionResponse, error)
+}
+
+// SuggestionController handles HTTP requests for food suggestions
+type SuggestionController struct {
+	suggestionUseCase SuggestionUseCase
+}
+
+// NewSuggestionController creates a new suggestion controller
+func NewSuggestionController(suggestionUseCase SuggestionUseCase) *SuggestionController {
+	return &SuggestionController{
+		suggestionUseCase: suggestionUseCase,
+	}
+}
+
+// @Summary Suggest foods for the remaining targets
+// @Description Rank the current user's favorites, recently logged foods and catalog foods by how well a scaled serving of each fills the calories and macros left of the day's nutrition goal. Each suggestion carries the amount to log, its nutrition, a fit score from 0 to 100 and what would be left afterwards. Dietary preferences are given as food search filters. Foods flagged for an allergen in the user's restriction profile are never suggested, nor, in strict mode, foods flagged for a diet; other diet conflicts are listed in flags.
+// @Tags suggestions
+// @Produce json
+// @Security BearerAuth
+// @Param date query string false "Date (YYYY-MM-DD), defaults to today (UTC)"
+// @Param limit query int false "Maximum number of suggestions (max 50)" default(10)
+// @Param type query string false "Food type" Enums(brand, generic)
+// @Param min_calories query number false "Minimum calories per 100 g"
+// @Param max_calories query number false "Maximum calories per 100 g"
+// @Param min_protein query number false "Minimum protein per 100 g"
+// @Param max_protein query number false "Maximum protein per 100 g"
+// @Param min_carbs query number false "Minimum carbs per 100 g"
+// @Param max_carbs query number false "Maximum carbs per 100 g"
+// @Param min_fat query number false "Minimum fat per 100 g"
+// @Param max_fat query number false "Maximum fat per 100 g"
+// @Param tags query string false "Comma-separated dietary tags (high_protein, high_fiber, low_calorie, low_carb, low_fat, low_sugar, low_sodium)"
+// @Success 200 {object} entity.SuggestionResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /suggestions [get]
+func (c *SuggestionController) Suggest(ctx *gin.Context) {
+	date, ok := queryDate(ctx, "date")
+	if !ok {
+		return
+	}
+
+	limit := 0
+	if limitStr := ctx.Query("limit"); limitStr != "" {
+		limitVal, err := strconv.Atoi(limitStr)
+		if err != nil || limitVal <= 0 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+			return
+		}
+		limit = limitVal
+	}
+
+	filters, err := parseSearchFilters(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := c.suggestionUseCase.Suggest(ctx.Request.Context(), entity.SuggestionRequest{
+		UserID:  ctx.GetInt64("userID"),
+		Date:    date,
+		Limit:   limit,
+		Filters: filters,
+	})
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
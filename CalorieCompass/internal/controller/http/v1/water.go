@@ -0,0 +1,230 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// WaterUseCase defines the interface for water logging business logic
+type WaterUseCase interface {
+	Log(ctx context.Context, userID int64, input entity.WaterEntryInput) (*entity.WaterEntry, error)
+	DeleteEntry(ctx context.Context, userID, id int64) error
+	Day(ctx context.Context, userID int64, date time.Time) (*entity.HydrationDay, error)
+	ListContainers(ctx context.Context, userID int64) ([]entity.WaterContainer, error)
+	CreateContainer(ctx context.Context, userID int64, input entity.WaterContainerInput) (*entity.WaterContainer, error)
+	DeleteContainer(ctx context.Context, userID, id int64) error
+	GetSettings(ctx context.Context, userID int64) (*entity.WaterSettings, error)
+	UpdateSettings(ctx context.Context, userID int64, input entity.WaterSettingsInput) (*entity.WaterSettings, error)
+}
+
+// WaterController handles HTTP requests for water logging
+type WaterController struct {
+	waterUseCase WaterUseCase
+}
+
+// NewWaterController creates a new water controller
+func NewWaterController(waterUseCase WaterUseCase) *WaterController {
+	return &WaterController{
+		waterUseCase: waterUseCase,
+	}
+}
+
+// @Summary Get water day
+// @Description Get the water logged by the current user on a date with the day's hydration: water, water from drinks and high-water foods logged in the diary, and the target derived from body weight (35 ml/kg) plus 500 ml per hour of exercise
+// @Tags water
+// @Produce json
+// @Security BearerAuth
+// @Param date query string false "Date (YYYY-MM-DD), defaults to today (UTC)"
+// @Success 200 {object} entity.HydrationDay
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /water [get]
+func (c *WaterController) Day(ctx *gin.Context) {
+	date, ok := queryDate(ctx, "date")
+	if !ok {
+		return
+	}
+
+	day, err := c.waterUseCase.Day(ctx.Request.Context(), ctx.GetInt64("userID"), date)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, day)
+}
+
+// @Summary Log water
+// @Description Log water as an amount in a volume unit or as a number of containers
+// @Tags water
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.WaterEntryInput true "Water entry"
+// @Success 201 {object} entity.WaterEntry
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /water [post]
+func (c *WaterController) Log(ctx *gin.Context) {
+	var input entity.WaterEntryInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := c.waterUseCase.Log(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, entry)
+}
+
+// @Summary Delete water entry
+// @Description Remove an entry from the current user's water log
+// @Tags water
+// @Security BearerAuth
+// @Param id path int true "Water entry ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /water/{id} [delete]
+func (c *WaterController) DeleteEntry(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.waterUseCase.DeleteEntry(ctx.Request.Context(), ctx.GetInt64("userID"), id); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary List containers
+// @Description List the built-in drink containers followed by the current user's own containers (ml)
+// @Tags water
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} entity.WaterContainer
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /water/containers [get]
+func (c *WaterController) ListContainers(ctx *gin.Context) {
+	containers, err := c.waterUseCase.ListContainers(ctx.Request.Context(), ctx.GetInt64("userID"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, containers)
+}
+
+// @Summary Create container
+// @Description Add a drink container size for the current user
+// @Tags water
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.WaterContainerInput true "Container"
+// @Success 201 {object} entity.WaterContainer
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /water/containers [post]
+func (c *WaterController) CreateContainer(ctx *gin.Context) {
+	var input entity.WaterContainerInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	container, err := c.waterUseCase.CreateContainer(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, container)
+}
+
+// @Summary Delete container
+// @Description Delete one of the current user's containers. Logged water keeps its amount.
+// @Tags water
+// @Security BearerAuth
+// @Param id path int true "Container ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /water/containers/{id} [delete]
+func (c *WaterController) DeleteContainer(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.waterUseCase.DeleteContainer(ctx.Request.Context(), ctx.GetInt64("userID"), id); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary Get water settings
+// @Description Get the current user's fixed daily water target, if any
+// @Tags water
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} entity.WaterSettings
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /water/settings [get]
+func (c *WaterController) GetSettings(ctx *gin.Context) {
+	settings, err := c.waterUseCase.GetSettings(ctx.Request.Context(), ctx.GetInt64("userID"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// @Summary Update water settings
+// @Description Set a fixed daily water target, or clear it to derive the target from body weight
+// @Tags water
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.WaterSettingsInput true "Settings"
+// @Success 200 {object} entity.WaterSettings
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /water/settings [put]
+func (c *WaterController) UpdateSettings(ctx *gin.Context) {
+	var input entity.WaterSettingsInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := c.waterUseCase.UpdateSettings(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
@@ -0,0 +1,200 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// weightDefaultDays is the default date range of weight lists and trends
+const weightDefaultDays = 90
+
+// WeightUseCase defines the interface for body weight business logic
+type WeightUseCase interface {
+	Record(ctx context.Context, userID int64, input entity.WeightEntryInput) (*entity.WeightEntry, error)
+	Delete(ctx context.Context, userID, id int64) error
+	List(ctx context.Context, userID int64, from, to time.Time) ([]entity.WeightEntry, error)
+	SetGoal(ctx context.Context, userID int64, input entity.WeightGoalInput) (*entity.WeightGoal, error)
+	DeleteGoal(ctx context.Context, userID int64) error
+	Trend(ctx context.Context, userID int64, from, to time.Time, window int) (*entity.WeightTrend, error)
+}
+
+// WeightController handles HTTP requests for body weight tracking
+type WeightController struct {
+	weightUseCase WeightUseCase
+}
+
+// NewWeightController creates a new weight controller
+func NewWeightController(weightUseCase WeightUseCase) *WeightController {
+	return &WeightController{
+		weightUseCase: weightUseCase,
+	}
+}
+
+// @Summary List weights
+// @Description List the weights recorded by the current user in a date range (kg)
+// @Tags weight
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First date (YYYY-MM-DD), defaults to 90 days before to"
+// @Param to query string false "Last date (YYYY-MM-DD), defaults to today (UTC)"
+// @Success 200 {array} entity.WeightEntry
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /weight [get]
+func (c *WeightController) List(ctx *gin.Context) {
+	from, to, ok := queryDateRange(ctx, weightDefaultDays)
+	if !ok {
+		return
+	}
+
+	entries, err := c.weightUseCase.List(ctx.Request.Context(), ctx.GetInt64("userID"), from, to)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entries)
+}
+
+// @Summary Record weight
+// @Description Record the current user's weight for a date. A weight already recorded that day is replaced.
+// @Tags weight
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.WeightEntryInput true "Weight"
+// @Success 201 {object} entity.WeightEntry
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /weight [post]
+func (c *WeightController) Record(ctx *gin.Context) {
+	var input entity.WeightEntryInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := c.weightUseCase.Record(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, entry)
+}
+
+// @Summary Delete weight
+// @Description Delete one of the current user's weight entries
+// @Tags weight
+// @Security BearerAuth
+// @Param id path int true "Weight entry ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /weight/{id} [delete]
+func (c *WeightController) Delete(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.weightUseCase.Delete(ctx.Request.Context(), ctx.GetInt64("userID"), id); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary Weight trend
+// @Description Exponentially smoothed trend weight that filters out day-to-day water fluctuations, the weekly rate of change fitted over the last 4 weeks, the projected date the goal weight is reached, and the TDEE implied by diary intake and trend change over the last window days
+// @Tags weight
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First date (YYYY-MM-DD), defaults to 90 days before to"
+// @Param to query string false "Last date (YYYY-MM-DD), defaults to today (UTC)"
+// @Param window query int false "TDEE window in days (7-90)" default(28)
+// @Success 200 {object} entity.WeightTrend
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /weight/trend [get]
+func (c *WeightController) Trend(ctx *gin.Context) {
+	from, to, ok := queryDateRange(ctx, weightDefaultDays)
+	if !ok {
+		return
+	}
+
+	window := 0
+	if windowStr := ctx.Query("window"); windowStr != "" {
+		windowVal, err := strconv.Atoi(windowStr)
+		if err != nil || windowVal < 7 || windowVal > 90 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "window must be between 7 and 90 days"})
+			return
+		}
+		window = windowVal
+	}
+
+	trend, err := c.weightUseCase.Trend(ctx.Request.Context(), ctx.GetInt64("userID"), from, to, window)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, trend)
+}
+
+// @Summary Set goal weight
+// @Description Set the current user's target weight
+// @Tags weight
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body entity.WeightGoalInput true "Goal weight"
+// @Success 200 {object} entity.WeightGoal
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /weight/goal [put]
+func (c *WeightController) SetGoal(ctx *gin.Context) {
+	var input entity.WeightGoalInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	goal, err := c.weightUseCase.SetGoal(ctx.Request.Context(), ctx.GetInt64("userID"), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, goal)
+}
+
+// @Summary Delete goal weight
+// @Description Remove the current user's target weight
+// @Tags weight
+// @Security BearerAuth
+// @Success 204
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /weight/goal [delete]
+func (c *WeightController) DeleteGoal(ctx *gin.Context) {
+	if err := c.weightUseCase.DeleteGoal(ctx.Request.Context(), ctx.GetInt64("userID")); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,49 @@
+package entity
+
+// Effects of a nutrient difference on an alternative
+const (
+	EffectBetter  = "better"
+	EffectWorse   = "worse"
+	EffectSimilar = "similar"
+)
+
+// NutrientComparison compares one nutrient of a food and an alternative over the same
+// serving. Change is the difference in percent of the original, omitted when the original
+// has none of the nutrient.
+type NutrientComparison struct {
+	Nutrient    string   `json:"nutrient" example:"sugar"`
+	Unit        string   `json:"unit" example:"g"`
+	Original    float64  `json:"original" example:"12.4"`
+	Alternative float64  `json:"alternative" example:"4.1"`
+	Change      *float64 `json:"change,omitempty" example:"-66.9"`
+	Effect      string   `json:"effect" example:"better"`
+}
+
+// FoodAlternative is a catalog food similar to another but better in at least one nutrient.
+// Nutrition covers the same amount as the original serving, and Score combines the name
+// similarity with the size of the improvements. Flags lists diet conflicts with the
+// user's restriction profile that did not exclude the food.
+type FoodAlternative struct {
+	FoodID       string               `json:"food_id" example:"catalog:1042"`
+	FoodName     string               `json:"food_name"`
+	BrandName    string               `json:"brand_name,omitempty"`
+	Category     string               `json:"category,omitempty"`
+	Similarity   float64              `json:"similarity" example:"0.62"`
+	Score        float64              `json:"score" example:"0.93"`
+	Nutrition    Serving              `json:"nutrition"`
+	Comparison   []NutrientComparison `json:"comparison"`
+	Improvements []string             `json:"improvements" example:"sugar,fiber"`
+	Explanation  string               `json:"explanation" example:"Per 150 g: 67% less sugar and 2.5 g more fiber"`
+	Flags        []FoodFlag           `json:"flags,omitempty"`
+}
+
+// FoodAlternatives lists healthier alternatives to a food, best first, compared over one of
+// its servings
+type FoodAlternatives struct {
+	FoodID       string            `json:"food_id" example:"fs:33691"`
+	FoodName     string            `json:"food_name"`
+	BrandName    string            `json:"brand_name,omitempty"`
+	Category     string            `json:"category,omitempty"`
+	Serving      Serving           `json:"serving"`
+	Alternatives []FoodAlternative `json:"alternatives"`
+}
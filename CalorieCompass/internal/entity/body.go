@@ -0,0 +1,117 @@
+package entity
+
+import "time"
+
+// Sexes used by sex-specific formulas
+const (
+	SexMale   = "male"
+	SexFemale = "female"
+)
+
+// Profile holds the body data that calculations depend on. Height is in cm.
+type Profile struct {
+	Sex       string    `json:"sex,omitempty" example:"female"`
+	BirthDate string    `json:"birth_date,omitempty" example:"1990-04-12"`
+	Height    float64   `json:"height,omitempty" example:"168"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ProfileInput replaces the user's profile. HeightUnit defaults to cm.
+type ProfileInput struct {
+	Sex        string  `json:"sex,omitempty" binding:"omitempty,oneof=male female" example:"female"`
+	BirthDate  string  `json:"birth_date,omitempty" example:"1990-04-12"`
+	Height     float64 `json:"height,omitempty" binding:"omitempty,gt=0" example:"168"`
+	HeightUnit string  `json:"height_unit,omitempty" binding:"omitempty,oneof=cm in" example:"cm"`
+}
+
+// Body measurement types
+const (
+	MeasurementWaist    = "waist"
+	MeasurementHips     = "hips"
+	MeasurementNeck     = "neck"
+	MeasurementChest    = "chest"
+	MeasurementArm      = "arm"
+	MeasurementThigh    = "thigh"
+	MeasurementBodyFat  = "body_fat"
+	MeasurementLeanMass = "lean_mass"
+)
+
+// MeasurementUnits maps each measurement type to the unit it is stored in
+var MeasurementUnits = map[string]string{
+	MeasurementWaist:    "cm",
+	MeasurementHips:     "cm",
+	MeasurementNeck:     "cm",
+	MeasurementChest:    "cm",
+	MeasurementArm:      "cm",
+	MeasurementThigh:    "cm",
+	MeasurementBodyFat:  "%",
+	MeasurementLeanMass: "kg",
+}
+
+// Derived body metrics available as time series besides the measurement types
+const (
+	MetricWeight         = "weight"
+	MetricBMI            = "bmi"
+	MetricWaistToHeight  = "waist_to_height"
+	MetricNavyBodyFat    = "navy_body_fat"
+	MetricFFMI           = "ffmi"
+	MetricNormalizedFFMI = "normalized_ffmi"
+)
+
+// Measurement is a body measurement recorded on a date, in the unit of its type
+type Measurement struct {
+	ID        int64     `json:"id"`
+	Date      string    `json:"date" example:"2026-01-31"`
+	Type      string    `json:"type" example:"waist"`
+	Value     float64   `json:"value" example:"84.5"`
+	Unit      string    `json:"unit" example:"cm"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MeasurementValueInput is one measurement. Lengths accept cm, mm, m, in or ft, lean mass
+// kg or lb; body fat is a percentage. Unit defaults to the type's storage unit.
+type MeasurementValueInput struct {
+	Type  string  `json:"type" binding:"required,oneof=waist hips neck chest arm thigh body_fat lean_mass" example:"waist"`
+	Value float64 `json:"value" binding:"required,gt=0" example:"33.3"`
+	Unit  string  `json:"unit,omitempty" example:"in"`
+}
+
+// MeasurementInput records measurements taken on a date, replacing measurements of the same
+// types already recorded that day
+type MeasurementInput struct {
+	Date   string                  `json:"date" binding:"required" example:"2026-01-31"`
+	Values []MeasurementValueInput `json:"values" binding:"required,min=1,max=20,dive"`
+}
+
+// BodyComposition is the latest known body data on a date with the metrics derived from
+// it. Metrics whose inputs are missing are omitted and their inputs listed in Missing.
+type BodyComposition struct {
+	Date           string        `json:"date" example:"2026-01-31"`
+	Weight         *float64      `json:"weight,omitempty" example:"82.4"`
+	Height         *float64      `json:"height,omitempty" example:"180"`
+	Measurements   []Measurement `json:"measurements"`
+	BMI            *float64      `json:"bmi,omitempty" example:"25.4"`
+	BMICategory    string        `json:"bmi_category,omitempty" example:"overweight"`
+	WaistToHeight  *float64      `json:"waist_to_height,omitempty" example:"0.47"`
+	NavyBodyFat    *float64      `json:"navy_body_fat,omitempty" example:"18.2"`
+	BodyFat        *float64      `json:"body_fat,omitempty" example:"18.2"`
+	LeanMass       *float64      `json:"lean_mass,omitempty" example:"67.4"`
+	FFMI           *float64      `json:"ffmi,omitempty" example:"20.8"`
+	NormalizedFFMI *float64      `json:"normalized_ffmi,omitempty" example:"20.8"`
+	Missing        []string      `json:"missing,omitempty"`
+}
+
+// BodySeriesPoint is one point of a body metric time series. Count is the number of
+// recorded values averaged into the point after downsampling.
+type BodySeriesPoint struct {
+	Date  string  `json:"date" example:"2026-01-31"`
+	Value float64 `json:"value" example:"84.5"`
+	Count int     `json:"count" example:"1"`
+}
+
+// BodySeries is a time series of a measurement type or a derived metric
+type BodySeries struct {
+	Metric string            `json:"metric" example:"waist"`
+	Unit   string            `json:"unit" example:"cm"`
+	Points []BodySeriesPoint `json:"points"`
+}
@@ -0,0 +1,69 @@
+package entity
+
+import "time"
+
+// Offline catalog datasets
+const (
+	CatalogSourceUSDA          = "usda"
+	CatalogSourceOpenFoodFacts = "off"
+)
+
+// CatalogServingID identifies a catalog food's labelled serving; the other serving is 100 g or ml
+const CatalogServingID = "serving"
+
+// CatalogFood is a food imported from an offline dataset. Nutrients are per 100 g
+// (or 100 ml when IsLiquid) in the units of Serving.
+type CatalogFood struct {
+	ID                 int64   `json:"id" db:"id"`
+	Source             string  `json:"source" db:"source"`
+	SourceID           string  `json:"source_id" db:"source_id"`
+	Name               string  `json:"name" db:"name"`
+	BrandName          string  `json:"brand_name,omitempty" db:"brand_name"`
+	Category           string  `json:"category,omitempty" db:"category"`
+	IsLiquid           bool    `json:"is_liquid" db:"is_liquid"`
+	ServingSize        float64 `json:"serving_size,omitempty" db:"serving_size"`
+	ServingDescription string  `json:"serving_description,omitempty" db:"serving_description"`
+	Calories           float64 `json:"calories" db:"calories"`
+	Carbs              float64 `json:"carbs" db:"carbs"`
+	Protein            float64 `json:"protein" db:"protein"`
+	Fat                float64 `json:"fat" db:"fat"`
+	SaturatedFat       float64 `json:"saturated_fat" db:"saturated_fat"`
+	Fiber              float64 `json:"fiber" db:"fiber"`
+	Cholesterol        float64 `json:"cholesterol" db:"cholesterol"`
+	Sodium             float64 `json:"sodium" db:"sodium"`
+	Sugar              float64 `json:"sugar" db:"sugar"`
+	Calcium            float64 `json:"calcium" db:"calcium"`
+	Iron               float64 `json:"iron" db:"iron"`
+	Potassium          float64 `json:"potassium" db:"potassium"`
+	VitaminA           float64 `json:"vitamin_a" db:"vitamin_a"`
+	VitaminC           float64 `json:"vitamin_c" db:"vitamin_c"`
+	VitaminD           float64 `json:"vitamin_d" db:"vitamin_d"`
+	Ingredients        string  `json:"ingredients,omitempty" db:"ingredients"`
+	ContentHash        string  `json:"-" db:"content_hash"`
+}
+
+// SimilarFood is a catalog food with the trigram similarity of its name to another food's
+type SimilarFood struct {
+	Food       CatalogFood
+	Similarity float64
+}
+
+// ImportRun tracks the progress of importing one dataset file so it can be resumed
+type ImportRun struct {
+	ID             int64      `db:"id"`
+	Source         string     `db:"source"`
+	FileName       string     `db:"file_name"`
+	FileSize       int64      `db:"file_size"`
+	FileModifiedAt time.Time  `db:"file_modified_at"`
+	Checkpoint     int64      `db:"checkpoint"`
+	Imported       int64      `db:"imported"`
+	Status         string     `db:"status"`
+	StartedAt      time.Time  `db:"started_at"`
+	FinishedAt     *time.Time `db:"finished_at"`
+}
+
+// Import run statuses
+const (
+	ImportRunning   = "running"
+	ImportCompleted = "completed"
+)
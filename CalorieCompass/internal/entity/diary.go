@@ -0,0 +1,132 @@
+package entity
+
+import "time"
+
+// DateLayout is the format of calendar dates in requests and responses
+const DateLayout = "2006-01-02"
+
+// Meal slots a diary entry can be logged under
+const (
+	MealBreakfast = "breakfast"
+	MealLunch     = "lunch"
+	MealDinner    = "dinner"
+	MealSnack     = "snack"
+)
+
+// Meals lists the meal slots in display order
+var Meals = []string{MealBreakfast, MealLunch, MealDinner, MealSnack}
+
+// DiaryEntry is a logged food with a snapshot of its name and nutrients at the time of logging
+type DiaryEntry struct {
+	ID        int64     `json:"id"`
+	Date      string    `json:"date" example:"2026-01-31"`
+	Meal      string    `json:"meal" example:"breakfast"`
+	FoodID    string    `json:"food_id" example:"fs:33691"`
+	FoodName  string    `json:"food_name"`
+	BrandName string    `json:"brand_name,omitempty"`
+	ServingID string    `json:"serving_id"`
+	Amount    float64   `json:"amount"`
+	Unit      string    `json:"unit"`
+	EatenAt   time.Time `json:"eaten_at"`
+	Nutrition Serving   `json:"nutrition"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DiaryEntryInput represents a request to log a food
+type DiaryEntryInput struct {
+	Date      string     `json:"date" binding:"required" example:"2026-01-31"`
+	Meal      string     `json:"meal" binding:"required,oneof=breakfast lunch dinner snack" example:"breakfast"`
+	FoodID    string     `json:"food_id" binding:"required" example:"fs:33691"`
+	ServingID string     `json:"serving_id,omitempty"`
+	Amount    float64    `json:"amount" binding:"required,gt=0" example:"1"`
+	Unit      string     `json:"unit" binding:"required" example:"serving"`
+	EatenAt   *time.Time `json:"eaten_at,omitempty"`
+}
+
+// DiaryMeal groups a day's entries for one meal slot
+type DiaryMeal struct {
+	Meal    string       `json:"meal"`
+	Entries []DiaryEntry `json:"entries"`
+	Totals  Serving      `json:"totals"`
+}
+
+// DiarySummary balances the calories eaten on a day against the calories burned by
+// exercise. Net is what counts against the day's calorie budget: burned calories are only
+// subtracted when the user has chosen to add them back. NetCarbs are the day's carbs minus
+// fiber and Quality rates the food eaten.
+type DiarySummary struct {
+	Consumed        float64      `json:"consumed" example:"2150"`
+	Burned          float64      `json:"burned" example:"404"`
+	BurnedAddedBack bool         `json:"burned_added_back"`
+	Net             float64      `json:"net" example:"1746"`
+	NetCarbs        float64      `json:"net_carbs" example:"182"`
+	Quality         *DietQuality `json:"quality,omitempty"`
+}
+
+// DietQuality rates a day's food. GradeAverage averages the Nutri-Score grades of the
+// entries with a metric amount, from 1 (A) to 5 (E), weighted by their calories, and Grade
+// is its nearest grade; both are omitted when no entry could be scored. ScoredShare is the
+// percentage of calories those entries cover and NutrientDensity rates the day's totals.
+type DietQuality struct {
+	Grade           string   `json:"grade,omitempty" example:"b"`
+	GradeAverage    float64  `json:"grade_average,omitempty" example:"2.3"`
+	ScoredShare     float64  `json:"scored_share" example:"92.5"`
+	NutrientDensity *float64 `json:"nutrient_density,omitempty" example:"35.2"`
+}
+
+// DiaryDay is a user's diary for a single date
+type DiaryDay struct {
+	Date      string           `json:"date" example:"2026-01-31"`
+	Meals     []DiaryMeal      `json:"meals"`
+	Totals    Serving          `json:"totals"`
+	Exercises []ExerciseEntry  `json:"exercises"`
+	Summary   DiarySummary     `json:"summary"`
+	Hydration HydrationSummary `json:"hydration"`
+	Targets   *DailyTargets    `json:"targets,omitempty"`
+}
+
+// DailyIntake is the total nutrition logged in the diary on one date. Meals counts the meal
+// slots with at least one entry.
+type DailyIntake struct {
+	Date      string  `json:"date" example:"2026-01-31"`
+	Entries   int     `json:"entries"`
+	Meals     int     `json:"meals"`
+	Nutrition Serving `json:"nutrition"`
+}
+
+// MealParseRequest is a free-text meal description to turn into diary entries. Date and
+// meal are copied into the suggested entries when given.
+type MealParseRequest struct {
+	Text string `json:"text" binding:"required,max=1000" example:"2 scrambled eggs, a slice of whole wheat toast and a coffee with milk"`
+	Date string `json:"date,omitempty" example:"2026-01-31"`
+	Meal string `json:"meal,omitempty" binding:"omitempty,oneof=breakfast lunch dinner snack" example:"breakfast"`
+}
+
+// MealParseCandidate is a food that may match a parsed phrase, with the serving picked for
+// the phrase's quantity and the resulting nutrition
+type MealParseCandidate struct {
+	Food       Food    `json:"food"`
+	Serving    Serving `json:"serving"`
+	Amount     float64 `json:"amount"`
+	Unit       string  `json:"unit" example:"serving"`
+	Nutrition  Serving `json:"nutrition"`
+	Confidence float64 `json:"confidence"`
+}
+
+// MealParseItem is one food phrase of a meal description with its ranked candidates.
+// Entry is the diary entry for the best candidate, ready to be posted once confirmed.
+type MealParseItem struct {
+	Phrase     string               `json:"phrase" example:"a slice of whole wheat toast"`
+	Quantity   float64              `json:"quantity"`
+	Unit       string               `json:"unit,omitempty"`
+	Size       string               `json:"size,omitempty"`
+	Food       string               `json:"food" example:"whole wheat toast"`
+	Candidates []MealParseCandidate `json:"candidates"`
+	Entry      *DiaryEntryInput     `json:"entry,omitempty"`
+}
+
+// MealParseResponse is the parsed meal; nothing is logged until the client confirms
+type MealParseResponse struct {
+	Items      []MealParseItem `json:"items"`
+	Unresolved int             `json:"unresolved"`
+}
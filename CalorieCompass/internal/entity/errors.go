@@ -0,0 +1,10 @@
+package entity
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when a requested record does not exist or belongs to another user
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidInput is returned when a request passes binding but fails business validation
+	ErrInvalidInput = errors.New("invalid input")
+)
@@ -0,0 +1,78 @@
+package entity
+
+import "time"
+
+// Exercise intensities
+const (
+	IntensityLight    = "light"
+	IntensityModerate = "moderate"
+	IntensityVigorous = "vigorous"
+)
+
+// Exercise is an activity from the catalog or defined by the user, with its MET value at
+// each intensity. SpeedKmh is the speed the moderate MET applies to for activities that can
+// be logged by distance.
+type Exercise struct {
+	ID          int64   `json:"id"`
+	Code        string  `json:"code,omitempty" example:"12050"`
+	Name        string  `json:"name" example:"Running"`
+	Category    string  `json:"category,omitempty" example:"running"`
+	LightMET    float64 `json:"met_light" example:"7"`
+	MET         float64 `json:"met" example:"9.8"`
+	VigorousMET float64 `json:"met_vigorous" example:"11.8"`
+	SpeedKmh    float64 `json:"speed_kmh,omitempty" example:"9.7"`
+	Custom      bool    `json:"custom"`
+}
+
+// ExerciseInput creates or updates a user-defined exercise. The light and vigorous METs
+// default to the moderate MET.
+type ExerciseInput struct {
+	Name        string  `json:"name" binding:"required,max=255" example:"Spin class"`
+	Category    string  `json:"category,omitempty" binding:"max=50" example:"cycling"`
+	MET         float64 `json:"met" binding:"required,gt=0,lte=25" example:"8.5"`
+	LightMET    float64 `json:"met_light,omitempty" binding:"omitempty,gt=0,lte=25"`
+	VigorousMET float64 `json:"met_vigorous,omitempty" binding:"omitempty,gt=0,lte=25"`
+	SpeedKmh    float64 `json:"speed_kmh,omitempty" binding:"omitempty,gt=0,lte=100"`
+}
+
+// ExerciseEntry is a logged exercise with the MET, body weight and calories burned computed
+// when it was logged. Duration is in minutes and distance in km.
+type ExerciseEntry struct {
+	ID         int64     `json:"id"`
+	Date       string    `json:"date" example:"2026-01-31"`
+	ExerciseID *int64    `json:"exercise_id,omitempty"`
+	Name       string    `json:"name" example:"Running"`
+	Intensity  string    `json:"intensity" example:"moderate"`
+	Duration   float64   `json:"duration" example:"30"`
+	Distance   *float64  `json:"distance,omitempty" example:"5"`
+	MET        float64   `json:"met" example:"9.8"`
+	Weight     float64   `json:"weight" example:"82.4"`
+	Calories   float64   `json:"calories" example:"404"`
+	Note       string    `json:"note,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ExerciseEntryInput logs an exercise by duration, distance or both. With a distance only,
+// the duration follows from the exercise's reference speed; with both, the MET is adjusted
+// to the actual speed. Intensity defaults to moderate and DistanceUnit to km.
+type ExerciseEntryInput struct {
+	Date         string  `json:"date" binding:"required" example:"2026-01-31"`
+	ExerciseID   int64   `json:"exercise_id" binding:"required" example:"5"`
+	Intensity    string  `json:"intensity,omitempty" binding:"omitempty,oneof=light moderate vigorous" example:"moderate"`
+	Duration     float64 `json:"duration,omitempty" binding:"omitempty,gt=0,lte=1440" example:"30"`
+	Distance     float64 `json:"distance,omitempty" binding:"omitempty,gt=0" example:"5"`
+	DistanceUnit string  `json:"distance_unit,omitempty" binding:"omitempty,oneof=km mi m" example:"km"`
+	Note         string  `json:"note,omitempty" binding:"max=255"`
+}
+
+// ExerciseSettings are the user's exercise preferences. With AddBurnedCalories the calories
+// burned are added back to the day's calorie budget in the diary summary.
+type ExerciseSettings struct {
+	AddBurnedCalories bool      `json:"add_burned_calories"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// ExerciseSettingsInput replaces the user's exercise settings
+type ExerciseSettingsInput struct {
+	AddBurnedCalories bool `json:"add_burned_calories"`
+}
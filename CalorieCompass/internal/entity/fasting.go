@@ -0,0 +1,78 @@
+package entity
+
+import "time"
+
+// Fasting session statuses
+const (
+	FastingActive     = "active"
+	FastingCompleted  = "completed"
+	FastingEndedEarly = "ended_early"
+	FastingBroken     = "broken"
+)
+
+// FastingProtocolCustom is the protocol of fasts with a user-chosen length
+const FastingProtocolCustom = "custom"
+
+// FastingProtocol is a preset fasting schedule
+type FastingProtocol struct {
+	Code         string  `json:"code" example:"16:8"`
+	Name         string  `json:"name" example:"16:8"`
+	FastingHours float64 `json:"fasting_hours" example:"16"`
+	EatingHours  float64 `json:"eating_hours" example:"8"`
+	Description  string  `json:"description"`
+}
+
+// FastingViolation is a diary entry eaten during a fast
+type FastingViolation struct {
+	EntryID  int64     `json:"entry_id"`
+	FoodName string    `json:"food_name"`
+	EatenAt  time.Time `json:"eaten_at"`
+	Calories float64   `json:"calories" example:"180"`
+}
+
+// FastingSession is a fast, in progress while EndedAt is unset. Hours is the time fasted so
+// far and Progress the share of the planned length reached, in percent.
+type FastingSession struct {
+	ID           int64              `json:"id"`
+	Protocol     string             `json:"protocol" example:"16:8"`
+	StartedAt    time.Time          `json:"started_at"`
+	PlannedHours float64            `json:"planned_hours" example:"16"`
+	PlannedEnd   time.Time          `json:"planned_end"`
+	EndedAt      *time.Time         `json:"ended_at,omitempty"`
+	Hours        float64            `json:"hours" example:"14.5"`
+	Progress     float64            `json:"progress" example:"90.6"`
+	Status       string             `json:"status" example:"active"`
+	Violations   []FastingViolation `json:"violations"`
+	Note         string             `json:"note,omitempty"`
+	CreatedAt    time.Time          `json:"created_at"`
+}
+
+// FastingStartInput starts a fast following a protocol. Hours overrides the protocol's
+// fasting length and is required for custom fasts. StartedAt defaults to now.
+type FastingStartInput struct {
+	Protocol  string     `json:"protocol" binding:"required" example:"16:8"`
+	Hours     float64    `json:"hours,omitempty" binding:"omitempty,gt=0,lte=168" example:"16"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	Note      string     `json:"note,omitempty" binding:"max=255"`
+}
+
+// FastingEndInput ends the fast in progress. EndedAt defaults to now.
+type FastingEndInput struct {
+	EndedAt *time.Time `json:"ended_at,omitempty"`
+}
+
+// FastingStats summarizes the fasts started in a date range. A fast is completed when it
+// lasted its planned length without violations; streaks count consecutive days on which a
+// completed fast ended.
+type FastingStats struct {
+	From           string  `json:"from" example:"2026-01-01"`
+	To             string  `json:"to" example:"2026-01-31"`
+	Sessions       int     `json:"sessions" example:"28"`
+	Completed      int     `json:"completed" example:"24"`
+	CompletionRate float64 `json:"completion_rate" example:"85.7"`
+	AverageHours   float64 `json:"average_hours" example:"16.3"`
+	LongestHours   float64 `json:"longest_hours" example:"20.1"`
+	Violations     int     `json:"violations" example:"3"`
+	CurrentStreak  int     `json:"current_streak" example:"6"`
+	LongestStreak  int     `json:"longest_streak" example:"11"`
+}
@@ -0,0 +1,44 @@
+package entity
+
+import "time"
+
+// FavoriteFood is a food the user marked for quick logging, with a default amount and a
+// snapshot of its name and macros at that amount. Food is only set when details are requested.
+type FavoriteFood struct {
+	FoodID    string       `json:"food_id" example:"fs:33691"`
+	FoodName  string       `json:"food_name"`
+	BrandName string       `json:"brand_name,omitempty"`
+	ServingID string       `json:"serving_id"`
+	Amount    float64      `json:"amount"`
+	Unit      string       `json:"unit"`
+	Calories  float64      `json:"calories"`
+	Carbs     float64      `json:"carbs"`
+	Protein   float64      `json:"protein"`
+	Fat       float64      `json:"fat"`
+	CreatedAt time.Time    `json:"created_at"`
+	Food      *FoodDetails `json:"food,omitempty"`
+}
+
+// FavoriteFoodInput represents a request to add or update a favorite food
+type FavoriteFoodInput struct {
+	FoodID    string  `json:"food_id" binding:"required" example:"fs:33691"`
+	ServingID string  `json:"serving_id,omitempty"`
+	Amount    float64 `json:"amount" binding:"omitempty,gt=0" example:"1"`
+	Unit      string  `json:"unit,omitempty" example:"serving"`
+}
+
+// RecentFood is a food from the user's logging history, ranked by how often and how
+// recently it was logged and how close those logs were to the current time of day
+type RecentFood struct {
+	FoodID       string       `json:"food_id" example:"fs:33691"`
+	FoodName     string       `json:"food_name"`
+	BrandName    string       `json:"brand_name,omitempty"`
+	ServingID    string       `json:"serving_id"`
+	Amount       float64      `json:"amount"`
+	Unit         string       `json:"unit"`
+	Calories     float64      `json:"calories"`
+	LogCount     int          `json:"log_count"`
+	LastLoggedAt time.Time    `json:"last_logged_at"`
+	Score        float64      `json:"score"`
+	Food         *FoodDetails `json:"food,omitempty"`
+}
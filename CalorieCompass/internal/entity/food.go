@@ -1,19 +1,37 @@
 package entity
 
-// Food represents a food item
+// Food sources, used as ID namespaces (e.g. "custom:123" or "fs:456")
+const (
+	FoodSourceFatSecret = "fs"
+	FoodSourceCustom    = "custom"
+	FoodSourceCatalog   = "catalog"
+	FoodSourceRecipe    = "recipe"
+)
+
+// Food represents a food item. Ingredients is only used to flag restrictions and is not
+// returned; Per100g, also not returned, marks Calories and the macros as per 100 g/ml
+// values rather than a listed serving's, which sorting by calories relies on. FlagsUnverified marks foods flagged from their name alone because their
+// details could not be checked; strict restriction profiles leave them out.
 type Food struct {
-	ID        string  `json:"id"`
-	Name      string  `json:"name"`
-	BrandName string  `json:"brand_name,omitempty"`
-	Type      string  `json:"type"`
-	URL       string  `json:"url"`
-	Calories  float64 `json:"calories"`
-	Carbs     float64 `json:"carbs"`
-	Protein   float64 `json:"protein"`
-	Fat       float64 `json:"fat"`
-}
-
-// Serving represents a serving size for a food
+	ID              string       `json:"id"`
+	Name            string       `json:"name"`
+	BrandName       string       `json:"brand_name,omitempty"`
+	Type            string       `json:"type"`
+	URL             string       `json:"url"`
+	Calories        float64      `json:"calories"`
+	Carbs           float64      `json:"carbs"`
+	Protein         float64      `json:"protein"`
+	Fat             float64      `json:"fat"`
+	Source          string       `json:"source,omitempty"`
+	Quality         *FoodQuality `json:"quality,omitempty"`
+	Flags           []FoodFlag   `json:"flags,omitempty"`
+	FlagsUnverified bool         `json:"flags_unverified,omitempty"`
+	Ingredients     string       `json:"-"`
+	Per100g         bool         `json:"-"`
+}
+
+// Serving represents a serving size for a food. Cholesterol, sodium, calcium, iron,
+// potassium and vitamin C are in mg, vitamins A (RAE) and D in µg, the other nutrients in g.
 type Serving struct {
 	ID                     string  `json:"id"`
 	Description            string  `json:"description"`
@@ -31,21 +49,109 @@ type Serving struct {
 	Cholesterol            float64 `json:"cholesterol"`
 	Sodium                 float64 `json:"sodium"`
 	Sugar                  float64 `json:"sugar"`
+	Calcium                float64 `json:"calcium"`
+	Iron                   float64 `json:"iron"`
+	Potassium              float64 `json:"potassium"`
+	VitaminA               float64 `json:"vitamin_a"`
+	VitaminC               float64 `json:"vitamin_c"`
+	VitaminD               float64 `json:"vitamin_d"`
 }
 
-// FoodDetails contains detailed information about a food
+// FoodDetails contains detailed information about a food. Ingredients is the ingredient
+// list when the source provides one, and Attributes the allergens and diets it declares.
 type FoodDetails struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	BrandName string    `json:"brand_name,omitempty"`
-	Servings  []Serving `json:"servings"`
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	BrandName   string          `json:"brand_name,omitempty"`
+	Source      string          `json:"source,omitempty"`
+	Servings    []Serving       `json:"servings"`
+	Ingredients string          `json:"ingredients,omitempty"`
+	Attributes  *FoodAttributes `json:"attributes,omitempty"`
+	Quality     *FoodQuality    `json:"quality,omitempty"`
+	Flags       []FoodFlag      `json:"flags,omitempty"`
+}
+
+// NutriScore is a food's Nutri-Score (2023 algorithm) per 100 g or 100 ml, with the
+// category whose thresholds were used and the points the score is made of
+type NutriScore struct {
+	Grade    string `json:"grade" example:"b"`
+	Score    int    `json:"score" example:"1"`
+	Category string `json:"category" example:"general"`
+	Negative int    `json:"negative_points" example:"5"`
+	Positive int    `json:"positive_points" example:"4"`
+}
+
+// FoodQuality rates a food from its per-100 g/ml values. NutrientDensity is omitted for
+// foods without energy.
+type FoodQuality struct {
+	NutriScore      NutriScore `json:"nutri_score"`
+	NutrientDensity *float64   `json:"nutrient_density,omitempty" example:"42.5"`
+}
+
+// Food search sort orders
+const (
+	SortRelevance      = "relevance"
+	SortCalories       = "calories"
+	SortProteinDensity = "protein_density"
+)
+
+// Food type filters, matching Food.Type case-insensitively
+const (
+	FoodTypeBrand   = "brand"
+	FoodTypeGeneric = "generic"
+)
+
+// Dietary tags derived from per-100 g nutrients
+const (
+	TagHighProtein = "high_protein"
+	TagHighFiber   = "high_fiber"
+	TagLowCalorie  = "low_calorie"
+	TagLowCarb     = "low_carb"
+	TagLowFat      = "low_fat"
+	TagLowSugar    = "low_sugar"
+	TagLowSodium   = "low_sodium"
+)
+
+// NutrientRange bounds a per-100 g nutrient value; nil bounds are open
+type NutrientRange struct {
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+}
+
+// Active reports whether either bound is set
+func (r NutrientRange) Active() bool {
+	return r.Min != nil || r.Max != nil
+}
+
+// Contains reports whether value lies within the range
+func (r NutrientRange) Contains(value float64) bool {
+	return (r.Min == nil || value >= *r.Min) && (r.Max == nil || value <= *r.Max)
+}
+
+// FoodSearchFilters narrows a food search. Nutrient ranges are per 100 g (or 100 ml).
+type FoodSearchFilters struct {
+	Type     string        `json:"type,omitempty" example:"generic"`
+	Calories NutrientRange `json:"calories"`
+	Protein  NutrientRange `json:"protein"`
+	Carbs    NutrientRange `json:"carbs"`
+	Fat      NutrientRange `json:"fat"`
+	Tags     []string      `json:"tags,omitempty"`
+}
+
+// NeedsNutrients reports whether the filters depend on per-100 g nutrient values
+func (f FoodSearchFilters) NeedsNutrients() bool {
+	return f.Calories.Active() || f.Protein.Active() || f.Carbs.Active() || f.Fat.Active() || len(f.Tags) > 0
 }
 
 // FoodSearchRequest represents a request to search for foods
 type FoodSearchRequest struct {
-	Query string `json:"query" binding:"required"`
-	Page  int    `json:"page" default:"0"`
-	Limit int    `json:"limit" default:"50"`
+	UserID       int64              `json:"-"`
+	Query        string             `json:"query" binding:"required"`
+	Page         int                `json:"page" default:"0"`
+	Limit        int                `json:"limit" default:"50"`
+	Filters      FoodSearchFilters  `json:"filters"`
+	Sort         string             `json:"sort,omitempty" default:"relevance"`
+	Restrictions RestrictionProfile `json:"-"`
 }
 
 // FoodSearchResponse represents the response from a food search
@@ -60,3 +166,49 @@ type FoodSearchResponse struct {
 type FoodDetailsRequest struct {
 	FoodID string `json:"food_id" binding:"required"`
 }
+
+// FoodNutritionRequest represents a request for a food's nutrition at an arbitrary amount
+type FoodNutritionRequest struct {
+	UserID    int64   `json:"-"`
+	FoodID    string  `json:"food_id" binding:"required"`
+	Amount    float64 `json:"amount" binding:"required"`
+	Unit      string  `json:"unit" binding:"required"`
+	ServingID string  `json:"serving_id,omitempty"`
+}
+
+// FoodNutritionResponse contains a food's nutrients scaled to the requested amount
+type FoodNutritionResponse struct {
+	FoodID        string  `json:"food_id"`
+	Name          string  `json:"name"`
+	BrandName     string  `json:"brand_name,omitempty"`
+	Amount        float64 `json:"amount"`
+	Unit          string  `json:"unit"`
+	BaseServingID string  `json:"base_serving_id"`
+	Density       float64 `json:"density,omitempty"`
+	Nutrition     Serving `json:"nutrition"`
+}
+
+// CustomFoodInput represents a request to create or update a user-defined food
+type CustomFoodInput struct {
+	Name      string    `json:"name" binding:"required" example:"Grandma's lentil soup"`
+	BrandName string    `json:"brand_name,omitempty"`
+	Servings  []Serving `json:"servings" binding:"required,min=1"`
+}
+
+// FoodBatchRequest represents a request for the details of several foods at once
+type FoodBatchRequest struct {
+	FoodIDs []string `json:"food_ids" binding:"required,min=1,max=100,dive,required" example:"fs:33691,custom:12"`
+}
+
+// FoodBatchItem is the result for a single food in a batch; exactly one of Food and Error is set
+type FoodBatchItem struct {
+	FoodID string       `json:"food_id"`
+	Food   *FoodDetails `json:"food,omitempty"`
+	Error  string       `json:"error,omitempty"`
+}
+
+// FoodBatchResponse lists one result per distinct requested food ID, in request order
+type FoodBatchResponse struct {
+	Items  []FoodBatchItem `json:"items"`
+	Failed int             `json:"failed"`
+}
@@ -0,0 +1,125 @@
+package entity
+
+import "time"
+
+// GlycemicIndexMatch is the glycemic index (glucose = 100) found for a food, with the
+// reference food or category it was taken from and whether its name or its dataset
+// category matched
+type GlycemicIndexMatch struct {
+	Index     int    `json:"glycemic_index" example:"51"`
+	Class     string `json:"class" example:"low"`
+	Reference string `json:"reference" example:"Banana"`
+	Source    string `json:"source" example:"name"`
+}
+
+// GlycemicEntry is the carbohydrate quality of one diary entry. Net carbs are carbs minus
+// fiber; the glycemic load is the glycemic index times the net carbs divided by 100, and
+// both are omitted when no glycemic index is known for the food.
+type GlycemicEntry struct {
+	EntryID       int64               `json:"entry_id"`
+	Meal          string              `json:"meal" example:"breakfast"`
+	FoodID        string              `json:"food_id" example:"fs:33691"`
+	FoodName      string              `json:"food_name"`
+	EatenAt       time.Time           `json:"eaten_at"`
+	Carbs         float64             `json:"carbs" example:"27"`
+	Fiber         float64             `json:"fiber" example:"3.1"`
+	NetCarbs      float64             `json:"net_carbs" example:"23.9"`
+	GlycemicIndex *GlycemicIndexMatch `json:"glycemic_index,omitempty"`
+	GlycemicLoad  *float64            `json:"glycemic_load,omitempty" example:"12.2"`
+	LoadClass     string              `json:"load_class,omitempty" example:"medium"`
+}
+
+// GlycemicTotals sums the carbohydrates and glycemic load of several entries. AverageIndex
+// is the glycemic index weighted by net carbs, and Coverage the percentage of net carbs
+// from foods with a known glycemic index; the load only counts those foods.
+type GlycemicTotals struct {
+	Carbs        float64  `json:"carbs" example:"210"`
+	Fiber        float64  `json:"fiber" example:"28"`
+	NetCarbs     float64  `json:"net_carbs" example:"182"`
+	GlycemicLoad float64  `json:"glycemic_load" example:"96.4"`
+	LoadClass    string   `json:"load_class" example:"medium"`
+	AverageIndex *float64 `json:"average_index,omitempty" example:"56.8"`
+	Coverage     float64  `json:"coverage" example:"91.5"`
+}
+
+// GlycemicMeal is the carbohydrate quality of one meal slot
+type GlycemicMeal struct {
+	Meal   string         `json:"meal" example:"breakfast"`
+	Totals GlycemicTotals `json:"totals"`
+}
+
+// GlycemicDay is the carbohydrate quality of a day's diary. The day's load class uses the
+// daily thresholds (below 80 low, above 120 high), meals and entries the per-serving ones
+// (10 or less low, 20 or more high).
+type GlycemicDay struct {
+	Date    string          `json:"date" example:"2026-01-31"`
+	Totals  GlycemicTotals  `json:"totals"`
+	Meals   []GlycemicMeal  `json:"meals"`
+	Entries []GlycemicEntry `json:"entries"`
+}
+
+// Blood glucose units; readings are stored in mg/dL
+const (
+	GlucoseMgDl  = "mg/dL"
+	GlucoseMmolL = "mmol/L"
+)
+
+// MgDlPerMmolL converts blood glucose from mmol/L to mg/dL
+const MgDlPerMmolL = 18.016
+
+// When a blood glucose reading was taken
+const (
+	GlucoseFasting    = "fasting"
+	GlucoseBeforeMeal = "before_meal"
+	GlucoseAfterMeal  = "after_meal"
+	GlucoseBedtime    = "bedtime"
+	GlucoseRandom     = "random"
+)
+
+// GlucoseReading is a blood glucose measurement, in mg/dL and mmol/L
+type GlucoseReading struct {
+	ID         int64     `json:"id"`
+	MgDl       float64   `json:"mg_dl" example:"112"`
+	MmolL      float64   `json:"mmol_l" example:"6.2"`
+	Context    string    `json:"context" example:"after_meal"`
+	MeasuredAt time.Time `json:"measured_at"`
+	Note       string    `json:"note,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// GlucoseReadingInput records a blood glucose measurement. Unit defaults to mg/dL and
+// MeasuredAt to now.
+type GlucoseReadingInput struct {
+	Value      float64    `json:"value" binding:"required,gt=0" example:"112"`
+	Unit       string     `json:"unit,omitempty" binding:"omitempty,oneof=mg/dL mmol/L" example:"mg/dL"`
+	Context    string     `json:"context,omitempty" binding:"omitempty,oneof=fasting before_meal after_meal bedtime random" example:"after_meal"`
+	MeasuredAt *time.Time `json:"measured_at,omitempty"`
+	Note       string     `json:"note,omitempty" binding:"max=255"`
+}
+
+// MealGlucose relates a meal to the blood glucose readings taken after it. A meal is a run
+// of diary entries eaten less than 30 minutes apart. Baseline is the last reading in the
+// hour before the meal, Peak the highest reading in the post-meal window and Rise their
+// difference, all in mg/dL.
+type MealGlucose struct {
+	Meal          string           `json:"meal" example:"lunch"`
+	EatenAt       time.Time        `json:"eaten_at"`
+	Foods         []string         `json:"foods"`
+	Totals        GlycemicTotals   `json:"totals"`
+	Baseline      *float64         `json:"baseline,omitempty" example:"95"`
+	Peak          *float64         `json:"peak,omitempty" example:"148"`
+	Rise          *float64         `json:"rise,omitempty" example:"53"`
+	MinutesToPeak *int             `json:"minutes_to_peak,omitempty" example:"45"`
+	Readings      []GlucoseReading `json:"readings"`
+}
+
+// GlucoseMealReport lists the meals of a date range with their post-meal readings.
+// Correlation is the Pearson correlation between the glycemic load of meals and their
+// glucose rise, given when at least three meals have both.
+type GlucoseMealReport struct {
+	From          string        `json:"from" example:"2026-01-01"`
+	To            string        `json:"to" example:"2026-01-31"`
+	WindowMinutes int           `json:"window_minutes" example:"120"`
+	Meals         []MealGlucose `json:"meals"`
+	Correlation   *float64      `json:"correlation,omitempty" example:"0.62"`
+}
@@ -0,0 +1,110 @@
+package entity
+
+import "time"
+
+// Macro target presets
+const (
+	MacroPresetBalanced    = "balanced"
+	MacroPresetHighProtein = "high_protein"
+	MacroPresetLowCarb     = "low_carb"
+	MacroPresetKeto        = "keto"
+	MacroPresetCustom      = "custom"
+)
+
+// Ways to split calories between macronutrients in a custom target
+const (
+	MacroSplitPercent = "percent"
+	MacroSplitGrams   = "grams"
+)
+
+// Weekdays lists the weekday names accepted by goal schedules, indexed by time.Weekday
+var Weekdays = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+// MacroPreset is a named split of calories between carbs, protein and fat, in percent.
+// NetCarbs presets count carbs minus fiber against the carb target.
+type MacroPreset struct {
+	Code        string  `json:"code" example:"high_protein"`
+	Name        string  `json:"name" example:"High protein"`
+	CarbsPct    float64 `json:"carbs_pct" example:"40"`
+	ProteinPct  float64 `json:"protein_pct" example:"30"`
+	FatPct      float64 `json:"fat_pct" example:"30"`
+	NetCarbs    bool    `json:"net_carbs"`
+	Description string  `json:"description"`
+}
+
+// MacroTargetInput describes a day's targets. Presets need calories; custom targets split
+// calories by percent (summing to 100) or set grams directly, in which case calories
+// default to the energy of the grams. NetCarbs overrides the preset's carb counting.
+type MacroTargetInput struct {
+	Calories float64 `json:"calories,omitempty" binding:"omitempty,gt=0,lte=10000" example:"2200"`
+	Preset   string  `json:"preset" binding:"required,oneof=balanced high_protein low_carb keto custom" example:"high_protein"`
+	Split    string  `json:"split,omitempty" binding:"omitempty,oneof=percent grams" example:"percent"`
+	Carbs    float64 `json:"carbs,omitempty" binding:"gte=0" example:"40"`
+	Protein  float64 `json:"protein,omitempty" binding:"gte=0" example:"30"`
+	Fat      float64 `json:"fat,omitempty" binding:"gte=0" example:"30"`
+	NetCarbs *bool   `json:"net_carbs,omitempty"`
+}
+
+// GoalScheduleInput overrides the targets on some weekdays, e.g. training days
+type GoalScheduleInput struct {
+	Label    string           `json:"label,omitempty" binding:"max=30" example:"training"`
+	Weekdays []string         `json:"weekdays" binding:"required,min=1,dive,oneof=sunday monday tuesday wednesday thursday friday saturday" example:"monday,wednesday,friday"`
+	Target   MacroTargetInput `json:"target"`
+}
+
+// NutritionGoalInput sets the targets from a date on, replacing any goal starting the same
+// date. Schedules override the default target on their weekdays.
+type NutritionGoalInput struct {
+	EffectiveFrom string              `json:"effective_from" binding:"required" example:"2026-02-01"`
+	Label         string              `json:"label,omitempty" binding:"max=30" example:"rest"`
+	Target        MacroTargetInput    `json:"target"`
+	Schedules     []GoalScheduleInput `json:"schedules,omitempty" binding:"max=7,dive"`
+}
+
+// NutritionTarget is a day's calorie and macronutrient targets in kcal and g, with each
+// macronutrient's share of calories in percent
+type NutritionTarget struct {
+	Label      string  `json:"label,omitempty" example:"training"`
+	Preset     string  `json:"preset" example:"high_protein"`
+	Calories   float64 `json:"calories" example:"2200"`
+	Carbs      float64 `json:"carbs" example:"220"`
+	Protein    float64 `json:"protein" example:"165"`
+	Fat        float64 `json:"fat" example:"73.3"`
+	CarbsPct   float64 `json:"carbs_pct" example:"40"`
+	ProteinPct float64 `json:"protein_pct" example:"30"`
+	FatPct     float64 `json:"fat_pct" example:"30"`
+	NetCarbs   bool    `json:"net_carbs"`
+}
+
+// GoalDay is a goal's target on one weekday
+type GoalDay struct {
+	Weekday string          `json:"weekday" example:"monday"`
+	Target  NutritionTarget `json:"target"`
+}
+
+// NutritionGoal is a weekly schedule of targets that applies from EffectiveFrom until the
+// next goal starts
+type NutritionGoal struct {
+	ID            int64     `json:"id"`
+	EffectiveFrom string    `json:"effective_from" example:"2026-02-01"`
+	Days          []GoalDay `json:"days"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// TargetProgress compares a consumed amount with its target
+type TargetProgress struct {
+	Target    float64 `json:"target" example:"165"`
+	Consumed  float64 `json:"consumed" example:"120"`
+	Remaining float64 `json:"remaining" example:"45"`
+	Progress  float64 `json:"progress" example:"72.7"`
+}
+
+// DailyTargets is a day's progress against the targets active on that date. Calories count
+// the summary's net calories and carbs count net carbs when the target does.
+type DailyTargets struct {
+	Target   NutritionTarget `json:"target"`
+	Calories TargetProgress  `json:"calories"`
+	Carbs    TargetProgress  `json:"carbs"`
+	Protein  TargetProgress  `json:"protein"`
+	Fat      TargetProgress  `json:"fat"`
+}
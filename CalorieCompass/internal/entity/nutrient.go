@@ -0,0 +1,80 @@
+package entity
+
+// Adequacy statuses of a nutrient's average intake
+const (
+	AdequacyLow       = "low"
+	AdequacyAdequate  = "adequate"
+	AdequacyOverLimit = "over_limit"
+)
+
+// Positions of a macronutrient's share of energy relative to its acceptable range
+const (
+	RangeBelow  = "below"
+	RangeWithin = "within"
+	RangeAbove  = "above"
+)
+
+// NutrientReference is a daily intake reference: a recommended dietary allowance (rda) or
+// adequate intake (ai), with the upper limit where one applies
+type NutrientReference struct {
+	Nutrient   string   `json:"nutrient" example:"sodium"`
+	Unit       string   `json:"unit" example:"mg"`
+	Type       string   `json:"type" example:"ai"`
+	Amount     float64  `json:"amount" example:"1500"`
+	UpperLimit *float64 `json:"upper_limit,omitempty" example:"2300"`
+}
+
+// MacroRange is an acceptable macronutrient distribution range in percent of energy
+type MacroRange struct {
+	Nutrient string  `json:"nutrient" example:"fat"`
+	Min      float64 `json:"min" example:"20"`
+	Max      float64 `json:"max" example:"35"`
+}
+
+// NutrientReferences are the reference values of a life-stage group
+type NutrientReferences struct {
+	Sex        string              `json:"sex" example:"female"`
+	Age        int                 `json:"age" example:"35"`
+	Group      string              `json:"group" example:"31-50"`
+	References []NutrientReference `json:"references"`
+	Ranges     []MacroRange        `json:"ranges"`
+}
+
+// NutrientAdequacy compares a nutrient's average daily intake with its reference. Gap and
+// Excess are the amounts below the reference and above the upper limit; TopFoods lists the
+// foods contributing most to the nutrient when it is low or over the limit.
+type NutrientAdequacy struct {
+	Nutrient   string    `json:"nutrient" example:"sodium"`
+	Unit       string    `json:"unit" example:"mg"`
+	Type       string    `json:"type" example:"ai"`
+	Intake     float64   `json:"intake" example:"3120"`
+	Reference  float64   `json:"reference" example:"1500"`
+	UpperLimit *float64  `json:"upper_limit,omitempty" example:"2300"`
+	Percent    float64   `json:"percent" example:"208"`
+	Status     string    `json:"status" example:"over_limit"`
+	Gap        float64   `json:"gap,omitempty"`
+	Excess     float64   `json:"excess,omitempty" example:"820"`
+	TopFoods   []TopFood `json:"top_foods"`
+}
+
+// MacroRangeCheck places a macronutrient's average share of energy against its range
+type MacroRangeCheck struct {
+	Nutrient string  `json:"nutrient" example:"fat"`
+	Min      float64 `json:"min" example:"20"`
+	Max      float64 `json:"max" example:"35"`
+	Percent  float64 `json:"percent" example:"38.4"`
+	Status   string  `json:"status" example:"above"`
+}
+
+// NutrientAnalysis compares the average daily intake over the logged days of a rolling
+// window with the reference values for the user's age and sex
+type NutrientAnalysis struct {
+	From       string             `json:"from" example:"2026-01-25"`
+	To         string             `json:"to" example:"2026-01-31"`
+	DaysLogged int                `json:"days_logged" example:"6"`
+	Sex        string             `json:"sex" example:"female"`
+	Age        int                `json:"age" example:"35"`
+	Group      string             `json:"group" example:"31-50"`
+	Nutrients  []NutrientAdequacy `json:"nutrients"`
+	Ranges     []MacroRangeCheck  `json:"ranges"`
+}
@@ -0,0 +1,92 @@
+package entity
+
+import "time"
+
+// RecipeIngredient is a food in a recipe with a snapshot of its name and its nutrients
+// scaled to the ingredient amount
+type RecipeIngredient struct {
+	FoodID    string  `json:"food_id" example:"fs:33691"`
+	FoodName  string  `json:"food_name"`
+	BrandName string  `json:"brand_name,omitempty"`
+	ServingID string  `json:"serving_id"`
+	Amount    float64 `json:"amount"`
+	Unit      string  `json:"unit"`
+	Nutrition Serving `json:"nutrition"`
+}
+
+// Recipe is a user-defined recipe. Total and PerServing are computed from the ingredients
+// and refreshed whenever the recipe or one of its custom food ingredients changes.
+type Recipe struct {
+	ID           string             `json:"id" example:"recipe:12"`
+	Name         string             `json:"name"`
+	Servings     float64            `json:"servings"`
+	YieldAmount  float64            `json:"yield_amount,omitempty"`
+	YieldUnit    string             `json:"yield_unit,omitempty"`
+	Instructions string             `json:"instructions,omitempty"`
+	Ingredients  []RecipeIngredient `json:"ingredients"`
+	Total        Serving            `json:"total"`
+	PerServing   Serving            `json:"per_serving"`
+	Flags        []FoodFlag         `json:"flags,omitempty"`
+	ComputedAt   time.Time          `json:"computed_at"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+}
+
+// RecipeIngredientInput is an ingredient given as a food, an amount and a unit (or "serving")
+type RecipeIngredientInput struct {
+	FoodID    string  `json:"food_id" binding:"required" example:"fs:33691"`
+	ServingID string  `json:"serving_id,omitempty"`
+	Amount    float64 `json:"amount" binding:"required,gt=0" example:"200"`
+	Unit      string  `json:"unit" binding:"required" example:"g"`
+}
+
+// RecipeInput represents a request to create or update a recipe. The optional yield is the
+// cooked weight or volume of the whole recipe, e.g. 1.2 kg.
+type RecipeInput struct {
+	Name         string                  `json:"name" binding:"required" example:"Chili con carne"`
+	Servings     float64                 `json:"servings" binding:"required,gt=0" example:"4"`
+	YieldAmount  float64                 `json:"yield_amount,omitempty" binding:"omitempty,gt=0" example:"1.2"`
+	YieldUnit    string                  `json:"yield_unit,omitempty" example:"kg"`
+	Instructions string                  `json:"instructions,omitempty"`
+	Ingredients  []RecipeIngredientInput `json:"ingredients" binding:"required,min=1,dive"`
+}
+
+// Recipe import content formats
+const (
+	RecipeImportAuto   = "auto"
+	RecipeImportJSONLD = "jsonld"
+	RecipeImportHTML   = "html"
+	RecipeImportText   = "text"
+)
+
+// RecipeImportRequest carries pasted recipe content: schema.org Recipe JSON-LD, an HTML page
+// containing it, or a plain-text ingredient list with one ingredient per line
+type RecipeImportRequest struct {
+	Content  string  `json:"content" binding:"required"`
+	Format   string  `json:"format,omitempty" binding:"omitempty,oneof=auto jsonld html text" example:"auto"`
+	Name     string  `json:"name,omitempty" example:"Pancakes"`
+	Servings float64 `json:"servings,omitempty" binding:"omitempty,gt=0" example:"4"`
+}
+
+// ImportedIngredient is a parsed ingredient line with its best food match. Ingredient is
+// the ready-to-submit recipe ingredient, set only when a match was found.
+type ImportedIngredient struct {
+	Line         string                 `json:"line" example:"1 1/2 cups milk"`
+	Quantity     float64                `json:"quantity"`
+	Unit         string                 `json:"unit,omitempty"`
+	Food         string                 `json:"food" example:"milk"`
+	Note         string                 `json:"note,omitempty"`
+	Match        *Food                  `json:"match,omitempty"`
+	Confidence   float64                `json:"confidence"`
+	Alternatives []Food                 `json:"alternatives,omitempty"`
+	Ingredient   *RecipeIngredientInput `json:"ingredient,omitempty"`
+}
+
+// RecipeImportDraft is an unsaved recipe for the user to review; Recipe contains only the
+// matched ingredients and can be posted to /recipe once confirmed
+type RecipeImportDraft struct {
+	Format      string               `json:"format"`
+	Recipe      RecipeInput          `json:"recipe"`
+	Ingredients []ImportedIngredient `json:"ingredients"`
+	Unmatched   int                  `json:"unmatched"`
+}
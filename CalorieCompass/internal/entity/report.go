@@ -0,0 +1,86 @@
+package entity
+
+// Report granularities
+const (
+	GranularityDay   = "day"
+	GranularityWeek  = "week"
+	GranularityMonth = "month"
+)
+
+// MacroDistribution is the share of energy from carbs, protein and fat, in percent
+type MacroDistribution struct {
+	CarbsPct   float64 `json:"carbs_pct" example:"45.2"`
+	ProteinPct float64 `json:"protein_pct" example:"22.1"`
+	FatPct     float64 `json:"fat_pct" example:"32.7"`
+}
+
+// ReportAdherence is the share of logged days with a goal, in percent, on which each
+// intake was within the report tolerance of its target. Calories count net calories.
+type ReportAdherence struct {
+	Days     int     `json:"days" example:"26"`
+	Calories float64 `json:"calories" example:"73.1"`
+	Carbs    float64 `json:"carbs" example:"57.7"`
+	Protein  float64 `json:"protein" example:"65.4"`
+	Fat      float64 `json:"fat" example:"61.5"`
+}
+
+// ReportDay is a logged day ranked by how far its net calories were from the target
+type ReportDay struct {
+	Date      string  `json:"date" example:"2026-01-14"`
+	Calories  float64 `json:"calories" example:"2080"`
+	Target    float64 `json:"target" example:"2100"`
+	Deviation float64 `json:"deviation" example:"-1"`
+}
+
+// ReportPeriod summarizes one day, week (Monday to Sunday) or month of a report, clipped to
+// the report's range. Averages are per logged day.
+type ReportPeriod struct {
+	Start        string            `json:"start" example:"2026-01-05"`
+	End          string            `json:"end" example:"2026-01-11"`
+	Days         int               `json:"days" example:"7"`
+	DaysLogged   int               `json:"days_logged" example:"6"`
+	Average      Serving           `json:"average"`
+	Distribution MacroDistribution `json:"distribution"`
+	Adherence    *ReportAdherence  `json:"adherence,omitempty"`
+}
+
+// TopFood is a food's contribution to a nutrient over a report's range
+type TopFood struct {
+	FoodID   string  `json:"food_id" example:"fs:33691"`
+	FoodName string  `json:"food_name"`
+	Entries  int     `json:"entries" example:"12"`
+	Amount   float64 `json:"amount" example:"2340"`
+	Share    float64 `json:"share" example:"14.8"`
+}
+
+// NutrientTopFoods lists the foods contributing most to a nutrient
+type NutrientTopFoods struct {
+	Nutrient string    `json:"nutrient" example:"protein"`
+	Foods    []TopFood `json:"foods"`
+}
+
+// LoggingCompleteness shows how consistently the diary was kept. A day is complete when
+// enough meal slots were logged.
+type LoggingCompleteness struct {
+	Days         int     `json:"days" example:"31"`
+	DaysLogged   int     `json:"days_logged" example:"28"`
+	CompleteDays int     `json:"complete_days" example:"22"`
+	LoggedPct    float64 `json:"logged_pct" example:"90.3"`
+	CompletePct  float64 `json:"complete_pct" example:"71"`
+	AverageMeals float64 `json:"average_meals" example:"3.2"`
+}
+
+// NutritionReport reviews the diary over a date range
+type NutritionReport struct {
+	From         string              `json:"from" example:"2026-01-01"`
+	To           string              `json:"to" example:"2026-01-31"`
+	Granularity  string              `json:"granularity" example:"week"`
+	Average      Serving             `json:"average"`
+	Distribution MacroDistribution   `json:"distribution"`
+	Adherence    *ReportAdherence    `json:"adherence,omitempty"`
+	BestDays     []ReportDay         `json:"best_days"`
+	WorstDays    []ReportDay         `json:"worst_days"`
+	Periods      []ReportPeriod      `json:"periods"`
+	TopFoods     []NutrientTopFoods  `json:"top_foods"`
+	Completeness LoggingCompleteness `json:"completeness"`
+}
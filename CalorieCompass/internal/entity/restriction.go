@@ -0,0 +1,105 @@
+package entity
+
+import "time"
+
+// The 14 allergens that EU food law requires to be declared
+const (
+	AllergenGluten      = "gluten"
+	AllergenCrustaceans = "crustaceans"
+	AllergenEggs        = "eggs"
+	AllergenFish        = "fish"
+	AllergenPeanuts     = "peanuts"
+	AllergenSoybeans    = "soybeans"
+	AllergenMilk        = "milk"
+	AllergenTreeNuts    = "tree_nuts"
+	AllergenCelery      = "celery"
+	AllergenMustard     = "mustard"
+	AllergenSesame      = "sesame"
+	AllergenSulphites   = "sulphites"
+	AllergenLupin       = "lupin"
+	AllergenMolluscs    = "molluscs"
+)
+
+// Allergens lists the allergens a restriction profile can avoid
+var Allergens = []string{
+	AllergenGluten, AllergenCrustaceans, AllergenEggs, AllergenFish, AllergenPeanuts, AllergenSoybeans,
+	AllergenMilk, AllergenTreeNuts, AllergenCelery, AllergenMustard, AllergenSesame, AllergenSulphites,
+	AllergenLupin, AllergenMolluscs,
+}
+
+// Diets a restriction profile can follow
+const (
+	DietVegetarian  = "vegetarian"
+	DietVegan       = "vegan"
+	DietHalal       = "halal"
+	DietGlutenFree  = "gluten_free"
+	DietLactoseFree = "lactose_free"
+)
+
+// Diets lists the diets a restriction profile can follow
+var Diets = []string{DietVegetarian, DietVegan, DietHalal, DietGlutenFree, DietLactoseFree}
+
+// Kinds of restriction a food can be flagged for
+const (
+	RestrictionAllergen = "allergen"
+	RestrictionDiet     = "diet"
+)
+
+// Where the reason for a flag was found
+const (
+	FlagSourceAttribute   = "attribute"
+	FlagSourceIngredients = "ingredients"
+	FlagSourceName        = "name"
+)
+
+// RestrictionProfile lists the allergens and diets a user avoids or follows. Strict
+// profiles hide flagged foods from search results instead of only flagging them.
+type RestrictionProfile struct {
+	Allergens []string   `json:"allergens" example:"peanuts,milk"`
+	Diets     []string   `json:"diets" example:"vegetarian"`
+	Strict    bool       `json:"strict"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// Active reports whether the profile restricts anything
+func (p RestrictionProfile) Active() bool {
+	return len(p.Allergens) > 0 || len(p.Diets) > 0
+}
+
+// HidesFlagged reports whether flagged foods are left out of search results
+func (p RestrictionProfile) HidesFlagged() bool {
+	return p.Strict && p.Active()
+}
+
+// RestrictionProfileInput represents a request to replace a user's restriction profile
+type RestrictionProfileInput struct {
+	Allergens []string `json:"allergens" binding:"max=14" example:"peanuts,milk"`
+	Diets     []string `json:"diets" binding:"max=5" example:"vegetarian"`
+	Strict    bool     `json:"strict"`
+}
+
+// RestrictionOptions lists the allergen and diet codes a profile accepts
+type RestrictionOptions struct {
+	Allergens []string `json:"allergens"`
+	Diets     []string `json:"diets"`
+}
+
+// FoodAttributes are the allergens and diets a provider has declared for a food. Allergens
+// the food was checked not to contain and diets it was checked not to suit are listed too,
+// so that only undeclared restrictions fall back to the food's text.
+type FoodAttributes struct {
+	Allergens       []string `json:"allergens,omitempty" example:"milk"`
+	FreeFrom        []string `json:"free_from,omitempty" example:"peanuts,gluten"`
+	SuitableDiets   []string `json:"suitable_diets,omitempty" example:"vegetarian"`
+	UnsuitableDiets []string `json:"unsuitable_diets,omitempty" example:"vegan"`
+}
+
+// FoodFlag marks a conflict between a food and the user's restriction profile: the
+// restriction, whether it is an allergen or a diet, where it was found and, for text
+// matches, the word that matched
+type FoodFlag struct {
+	Restriction string `json:"restriction" example:"milk"`
+	Kind        string `json:"kind" example:"allergen"`
+	Source      string `json:"source" example:"ingredients"`
+	Match       string `json:"match,omitempty" example:"whey"`
+}
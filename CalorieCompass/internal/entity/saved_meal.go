@@ -0,0 +1,67 @@
+package entity
+
+import "time"
+
+// SavedMealItem is a food in a saved meal with a snapshot of its nutrients at the saved amount
+type SavedMealItem struct {
+	FoodID    string  `json:"food_id" example:"fs:33691"`
+	FoodName  string  `json:"food_name"`
+	BrandName string  `json:"brand_name,omitempty"`
+	ServingID string  `json:"serving_id"`
+	Amount    float64 `json:"amount"`
+	Unit      string  `json:"unit"`
+	Nutrition Serving `json:"nutrition"`
+}
+
+// SavedMeal is a named group of foods that can be logged to the diary in one call
+type SavedMeal struct {
+	ID        int64           `json:"id"`
+	Name      string          `json:"name" example:"Usual breakfast"`
+	Meal      string          `json:"meal,omitempty" example:"breakfast"`
+	Items     []SavedMealItem `json:"items"`
+	Totals    Serving         `json:"totals"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// SavedMealItemInput is a food in a saved meal given as an amount and a unit (or "serving")
+type SavedMealItemInput struct {
+	FoodID    string  `json:"food_id" binding:"required" example:"fs:33691"`
+	ServingID string  `json:"serving_id,omitempty"`
+	Amount    float64 `json:"amount" binding:"required,gt=0" example:"1"`
+	Unit      string  `json:"unit" binding:"required" example:"serving"`
+}
+
+// SavedMealInput represents a request to create or replace a saved meal. Meal is the
+// default slot it is logged under.
+type SavedMealInput struct {
+	Name  string               `json:"name" binding:"required" example:"Usual breakfast"`
+	Meal  string               `json:"meal,omitempty" binding:"omitempty,oneof=breakfast lunch dinner snack" example:"breakfast"`
+	Items []SavedMealItemInput `json:"items" binding:"required,min=1,max=50,dive"`
+}
+
+// SavedMealFromDiaryInput saves the foods logged in a meal slot on a date as a saved meal
+type SavedMealFromDiaryInput struct {
+	Name string `json:"name" binding:"required" example:"Usual breakfast"`
+	Date string `json:"date" binding:"required" example:"2026-01-31"`
+	Meal string `json:"meal" binding:"required,oneof=breakfast lunch dinner snack" example:"breakfast"`
+}
+
+// LogSavedMealInput logs every item of a saved meal. Meal defaults to the saved meal's slot
+// and Scale multiplies every amount, e.g. 0.5 for half portions.
+type LogSavedMealInput struct {
+	Date    string     `json:"date" binding:"required" example:"2026-01-31"`
+	Meal    string     `json:"meal,omitempty" binding:"omitempty,oneof=breakfast lunch dinner snack" example:"breakfast"`
+	Scale   float64    `json:"scale,omitempty" binding:"omitempty,gt=0" example:"1"`
+	EatenAt *time.Time `json:"eaten_at,omitempty"`
+}
+
+// DiaryCopyInput copies a meal slot, or a whole day when Meal is empty, to another date.
+// ToMeal moves a copied slot to a different slot; Scale multiplies every amount.
+type DiaryCopyInput struct {
+	FromDate string  `json:"from_date" binding:"required" example:"2026-01-30"`
+	ToDate   string  `json:"to_date" binding:"required" example:"2026-01-31"`
+	Meal     string  `json:"meal,omitempty" binding:"omitempty,oneof=breakfast lunch dinner snack" example:"breakfast"`
+	ToMeal   string  `json:"to_meal,omitempty" binding:"omitempty,oneof=breakfast lunch dinner snack" example:"lunch"`
+	Scale    float64 `json:"scale,omitempty" binding:"omitempty,gt=0" example:"1"`
+}
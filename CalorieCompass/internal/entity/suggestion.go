@@ -0,0 +1,54 @@
+package entity
+
+import "time"
+
+// Suggestion candidate sources, in order of preference
+const (
+	SuggestionSourceFavorite = "favorite"
+	SuggestionSourceHistory  = "history"
+	SuggestionSourceCatalog  = "catalog"
+)
+
+// MacroBudget is an amount of energy and macronutrients, such as what is left of a day's targets
+type MacroBudget struct {
+	Calories float64 `json:"calories" example:"300"`
+	Carbs    float64 `json:"carbs" example:"10"`
+	Protein  float64 `json:"protein" example:"40"`
+	Fat      float64 `json:"fat" example:"8"`
+}
+
+// SuggestionRequest asks for foods that fill the remaining targets of a date, matching
+// Filters as in food search. Allergens and diets come from the user's restriction profile.
+type SuggestionRequest struct {
+	UserID  int64
+	Date    time.Time
+	Limit   int
+	Filters FoodSearchFilters
+}
+
+// FoodSuggestion is a food scaled to the amount that best fits the remaining budget. Fit
+// scores the match from 0 to 100 and Remaining is what is left after eating it. ServingID,
+// Amount and Unit can be logged to the diary as they are. Flags lists diet conflicts with
+// the user's restriction profile that did not exclude the food.
+type FoodSuggestion struct {
+	FoodID    string      `json:"food_id" example:"fs:33691"`
+	FoodName  string      `json:"food_name"`
+	BrandName string      `json:"brand_name,omitempty"`
+	Source    string      `json:"source" example:"favorite"`
+	ServingID string      `json:"serving_id"`
+	Amount    float64     `json:"amount" example:"1.5"`
+	Unit      string      `json:"unit" example:"serving"`
+	Nutrition Serving     `json:"nutrition"`
+	Fit       float64     `json:"fit" example:"87.5"`
+	Remaining MacroBudget `json:"remaining"`
+	Flags     []FoodFlag  `json:"flags,omitempty"`
+}
+
+// SuggestionResponse lists suggestions for a date, best fit first. Carbs in Remaining are
+// net carbs when the day's target counts them.
+type SuggestionResponse struct {
+	Date        string           `json:"date" example:"2026-01-31"`
+	Target      NutritionTarget  `json:"target"`
+	Remaining   MacroBudget      `json:"remaining"`
+	Suggestions []FoodSuggestion `json:"suggestions"`
+}
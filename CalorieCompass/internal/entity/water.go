@@ -0,0 +1,82 @@
+package entity
+
+import "time"
+
+// Sources of a daily water target
+const (
+	WaterTargetCustom  = "custom"
+	WaterTargetWeight  = "weight"
+	WaterTargetDefault = "default"
+)
+
+// WaterContainer is a named drink size. Built-in containers have no ID.
+type WaterContainer struct {
+	ID     int64   `json:"id,omitempty"`
+	Name   string  `json:"name" example:"bottle"`
+	Volume float64 `json:"volume" example:"500"`
+	Custom bool    `json:"custom"`
+}
+
+// WaterContainerInput creates a user-defined container. Unit defaults to ml.
+type WaterContainerInput struct {
+	Name   string  `json:"name" binding:"required,max=50" example:"sports bottle"`
+	Volume float64 `json:"volume" binding:"required,gt=0" example:"750"`
+	Unit   string  `json:"unit,omitempty" example:"ml"`
+}
+
+// WaterEntry is an amount of water drunk, in ml
+type WaterEntry struct {
+	ID        int64     `json:"id"`
+	Date      string    `json:"date" example:"2026-01-31"`
+	Amount    float64   `json:"amount" example:"500"`
+	Container string    `json:"container,omitempty" example:"bottle"`
+	DrunkAt   time.Time `json:"drunk_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WaterEntryInput logs water either as an amount in a volume unit (ml by default) or as a
+// number of containers
+type WaterEntryInput struct {
+	Date      string     `json:"date" binding:"required" example:"2026-01-31"`
+	Amount    float64    `json:"amount,omitempty" binding:"omitempty,gt=0" example:"330"`
+	Unit      string     `json:"unit,omitempty" example:"ml"`
+	Container string     `json:"container,omitempty" binding:"max=50" example:"bottle"`
+	Count     float64    `json:"count,omitempty" binding:"omitempty,gt=0,lte=20" example:"1"`
+	DrunkAt   *time.Time `json:"drunk_at,omitempty"`
+}
+
+// WaterSettings holds the user's fixed daily water target in ml; without it the target is
+// derived from body weight
+type WaterSettings struct {
+	Target    *float64  `json:"target,omitempty" example:"2500"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WaterSettingsInput sets or, without a target, clears the fixed daily water target. Unit
+// defaults to ml.
+type WaterSettingsInput struct {
+	Target *float64 `json:"target,omitempty" binding:"omitempty,gt=0" example:"2500"`
+	Unit   string   `json:"unit,omitempty" example:"ml"`
+}
+
+// HydrationSummary totals a day's water intake in ml: plain water logged, water in drinks
+// logged in the diary and water in high-water foods. The target is the base target plus
+// extra water for the day's exercise.
+type HydrationSummary struct {
+	Water        float64 `json:"water" example:"1500"`
+	Beverages    float64 `json:"beverages" example:"450"`
+	Foods        float64 `json:"foods" example:"220"`
+	Total        float64 `json:"total" example:"2170"`
+	Target       float64 `json:"target" example:"3130"`
+	TargetSource string  `json:"target_source" example:"weight"`
+	Exercise     float64 `json:"exercise" example:"250"`
+	Remaining    float64 `json:"remaining" example:"960"`
+	Progress     float64 `json:"progress" example:"69.3"`
+}
+
+// HydrationDay is a day's water log with its hydration summary
+type HydrationDay struct {
+	Date    string           `json:"date" example:"2026-01-31"`
+	Entries []WaterEntry     `json:"entries"`
+	Summary HydrationSummary `json:"summary"`
+}
@@ -0,0 +1,63 @@
+package entity
+
+import "time"
+
+// WeightEntry is a body weight recorded on a date. Weights are stored in kg.
+type WeightEntry struct {
+	ID        int64     `json:"id"`
+	Date      string    `json:"date" example:"2026-01-31"`
+	Weight    float64   `json:"weight" example:"82.4"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WeightEntryInput records a weight for a date, replacing any weight already recorded that
+// day. Unit defaults to kg.
+type WeightEntryInput struct {
+	Date   string  `json:"date" binding:"required" example:"2026-01-31"`
+	Weight float64 `json:"weight" binding:"required,gt=0" example:"82.4"`
+	Unit   string  `json:"unit,omitempty" binding:"omitempty,oneof=kg lb" example:"kg"`
+	Note   string  `json:"note,omitempty" binding:"max=255"`
+}
+
+// WeightGoal is the user's target weight in kg
+type WeightGoal struct {
+	TargetWeight float64   `json:"target_weight" example:"75"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// WeightGoalInput sets the target weight. Unit defaults to kg.
+type WeightGoalInput struct {
+	TargetWeight float64 `json:"target_weight" binding:"required,gt=0" example:"75"`
+	Unit         string  `json:"unit,omitempty" binding:"omitempty,oneof=kg lb" example:"kg"`
+}
+
+// WeightTrendPoint is one day of the smoothed weight series. Weight is only set on days with
+// a recorded weight.
+type WeightTrendPoint struct {
+	Date   string   `json:"date" example:"2026-01-31"`
+	Weight *float64 `json:"weight,omitempty" example:"82.4"`
+	Trend  float64  `json:"trend" example:"82.1"`
+}
+
+// TDEEEstimate is the energy expenditure implied by intake logged in the diary and the trend
+// weight change over the same window
+type TDEEEstimate struct {
+	Calories      float64 `json:"calories" example:"2450"`
+	AverageIntake float64 `json:"average_intake" example:"2100"`
+	TrendChange   float64 `json:"trend_change" example:"-1.27"`
+	WindowDays    int     `json:"window_days" example:"28"`
+	LoggedDays    int     `json:"logged_days" example:"25"`
+}
+
+// WeightTrend summarises weight progress: the smoothed series, the current rate of change,
+// the projected date the goal is reached and the estimated TDEE
+type WeightTrend struct {
+	Points        []WeightTrendPoint `json:"points"`
+	CurrentTrend  float64            `json:"current_trend" example:"82.1"`
+	WeeklyRate    float64            `json:"weekly_rate" example:"-0.45"`
+	Goal          *WeightGoal        `json:"goal,omitempty"`
+	ProjectedDate *string            `json:"projected_date,omitempty" example:"2026-05-12"`
+	TDEE          *TDEEEstimate      `json:"tdee,omitempty"`
+}
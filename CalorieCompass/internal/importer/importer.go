@@ -0,0 +1,250 @@
+package importer
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"CalorieCompass/internal/entity"
+)
+
+const defaultBatchSize = 1000
+
+// Reader streams the records of one dataset export
+type Reader interface {
+	// Run identifies the export (source, file name, size and modification time) so that
+	// interrupted imports can be resumed and unchanged files skipped
+	Run() (entity.ImportRun, error)
+	// Each calls fn for every record in file order, including records that turn out to be
+	// unusable, so record positions stay stable between runs
+	Each(ctx context.Context, fn func(food entity.CatalogFood) error) error
+}
+
+// Store persists catalog foods and import progress
+type Store interface {
+	FindRun(ctx context.Context, run entity.ImportRun) (*entity.ImportRun, error)
+	StartRun(ctx context.Context, run entity.ImportRun) (int64, error)
+	ImportBatch(ctx context.Context, runID, checkpoint int64, foods []entity.CatalogFood) (int64, error)
+	FinishRun(ctx context.Context, runID int64) error
+}
+
+// Stats summarises an import
+type Stats struct {
+	Read    int64
+	Skipped int64
+	Invalid int64
+	Changed int64
+}
+
+// Importer loads dataset exports into the catalog in batches
+type Importer struct {
+	store     Store
+	batchSize int
+	force     bool
+}
+
+// New creates an importer. When force is set, files that were already imported
+// completely are processed again.
+func New(store Store, batchSize int, force bool) *Importer {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	return &Importer{
+		store:     store,
+		batchSize: batchSize,
+		force:     force,
+	}
+}
+
+// Import streams reader into the catalog. A run left unfinished for the same file is resumed
+// from its checkpoint; re-importing a newer export only rewrites foods whose content changed.
+func (im *Importer) Import(ctx context.Context, reader Reader) (Stats, error) {
+	var stats Stats
+
+	run, err := reader.Run()
+	if err != nil {
+		return stats, err
+	}
+
+	existing, err := im.store.FindRun(ctx, run)
+	if err != nil {
+		return stats, err
+	}
+
+	var runID, skip int64
+	switch {
+	case existing != nil && existing.Status == entity.ImportCompleted && !im.force:
+		log.Printf("%s was already imported on %s, skipping (use -force to re-import)", run.FileName, existing.StartedAt.Format("2006-01-02"))
+		return stats, nil
+	case existing != nil && existing.Status == entity.ImportRunning:
+		runID, skip = existing.ID, existing.Checkpoint
+		log.Printf("resuming import of %s after record %d", run.FileName, skip)
+	default:
+		if runID, err = im.store.StartRun(ctx, run); err != nil {
+			return stats, err
+		}
+	}
+
+	var position int64
+	batch := make([]entity.CatalogFood, 0, im.batchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		changed, err := im.store.ImportBatch(ctx, runID, position, batch)
+		if err != nil {
+			return err
+		}
+		stats.Changed += changed
+		batch = batch[:0]
+		log.Printf("imported %d records (%d changed)", position, stats.Changed)
+		return nil
+	}
+
+	err = reader.Each(ctx, func(food entity.CatalogFood) error {
+		position++
+		if position <= skip {
+			stats.Skipped++
+			return nil
+		}
+
+		stats.Read++
+		if !prepare(&food) {
+			stats.Invalid++
+			return nil
+		}
+
+		batch = append(batch, food)
+		if len(batch) >= im.batchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return stats, fmt.Errorf("import %s error: %w", run.FileName, err)
+	}
+
+	if err := flush(); err != nil {
+		return stats, fmt.Errorf("import %s error: %w", run.FileName, err)
+	}
+
+	if err := im.store.FinishRun(ctx, runID); err != nil {
+		return stats, err
+	}
+
+	return stats, nil
+}
+
+// prepare validates a record, trims it to the column sizes and computes its content hash.
+// Values are per 100 g/ml, so anything physically impossible is rejected as bad data.
+func prepare(food *entity.CatalogFood) bool {
+	food.Name = truncate(strings.Join(strings.Fields(food.Name), " "), 512)
+	food.BrandName = truncate(strings.TrimSpace(food.BrandName), 255)
+	food.Category = truncate(strings.TrimSpace(food.Category), 255)
+	food.ServingDescription = truncate(strings.TrimSpace(food.ServingDescription), 255)
+	food.Ingredients = truncate(strings.Join(strings.Fields(food.Ingredients), " "), 4000)
+	food.SourceID = strings.TrimSpace(food.SourceID)
+
+	if food.Name == "" || food.SourceID == "" || len(food.SourceID) > 64 {
+		return false
+	}
+
+	grams := []*float64{&food.Carbs, &food.Protein, &food.Fat, &food.SaturatedFat, &food.Fiber, &food.Sugar}
+	for _, value := range grams {
+		if !inRange(*value, 100) {
+			return false
+		}
+		*value = round3(*value)
+	}
+	if !inRange(food.Calories, 900) || !inRange(food.Sodium, 40000) || !inRange(food.Cholesterol, 5000) {
+		return false
+	}
+	if food.Calories == 0 && food.Carbs == 0 && food.Protein == 0 && food.Fat == 0 {
+		return false
+	}
+	if !inRange(food.ServingSize, 100000) {
+		food.ServingSize = 0
+	}
+
+	food.Calories = round3(food.Calories)
+	food.Sodium = round3(food.Sodium)
+	food.Cholesterol = round3(food.Cholesterol)
+	food.ServingSize = round3(food.ServingSize)
+
+	// Micronutrient data is sparse and noisy, so values above what even concentrated
+	// supplements hold per 100 g (mg, and µg for vitamins A and D) are dropped rather than
+	// rejecting the food. The limits also keep them well inside the NUMERIC(10,3) columns.
+	micros := []struct {
+		value *float64
+		max   float64
+	}{
+		{&food.Calcium, 40000}, {&food.Iron, 20000}, {&food.Potassium, 55000},
+		{&food.VitaminA, 100000}, {&food.VitaminC, 100000}, {&food.VitaminD, 50000},
+	}
+	for _, micro := range micros {
+		if !inRange(*micro.value, micro.max) {
+			*micro.value = 0
+		}
+		*micro.value = round3(*micro.value)
+	}
+
+	hash := sha1.New()
+	fmt.Fprintf(hash, "%s|%s|%s|%t|%s|%s|%s|%s", food.Name, food.BrandName, food.Category, food.IsLiquid,
+		formatFloat(food.ServingSize), food.ServingDescription, food.Ingredients,
+		strings.Join([]string{
+			formatFloat(food.Calories), formatFloat(food.Carbs), formatFloat(food.Protein), formatFloat(food.Fat),
+			formatFloat(food.SaturatedFat), formatFloat(food.Fiber), formatFloat(food.Cholesterol),
+			formatFloat(food.Sodium), formatFloat(food.Sugar), formatFloat(food.Calcium), formatFloat(food.Iron),
+			formatFloat(food.Potassium), formatFloat(food.VitaminA), formatFloat(food.VitaminC),
+			formatFloat(food.VitaminD),
+		}, ","))
+	food.ContentHash = hex.EncodeToString(hash.Sum(nil))
+
+	return true
+}
+
+// applyNutrients copies collected values onto a catalog food
+func applyNutrients(food *entity.CatalogFood, nutrients *nutrientSet) {
+	food.Calories = nutrients.values[fieldCalories]
+	food.Carbs = nutrients.values[fieldCarbs]
+	food.Protein = nutrients.values[fieldProtein]
+	food.Fat = nutrients.values[fieldFat]
+	food.SaturatedFat = nutrients.values[fieldSaturatedFat]
+	food.Fiber = nutrients.values[fieldFiber]
+	food.Cholesterol = nutrients.values[fieldCholesterol]
+	food.Sodium = nutrients.values[fieldSodium]
+	food.Sugar = nutrients.values[fieldSugar]
+	food.Calcium = nutrients.values[fieldCalcium]
+	food.Iron = nutrients.values[fieldIron]
+	food.Potassium = nutrients.values[fieldPotassium]
+	food.VitaminA = nutrients.values[fieldVitaminA]
+	food.VitaminC = nutrients.values[fieldVitaminC]
+	food.VitaminD = nutrients.values[fieldVitaminD]
+}
+
+func inRange(value, max float64) bool {
+	return !math.IsNaN(value) && value >= 0 && value <= max
+}
+
+func round3(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// truncate shortens s to at most n runes
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
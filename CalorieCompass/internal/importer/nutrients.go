@@ -0,0 +1,169 @@
+package importer
+
+import (
+	"math"
+	"strings"
+)
+
+// Catalog nutrient fields and the unit each is stored in
+const (
+	fieldCalories     = "calories"
+	fieldCarbs        = "carbs"
+	fieldProtein      = "protein"
+	fieldFat          = "fat"
+	fieldSaturatedFat = "saturated_fat"
+	fieldFiber        = "fiber"
+	fieldCholesterol  = "cholesterol"
+	fieldSodium       = "sodium"
+	fieldSugar        = "sugar"
+	fieldCalcium      = "calcium"
+	fieldIron         = "iron"
+	fieldPotassium    = "potassium"
+	fieldVitaminA     = "vitamin_a"
+	fieldVitaminC     = "vitamin_c"
+	fieldVitaminD     = "vitamin_d"
+)
+
+var fieldUnits = map[string]string{
+	fieldCalories:     "kcal",
+	fieldCarbs:        "g",
+	fieldProtein:      "g",
+	fieldFat:          "g",
+	fieldSaturatedFat: "g",
+	fieldFiber:        "g",
+	fieldCholesterol:  "mg",
+	fieldSodium:       "mg",
+	fieldSugar:        "g",
+	fieldCalcium:      "mg",
+	fieldIron:         "mg",
+	fieldPotassium:    "mg",
+	fieldVitaminA:     "ug",
+	fieldVitaminC:     "mg",
+	fieldVitaminD:     "ug",
+}
+
+// Mass units relative to grams and energy units relative to kcal
+var (
+	massUnits = map[string]float64{
+		"g":   1,
+		"mg":  0.001,
+		"ug":  0.000001,
+		"µg":  0.000001,
+		"mcg": 0.000001,
+		"kg":  1000,
+	}
+	energyUnits = map[string]float64{
+		"kcal": 1,
+		"kj":   1 / 4.184,
+	}
+	// International units in µg, for the fields where the factor does not depend on the source
+	internationalUnits = map[string]float64{
+		fieldVitaminD: 0.025,
+	}
+)
+
+// normalizeUnit converts amount from a dataset unit (e.g. "MG", "kJ", "UG") into the
+// unit the catalog stores for field. It reports false for unknown or mismatched units.
+func normalizeUnit(field string, amount float64, unit string) (float64, bool) {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return 0, false
+	}
+
+	unit = strings.ToLower(strings.TrimSpace(unit))
+	target := fieldUnits[field]
+
+	if from, ok := energyUnits[unit]; ok {
+		to, ok := energyUnits[target]
+		if !ok {
+			return 0, false
+		}
+		return amount * from / to, true
+	}
+
+	if unit == "iu" {
+		perUnit, ok := internationalUnits[field]
+		if !ok {
+			return 0, false
+		}
+		unit, amount = "ug", amount*perUnit
+	}
+
+	if from, ok := massUnits[unit]; ok {
+		to, ok := massUnits[target]
+		if !ok {
+			return 0, false
+		}
+		return amount * from / to, true
+	}
+
+	return 0, false
+}
+
+// usdaNutrient maps a FoodData Central nutrient number to a catalog field
+type usdaNutrient struct {
+	field string
+	// rank orders alternative sources of the same field; lower wins
+	rank int
+}
+
+var usdaNutrients = map[string]usdaNutrient{
+	"208":   {field: fieldCalories, rank: 0}, // Energy (kcal)
+	"958":   {field: fieldCalories, rank: 1}, // Energy, Atwater specific factors
+	"957":   {field: fieldCalories, rank: 2}, // Energy, Atwater general factors
+	"268":   {field: fieldCalories, rank: 3}, // Energy (kJ)
+	"203":   {field: fieldProtein},
+	"204":   {field: fieldFat},
+	"205":   {field: fieldCarbs},
+	"291":   {field: fieldFiber},
+	"269":   {field: fieldSugar, rank: 0}, // Sugars, total including NLEA
+	"269.3": {field: fieldSugar, rank: 1}, // Sugars, Total
+	"606":   {field: fieldSaturatedFat},
+	"601":   {field: fieldCholesterol},
+	"307":   {field: fieldSodium},
+	"301":   {field: fieldCalcium},
+	"303":   {field: fieldIron},
+	"306":   {field: fieldPotassium},
+	"320":   {field: fieldVitaminA},          // Vitamin A, RAE
+	"401":   {field: fieldVitaminC},          // Vitamin C, total ascorbic acid
+	"328":   {field: fieldVitaminD, rank: 0}, // Vitamin D (D2 + D3)
+	"324":   {field: fieldVitaminD, rank: 1}, // Vitamin D (D2 + D3), International Units
+}
+
+// nutrientSet collects normalised nutrient values, keeping the best-ranked source per field
+type nutrientSet struct {
+	values map[string]float64
+	ranks  map[string]int
+}
+
+func newNutrientSet() *nutrientSet {
+	return &nutrientSet{
+		values: make(map[string]float64),
+		ranks:  make(map[string]int),
+	}
+}
+
+// addUSDA records a FoodData Central nutrient amount if it maps to a catalog field
+func (n *nutrientSet) addUSDA(number string, amount float64, unit string) {
+	nutrient, ok := usdaNutrients[strings.TrimSpace(number)]
+	if !ok {
+		return
+	}
+	n.add(nutrient.field, nutrient.rank, amount, unit)
+}
+
+func (n *nutrientSet) add(field string, rank int, amount float64, unit string) {
+	value, ok := normalizeUnit(field, amount, unit)
+	if !ok {
+		return
+	}
+	if current, exists := n.ranks[field]; exists && current <= rank {
+		return
+	}
+	n.values[field] = value
+	n.ranks[field] = rank
+}
+
+func (n *nutrientSet) has(field string) bool {
+	_, ok := n.values[field]
+	return ok
+}
@@ -0,0 +1,212 @@
+package importer
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"CalorieCompass/internal/entity"
+)
+
+// Open Food Facts product lines can be very large (ingredients, images, translations)
+const maxLineSize = 16 * 1024 * 1024
+
+// OpenFoodFactsReader streams an Open Food Facts JSONL export, optionally gzip-compressed
+type OpenFoodFactsReader struct {
+	path string
+}
+
+// NewOpenFoodFactsReader creates a reader for an Open Food Facts JSONL (.jsonl or .jsonl.gz) file
+func NewOpenFoodFactsReader(path string) *OpenFoodFactsReader {
+	return &OpenFoodFactsReader{path: path}
+}
+
+// Run identifies the export by its file
+func (r *OpenFoodFactsReader) Run() (entity.ImportRun, error) {
+	return fileRun(entity.CatalogSourceOpenFoodFacts, r.path)
+}
+
+type offProduct struct {
+	Code            string                 `json:"code"`
+	ProductName     string                 `json:"product_name"`
+	Brands          string                 `json:"brands"`
+	Categories      string                 `json:"categories"`
+	Quantity        string                 `json:"quantity"`
+	ServingSize     string                 `json:"serving_size"`
+	ServingQuantity interface{}            `json:"serving_quantity"`
+	IngredientsText string                 `json:"ingredients_text"`
+	Nutriments      map[string]interface{} `json:"nutriments"`
+}
+
+// Each decodes one product per line. Malformed lines are logged and passed on as empty
+// records so that line numbers remain valid checkpoints.
+func (r *OpenFoodFactsReader) Each(ctx context.Context, fn func(food entity.CatalogFood) error) error {
+	file, err := openDataset(r.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 1024*1024), maxLineSize)
+
+	line := 0
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line++
+
+		var product offProduct
+		if err := json.Unmarshal(scanner.Bytes(), &product); err != nil {
+			log.Printf("%s line %d: %s", filepath.Base(r.path), line, err)
+			if err := fn(entity.CatalogFood{Source: entity.CatalogSourceOpenFoodFacts}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := fn(product.toCatalogFood()); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read %s error: %w", r.path, err)
+	}
+	return nil
+}
+
+// toCatalogFood maps the per-100 g nutriments, converting kJ to kcal and the grams Open Food
+// Facts stores every nutrient in to the catalog's units
+func (p offProduct) toCatalogFood() entity.CatalogFood {
+	food := entity.CatalogFood{
+		Source:      entity.CatalogSourceOpenFoodFacts,
+		SourceID:    p.Code,
+		Name:        p.ProductName,
+		IsLiquid:    isLiquidQuantity(p.Quantity),
+		Ingredients: p.IngredientsText,
+	}
+
+	food.BrandName, _, _ = strings.Cut(p.Brands, ",")
+	food.Category, _, _ = strings.Cut(p.Categories, ",")
+
+	if size, ok := flexFloat(p.ServingQuantity); ok {
+		food.ServingSize = size
+		food.ServingDescription = p.ServingSize
+	}
+
+	set := newNutrientSet()
+	nutriment := func(key string) (float64, bool) {
+		return flexFloat(p.Nutriments[key])
+	}
+
+	if kcal, ok := nutriment("energy-kcal_100g"); ok {
+		set.add(fieldCalories, 0, kcal, "kcal")
+	} else if kj, ok := nutriment("energy_100g"); ok {
+		set.add(fieldCalories, 1, kj, "kJ")
+	}
+
+	grams := map[string]string{
+		"carbohydrates_100g": fieldCarbs,
+		"proteins_100g":      fieldProtein,
+		"fat_100g":           fieldFat,
+		"saturated-fat_100g": fieldSaturatedFat,
+		"fiber_100g":         fieldFiber,
+		"sugars_100g":        fieldSugar,
+		"sodium_100g":        fieldSodium,
+		"cholesterol_100g":   fieldCholesterol,
+		"calcium_100g":       fieldCalcium,
+		"iron_100g":          fieldIron,
+		"potassium_100g":     fieldPotassium,
+		"vitamin-a_100g":     fieldVitaminA,
+		"vitamin-c_100g":     fieldVitaminC,
+		"vitamin-d_100g":     fieldVitaminD,
+	}
+	for key, field := range grams {
+		if value, ok := nutriment(key); ok {
+			set.add(field, 0, value, "g")
+		}
+	}
+
+	// Salt is 40% sodium by mass
+	if !set.has(fieldSodium) {
+		if salt, ok := nutriment("salt_100g"); ok {
+			set.add(fieldSodium, 1, salt/2.5, "g")
+		}
+	}
+
+	applyNutrients(&food, set)
+	return food
+}
+
+// isLiquidQuantity reports whether a package quantity such as "1.5 l" or "330 ml" is a volume
+func isLiquidQuantity(quantity string) bool {
+	fields := strings.Fields(strings.ToLower(quantity))
+	if len(fields) == 0 {
+		return false
+	}
+	unit := strings.TrimLeft(fields[len(fields)-1], "0123456789.,")
+	switch unit {
+	case "ml", "cl", "dl", "l", "fl oz":
+		return true
+	}
+	return false
+}
+
+// flexFloat reads a JSON number that Open Food Facts sometimes encodes as a string
+func flexFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// openDataset opens a dataset file, transparently decompressing .gz files
+func openDataset(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open %s error: %w", path, err)
+	}
+	if !strings.HasSuffix(path, ".gz") {
+		return file, nil
+	}
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("open gzip %s error: %w", path, err)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{gz, file}, nil
+}
+
+// fileRun describes an import of the file at path
+func fileRun(source, path string) (entity.ImportRun, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return entity.ImportRun{}, fmt.Errorf("stat %s error: %w", path, err)
+	}
+
+	return entity.ImportRun{
+		Source:         source,
+		FileName:       filepath.Base(path),
+		FileSize:       info.Size(),
+		FileModifiedAt: info.ModTime().UTC().Truncate(time.Microsecond),
+	}, nil
+}
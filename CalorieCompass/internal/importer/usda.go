@@ -0,0 +1,326 @@
+package importer
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"CalorieCompass/internal/entity"
+)
+
+// USDACSVReader reads a FoodData Central CSV download directory (food.csv, nutrient.csv,
+// food_nutrient.csv and, when present, branded_food.csv and food_category.csv). The small
+// lookup files and the relevant nutrient amounts are indexed first; food.csv is then streamed.
+type USDACSVReader struct {
+	dir string
+}
+
+// NewUSDACSVReader creates a reader for an extracted FoodData Central CSV directory
+func NewUSDACSVReader(dir string) *USDACSVReader {
+	return &USDACSVReader{dir: dir}
+}
+
+// Run identifies the export by its food.csv file
+func (r *USDACSVReader) Run() (entity.ImportRun, error) {
+	return fileRun(entity.CatalogSourceUSDA, filepath.Join(r.dir, "food.csv"))
+}
+
+type usdaBranding struct {
+	brand       string
+	category    string
+	servingSize float64
+	servingUnit string
+	household   string
+	ingredients string
+}
+
+// Each streams food.csv joined with its nutrients, branding and category
+func (r *USDACSVReader) Each(ctx context.Context, fn func(food entity.CatalogFood) error) error {
+	nutrientNumbers := make(map[string]string)
+	nutrientUnits := make(map[string]string)
+	err := r.scan("nutrient.csv", true, func(row csvRow) error {
+		number := row.get("nutrient_nbr")
+		if _, ok := usdaNutrients[number]; ok {
+			nutrientNumbers[row.get("id")] = number
+			nutrientUnits[row.get("id")] = row.get("unit_name")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	categories := make(map[string]string)
+	err = r.scan("food_category.csv", false, func(row csvRow) error {
+		categories[row.get("id")] = row.get("description")
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	branding := make(map[string]usdaBranding)
+	err = r.scan("branded_food.csv", false, func(row csvRow) error {
+		size, _ := strconv.ParseFloat(row.get("serving_size"), 64)
+		brand := row.get("brand_name")
+		if brand == "" {
+			brand = row.get("brand_owner")
+		}
+		branding[row.get("fdc_id")] = usdaBranding{
+			brand:       brand,
+			category:    row.get("branded_food_category"),
+			servingSize: size,
+			servingUnit: row.get("serving_size_unit"),
+			household:   row.get("household_serving_fulltext"),
+			ingredients: row.get("ingredients"),
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	nutrients := make(map[string]*nutrientSet)
+	err = r.scan("food_nutrient.csv", true, func(row csvRow) error {
+		nutrientID := row.get("nutrient_id")
+		number, ok := nutrientNumbers[nutrientID]
+		if !ok {
+			return nil
+		}
+		amount, err := strconv.ParseFloat(row.get("amount"), 64)
+		if err != nil {
+			return nil
+		}
+		fdcID := row.get("fdc_id")
+		set, ok := nutrients[fdcID]
+		if !ok {
+			set = newNutrientSet()
+			nutrients[fdcID] = set
+		}
+		set.addUSDA(number, amount, nutrientUnits[nutrientID])
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.scan("food.csv", true, func(row csvRow) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		fdcID := row.get("fdc_id")
+		food := entity.CatalogFood{
+			Source:   entity.CatalogSourceUSDA,
+			SourceID: fdcID,
+			Name:     row.get("description"),
+			Category: categories[row.get("food_category_id")],
+		}
+
+		if brand, ok := branding[fdcID]; ok {
+			food.BrandName = brand.brand
+			if brand.category != "" {
+				food.Category = brand.category
+			}
+			applyUSDAServing(&food, brand.servingSize, brand.servingUnit, brand.household)
+			food.Ingredients = brand.ingredients
+		}
+
+		if set, ok := nutrients[fdcID]; ok {
+			applyNutrients(&food, set)
+			// Nutrient amounts are no longer needed once the food is emitted
+			delete(nutrients, fdcID)
+		}
+
+		return fn(food)
+	})
+}
+
+// csvRow gives access to a CSV record by header name
+type csvRow struct {
+	columns map[string]int
+	record  []string
+}
+
+func (r csvRow) get(name string) string {
+	i, ok := r.columns[name]
+	if !ok || i >= len(r.record) {
+		return ""
+	}
+	return strings.TrimSpace(r.record[i])
+}
+
+// scan streams a CSV file from the download directory. Optional files that do not exist are ignored.
+func (r *USDACSVReader) scan(name string, required bool, fn func(row csvRow) error) error {
+	file, err := os.Open(filepath.Join(r.dir, name))
+	if err != nil {
+		if !required && errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("open %s error: %w", name, err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.ReuseRecord = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("read %s header error: %w", name, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.TrimPrefix(strings.TrimSpace(column), "\ufeff")] = i
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read %s error: %w", name, err)
+		}
+		if err := fn(csvRow{columns: columns, record: record}); err != nil {
+			return err
+		}
+	}
+}
+
+// USDAJSONReader streams a FoodData Central JSON download such as
+// {"FoundationFoods": [...]} or {"BrandedFoods": [...]} one food at a time
+type USDAJSONReader struct {
+	path string
+}
+
+// NewUSDAJSONReader creates a reader for a FoodData Central JSON file
+func NewUSDAJSONReader(path string) *USDAJSONReader {
+	return &USDAJSONReader{path: path}
+}
+
+// Run identifies the export by its file
+func (r *USDAJSONReader) Run() (entity.ImportRun, error) {
+	return fileRun(entity.CatalogSourceUSDA, r.path)
+}
+
+type usdaJSONFood struct {
+	FdcID        int64  `json:"fdcId"`
+	Description  string `json:"description"`
+	BrandOwner   string `json:"brandOwner"`
+	BrandName    string `json:"brandName"`
+	FoodCategory struct {
+		Description string `json:"description"`
+	} `json:"foodCategory"`
+	BrandedFoodCategory      string  `json:"brandedFoodCategory"`
+	ServingSize              float64 `json:"servingSize"`
+	ServingSizeUnit          string  `json:"servingSizeUnit"`
+	HouseholdServingFullText string  `json:"householdServingFullText"`
+	Ingredients              string  `json:"ingredients"`
+	FoodNutrients            []struct {
+		Amount   float64 `json:"amount"`
+		Nutrient struct {
+			Number   string `json:"number"`
+			UnitName string `json:"unitName"`
+		} `json:"nutrient"`
+	} `json:"foodNutrients"`
+}
+
+// Each decodes the top-level arrays element by element without loading the whole file
+func (r *USDAJSONReader) Each(ctx context.Context, fn func(food entity.CatalogFood) error) error {
+	file, err := openDataset(r.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	if err := expectDelim(decoder, '{'); err != nil {
+		return err
+	}
+
+	for decoder.More() {
+		// Key of the food list, e.g. "SRLegacyFoods"
+		if _, err := decoder.Token(); err != nil {
+			return fmt.Errorf("read json key error: %w", err)
+		}
+		if err := expectDelim(decoder, '['); err != nil {
+			return err
+		}
+
+		for decoder.More() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			var raw usdaJSONFood
+			if err := decoder.Decode(&raw); err != nil {
+				return fmt.Errorf("decode food error: %w", err)
+			}
+
+			food := entity.CatalogFood{
+				Source:      entity.CatalogSourceUSDA,
+				SourceID:    strconv.FormatInt(raw.FdcID, 10),
+				Name:        raw.Description,
+				Category:    raw.FoodCategory.Description,
+				Ingredients: raw.Ingredients,
+			}
+			food.BrandName = raw.BrandName
+			if food.BrandName == "" {
+				food.BrandName = raw.BrandOwner
+			}
+			if raw.BrandedFoodCategory != "" {
+				food.Category = raw.BrandedFoodCategory
+			}
+			applyUSDAServing(&food, raw.ServingSize, raw.ServingSizeUnit, raw.HouseholdServingFullText)
+
+			set := newNutrientSet()
+			for _, n := range raw.FoodNutrients {
+				set.addUSDA(n.Nutrient.Number, n.Amount, n.Nutrient.UnitName)
+			}
+			applyNutrients(&food, set)
+
+			if err := fn(food); err != nil {
+				return err
+			}
+		}
+
+		if err := expectDelim(decoder, ']'); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyUSDAServing records a branded food's labelled serving; "ml" servings mark the food as liquid
+func applyUSDAServing(food *entity.CatalogFood, size float64, unit, household string) {
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "g", "grm":
+		food.ServingSize = size
+	case "ml", "mlt":
+		food.ServingSize = size
+		food.IsLiquid = true
+	default:
+		return
+	}
+	food.ServingDescription = household
+}
+
+func expectDelim(decoder *json.Decoder, want json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return fmt.Errorf("read json error: %w", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("unexpected json token %v, want %s", token, want)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package activity
+
+// RestingMET is the energy cost of sitting quietly, 1 kcal per kg per hour
+const RestingMET = 1.0
+
+// MaxMET is the highest MET value accepted for any activity; the Compendium's most intense
+// activities stay below it
+const MaxMET = 25.0
+
+// Calories returns the energy in kcal spent on an activity of the given MET value. One MET
+// is taken as 1 kcal per kg of body weight per hour, as in the Compendium of Physical
+// Activities.
+func Calories(met, weightKg, minutes float64) float64 {
+	return met * weightKg * minutes / 60
+}
+
+// SpeedMET adjusts the MET value measured at a reference speed to another speed. The cost
+// above rest grows about linearly with speed for walking, running and cycling (ACSM
+// metabolic equations), so only the part above the resting MET is scaled. The result is
+// capped at MaxMET; use MaxSpeed to reject speeds beyond it.
+func SpeedMET(met, referenceSpeed, speed float64) float64 {
+	if referenceSpeed <= 0 || speed <= 0 || met <= RestingMET {
+		return met
+	}
+	return min(RestingMET+(met-RestingMET)*speed/referenceSpeed, MaxMET)
+}
+
+// MaxSpeed returns the speed at which SpeedMET reaches MaxMET, or 0 when the MET value does
+// not depend on speed
+func MaxSpeed(met, referenceSpeed float64) float64 {
+	if referenceSpeed <= 0 || met <= RestingMET {
+		return 0
+	}
+	return referenceSpeed * (MaxMET - RestingMET) / (met - RestingMET)
+}
+
+// Duration returns the minutes needed to cover a distance at a speed, or 0 without a speed
+func Duration(distanceKm, speedKmh float64) float64 {
+	if speedKmh <= 0 {
+		return 0
+	}
+	return distanceKm / speedKmh * 60
+}
@@ -0,0 +1,135 @@
+package bodycomp
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// ErrUnknownLengthUnit is returned when a length unit name is not recognised
+var ErrUnknownLengthUnit = errors.New("unknown length unit")
+
+// lengthUnits maps length unit names to centimetres
+var lengthUnits = map[string]float64{
+	"cm": 1, "centimeter": 1, "centimeters": 1, "centimetre": 1, "centimetres": 1,
+	"mm": 0.1, "m": 100,
+	"in": 2.54, "inch": 2.54, "inches": 2.54, `"`: 2.54,
+	"ft": 30.48, "foot": 30.48, "feet": 30.48,
+}
+
+// ToCentimeters converts a length to centimetres
+func ToCentimeters(value float64, unit string) (float64, error) {
+	factor, ok := lengthUnits[strings.ToLower(strings.TrimSpace(unit))]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownLengthUnit, unit)
+	}
+	return value * factor, nil
+}
+
+// NavyBodyFat estimates body fat percentage with the U.S. Navy circumference method
+// (Hodgdon and Beckett). Men need waist and neck, women also hips; all in cm. It reports
+// false when a measurement is missing or the result is out of range.
+func NavyBodyFat(male bool, heightCm, waistCm, neckCm, hipsCm float64) (float64, bool) {
+	if heightCm <= 0 || waistCm <= 0 || neckCm <= 0 {
+		return 0, false
+	}
+
+	var density float64
+	if male {
+		if waistCm <= neckCm {
+			return 0, false
+		}
+		density = 1.0324 - 0.19077*math.Log10(waistCm-neckCm) + 0.15456*math.Log10(heightCm)
+	} else {
+		if hipsCm <= 0 || waistCm+hipsCm <= neckCm {
+			return 0, false
+		}
+		density = 1.29579 - 0.35004*math.Log10(waistCm+hipsCm-neckCm) + 0.22100*math.Log10(heightCm)
+	}
+
+	bodyFat := 495/density - 450
+	if bodyFat <= 0 || bodyFat >= 75 {
+		return 0, false
+	}
+	return bodyFat, true
+}
+
+// BMI returns the body mass index, kg/m²
+func BMI(weightKg, heightCm float64) float64 {
+	meters := heightCm / 100
+	return weightKg / (meters * meters)
+}
+
+// BMICategory classifies a BMI using the WHO adult categories
+func BMICategory(bmi float64) string {
+	switch {
+	case bmi < 18.5:
+		return "underweight"
+	case bmi < 25:
+		return "normal"
+	case bmi < 30:
+		return "overweight"
+	default:
+		return "obese"
+	}
+}
+
+// WaistToHeight returns the waist-to-height ratio; above 0.5 indicates raised health risk
+func WaistToHeight(waistCm, heightCm float64) float64 {
+	return waistCm / heightCm
+}
+
+// LeanMass returns the fat-free mass for a weight and body fat percentage
+func LeanMass(weightKg, bodyFat float64) float64 {
+	return weightKg * (1 - bodyFat/100)
+}
+
+// FFMI returns the fat-free mass index and its value normalised to a height of 1.8 m
+// (Kouri et al.), which removes the bias in favour of tall people
+func FFMI(leanMassKg, heightCm float64) (float64, float64) {
+	meters := heightCm / 100
+	ffmi := leanMassKg / (meters * meters)
+	return ffmi, ffmi + 6.1*(1.8-meters)
+}
+
+// Sample is one value of a time series
+type Sample struct {
+	Date  time.Time
+	Value float64
+	Count int
+}
+
+// Downsample reduces samples sorted by date to at most max points by averaging consecutive
+// samples in equal-sized buckets. Each bucket is dated by its last sample, so the latest
+// value stays at the end of the series.
+func Downsample(samples []Sample, max int) []Sample {
+	if max <= 0 || len(samples) <= max {
+		return samples
+	}
+
+	result := make([]Sample, 0, max)
+	size := float64(len(samples)) / float64(max)
+	for b := 0; b < max; b++ {
+		start := int(math.Round(float64(b) * size))
+		end := int(math.Round(float64(b+1) * size))
+		if end <= start {
+			continue
+		}
+
+		bucket := Sample{Date: samples[end-1].Date}
+		total := 0.0
+		for _, sample := range samples[start:end] {
+			count := sample.Count
+			if count == 0 {
+				count = 1
+			}
+			total += sample.Value * float64(count)
+			bucket.Count += count
+		}
+		bucket.Value = total / float64(bucket.Count)
+		result = append(result, bucket)
+	}
+	return result
+}
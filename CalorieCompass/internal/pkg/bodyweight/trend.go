@@ -0,0 +1,107 @@
+package bodyweight
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// Smoothing is the share of each day's deviation from the trend that moves the trend,
+	// as in The Hacker's Diet. Lower values ignore more day-to-day water weight.
+	Smoothing = 0.1
+	// KcalPerKg is the approximate energy stored in a kilogram of body weight change
+	KcalPerKg = 7700.0
+)
+
+const day = 24 * time.Hour
+
+// Reading is a weight in kg measured on a date (midnight UTC)
+type Reading struct {
+	Date   time.Time
+	Weight float64
+}
+
+// Point is one day of a trend series. Weight is interpolated on days without a reading.
+type Point struct {
+	Date     time.Time
+	Weight   float64
+	Measured bool
+	Trend    float64
+}
+
+// Trend computes a daily exponentially smoothed moving average of readings sorted by date.
+// Gaps between readings are filled by linear interpolation, so a missed weigh-in neither
+// stalls nor jumps the trend. The series starts at the first reading's weight.
+func Trend(readings []Reading) []Point {
+	if len(readings) == 0 {
+		return nil
+	}
+
+	points := []Point{{Date: readings[0].Date, Weight: readings[0].Weight, Measured: true, Trend: readings[0].Weight}}
+	for i := 1; i < len(readings); i++ {
+		previous, current := readings[i-1], readings[i]
+		gap := int(math.Round(current.Date.Sub(previous.Date).Hours() / 24))
+		for d := 1; d <= gap; d++ {
+			weight := previous.Weight + (current.Weight-previous.Weight)*float64(d)/float64(gap)
+			trend := points[len(points)-1].Trend
+			points = append(points, Point{
+				Date:     previous.Date.Add(time.Duration(d) * day),
+				Weight:   weight,
+				Measured: d == gap,
+				Trend:    trend + Smoothing*(weight-trend),
+			})
+		}
+	}
+	return points
+}
+
+// WeeklyRate returns the least-squares slope of the trend over the last days points in kg
+// per week. It returns 0 when there are fewer than two points.
+func WeeklyRate(points []Point, days int) float64 {
+	if len(points) > days {
+		points = points[len(points)-days:]
+	}
+	n := float64(len(points))
+	if n < 2 {
+		return 0
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for i, point := range points {
+		x := float64(i)
+		sumX += x
+		sumY += point.Trend
+		sumXY += x * point.Trend
+		sumXX += x * x
+	}
+	slope := (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+	return slope * 7
+}
+
+// ProjectGoal returns the date the trend reaches target at the given weekly rate. It reports
+// false when the trend is not moving towards the target.
+func ProjectGoal(from time.Time, current, target, weeklyRate float64) (time.Time, bool) {
+	remaining := target - current
+	if remaining == 0 {
+		return from, true
+	}
+	if weeklyRate == 0 || math.Signbit(remaining) != math.Signbit(weeklyRate) {
+		return time.Time{}, false
+	}
+
+	days := math.Ceil(remaining / weeklyRate * 7)
+	// Projections further out than ten years are meaningless
+	if days > 3650 {
+		return time.Time{}, false
+	}
+	return from.Add(time.Duration(days) * day), true
+}
+
+// TDEE estimates total daily energy expenditure from the average daily intake and the trend
+// change over a window: energy eaten minus energy stored as body weight
+func TDEE(averageIntake, trendChange float64, days int) float64 {
+	if days <= 0 {
+		return averageIntake
+	}
+	return averageIntake - trendChange*KcalPerKg/float64(days)
+}
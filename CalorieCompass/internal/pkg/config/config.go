@@ -6,6 +6,7 @@ import (
 	"gopkg.in/yaml.v3"
 	"os"
 	"strings"
+	"time"
 )
 
 type (
@@ -16,6 +17,7 @@ type (
 		Postgres  `yaml:"postgres"`
 		JWT       `yaml:"jwt"`
 		FatSecret `yaml:"fatsecret"`
+		Food      `yaml:"food"`
 	}
 
 	App struct {
@@ -46,6 +48,20 @@ type (
 		ClientSecret   string `yaml:"client_secret"`
 		ConsumerKey    string `yaml:"consumer_key"`
 		ConsumerSecret string `yaml:"consumer_secret"`
+		// FoodAttributes requests declared allergens and diets with food details, which
+		// needs a FatSecret Premier plan
+		FoodAttributes bool `yaml:"food_attributes"`
+	}
+
+	Food struct {
+		Providers []FoodProvider `yaml:"providers"`
+	}
+
+	FoodProvider struct {
+		Name     string        `yaml:"name"`
+		Enabled  bool          `yaml:"enabled"`
+		Priority int           `yaml:"priority"`
+		Timeout  time.Duration `yaml:"timeout"`
 	}
 )
 
@@ -93,6 +109,9 @@ func NewConfig(configPath string) (*Config, error) {
 	if consumerSecret := os.Getenv("FATSECRET_CONSUMER_SECRET"); consumerSecret != "" {
 		config.FatSecret.ConsumerSecret = consumerSecret
 	}
+	if foodAttributes := os.Getenv("FATSECRET_FOOD_ATTRIBUTES"); foodAttributes != "" {
+		config.FatSecret.FoodAttributes = foodAttributes == "true"
+	}
 
 	return config, nil
 }
@@ -0,0 +1,168 @@
+package dri
+
+import "time"
+
+// Reference value types
+const (
+	TypeRDA = "rda"
+	TypeAI  = "ai"
+)
+
+// Nutrients with reference values, as named in servings
+const (
+	Protein = "protein"
+	Carbs   = "carbs"
+	Fat     = "fat"
+	Fiber   = "fiber"
+	Sodium  = "sodium"
+
+	Calcium   = "calcium"
+	Iron      = "iron"
+	Potassium = "potassium"
+	VitaminA  = "vitamin_a"
+	VitaminC  = "vitamin_c"
+	VitaminD  = "vitamin_d"
+)
+
+// Reference is a daily intake reference for one nutrient. UpperLimit is 0 when none is
+// set; for sodium it is the chronic disease risk reduction intake (CDRR), which replaced
+// the UL in 2019.
+type Reference struct {
+	Nutrient   string
+	Unit       string
+	Type       string
+	Amount     float64
+	UpperLimit float64
+}
+
+// Range is an acceptable macronutrient distribution range in percent of energy
+type Range struct {
+	Nutrient string
+	Min      float64
+	Max      float64
+}
+
+// Group is a life-stage group of the Dietary Reference Intakes with its reference values
+type Group struct {
+	Name   string
+	MinAge int
+	MaxAge int
+	Male   []Reference
+	Female []Reference
+	Ranges []Range
+}
+
+// MinAge is the youngest age the table covers; infant values are not included
+const MinAge = 1
+
+// groups follows the National Academies' DRI tables for the nutrients servings carry
+var groups = []Group{
+	{Name: "1-3", MinAge: 1, MaxAge: 3,
+		Male:   references(macros(13, 19, 800, 1200), minerals(700, 2500, 7, 40, 2000), vitamins(300, 15, 400, 15, 63)),
+		Female: references(macros(13, 19, 800, 1200), minerals(700, 2500, 7, 40, 2000), vitamins(300, 15, 400, 15, 63)),
+		Ranges: ranges(45, 65, 5, 20, 30, 40)},
+	{Name: "4-8", MinAge: 4, MaxAge: 8,
+		Male:   references(macros(19, 25, 1000, 1500), minerals(1000, 2500, 10, 40, 2300), vitamins(400, 25, 650, 15, 75)),
+		Female: references(macros(19, 25, 1000, 1500), minerals(1000, 2500, 10, 40, 2300), vitamins(400, 25, 650, 15, 75)),
+		Ranges: ranges(45, 65, 10, 30, 25, 35)},
+	{Name: "9-13", MinAge: 9, MaxAge: 13,
+		Male:   references(macros(34, 31, 1200, 1800), minerals(1300, 3000, 8, 40, 2500), vitamins(600, 45, 1200, 15, 100)),
+		Female: references(macros(34, 26, 1200, 1800), minerals(1300, 3000, 8, 40, 2300), vitamins(600, 45, 1200, 15, 100)),
+		Ranges: ranges(45, 65, 10, 30, 25, 35)},
+	{Name: "14-18", MinAge: 14, MaxAge: 18,
+		Male:   references(macros(52, 38, 1500, 2300), minerals(1300, 3000, 11, 45, 3000), vitamins(900, 75, 1800, 15, 100)),
+		Female: references(macros(46, 26, 1500, 2300), minerals(1300, 3000, 15, 45, 2300), vitamins(700, 65, 1800, 15, 100)),
+		Ranges: ranges(45, 65, 10, 30, 25, 35)},
+	{Name: "19-30", MinAge: 19, MaxAge: 30,
+		Male:   references(macros(56, 38, 1500, 2300), minerals(1000, 2500, 8, 45, 3400), vitamins(900, 90, 2000, 15, 100)),
+		Female: references(macros(46, 25, 1500, 2300), minerals(1000, 2500, 18, 45, 2600), vitamins(700, 75, 2000, 15, 100)),
+		Ranges: ranges(45, 65, 10, 35, 20, 35)},
+	{Name: "31-50", MinAge: 31, MaxAge: 50,
+		Male:   references(macros(56, 38, 1500, 2300), minerals(1000, 2500, 8, 45, 3400), vitamins(900, 90, 2000, 15, 100)),
+		Female: references(macros(46, 25, 1500, 2300), minerals(1000, 2500, 18, 45, 2600), vitamins(700, 75, 2000, 15, 100)),
+		Ranges: ranges(45, 65, 10, 35, 20, 35)},
+	{Name: "51-70", MinAge: 51, MaxAge: 70,
+		Male:   references(macros(56, 30, 1500, 2300), minerals(1000, 2000, 8, 45, 3400), vitamins(900, 90, 2000, 15, 100)),
+		Female: references(macros(46, 21, 1500, 2300), minerals(1200, 2000, 8, 45, 2600), vitamins(700, 75, 2000, 15, 100)),
+		Ranges: ranges(45, 65, 10, 35, 20, 35)},
+	{Name: "71+", MinAge: 71, MaxAge: 200,
+		Male:   references(macros(56, 30, 1500, 2300), minerals(1200, 2000, 8, 45, 3400), vitamins(900, 90, 2000, 20, 100)),
+		Female: references(macros(46, 21, 1500, 2300), minerals(1200, 2000, 8, 45, 2600), vitamins(700, 75, 2000, 20, 100)),
+		Ranges: ranges(45, 65, 10, 35, 20, 35)},
+}
+
+// Lookup returns the life-stage group for an age in years. It reports false below MinAge.
+func Lookup(age int) (Group, bool) {
+	for _, group := range groups {
+		if age >= group.MinAge && age <= group.MaxAge {
+			return group, true
+		}
+	}
+	return Group{}, false
+}
+
+// References returns the group's reference values for a sex
+func (g Group) References(male bool) []Reference {
+	if male {
+		return g.Male
+	}
+	return g.Female
+}
+
+// Age is the age in whole years on a date of someone born on birthDate
+func Age(birthDate, on time.Time) int {
+	age := on.Year() - birthDate.Year()
+	if on.Month() < birthDate.Month() || (on.Month() == birthDate.Month() && on.Day() < birthDate.Day()) {
+		age--
+	}
+	return age
+}
+
+// references joins a sex's macronutrient, mineral and vitamin reference values
+func references(parts ...[]Reference) []Reference {
+	var all []Reference
+	for _, part := range parts {
+		all = append(all, part...)
+	}
+	return all
+}
+
+// macros builds the protein RDA and fiber AI in g, the sodium AI and CDRR in mg and the
+// carbohydrate RDA of 130 g that applies from age 1
+func macros(protein, fiber, sodium, sodiumLimit float64) []Reference {
+	return []Reference{
+		{Nutrient: Protein, Unit: "g", Type: TypeRDA, Amount: protein},
+		{Nutrient: Carbs, Unit: "g", Type: TypeRDA, Amount: 130},
+		{Nutrient: Fiber, Unit: "g", Type: TypeAI, Amount: fiber},
+		{Nutrient: Sodium, Unit: "mg", Type: TypeAI, Amount: sodium, UpperLimit: sodiumLimit},
+	}
+}
+
+// minerals builds the calcium and iron RDAs and ULs and the potassium AI, all in mg.
+// Potassium has no UL for intake from food.
+func minerals(calcium, calciumLimit, iron, ironLimit, potassium float64) []Reference {
+	return []Reference{
+		{Nutrient: Calcium, Unit: "mg", Type: TypeRDA, Amount: calcium, UpperLimit: calciumLimit},
+		{Nutrient: Iron, Unit: "mg", Type: TypeRDA, Amount: iron, UpperLimit: ironLimit},
+		{Nutrient: Potassium, Unit: "mg", Type: TypeAI, Amount: potassium},
+	}
+}
+
+// vitamins builds the vitamin A RDA in µg RAE, the vitamin C RDA and UL in mg and the
+// vitamin D RDA and UL in µg. The vitamin A UL is left out: it applies to preformed
+// vitamin A only, which servings do not tell apart from carotenoids.
+func vitamins(vitaminA, vitaminC, vitaminCLimit, vitaminD, vitaminDLimit float64) []Reference {
+	return []Reference{
+		{Nutrient: VitaminA, Unit: "µg", Type: TypeRDA, Amount: vitaminA},
+		{Nutrient: VitaminC, Unit: "mg", Type: TypeRDA, Amount: vitaminC, UpperLimit: vitaminCLimit},
+		{Nutrient: VitaminD, Unit: "µg", Type: TypeRDA, Amount: vitaminD, UpperLimit: vitaminDLimit},
+	}
+}
+
+func ranges(carbsMin, carbsMax, proteinMin, proteinMax, fatMin, fatMax float64) []Range {
+	return []Range{
+		{Nutrient: Carbs, Min: carbsMin, Max: carbsMax},
+		{Nutrient: Protein, Min: proteinMin, Max: proteinMax},
+		{Nutrient: Fat, Min: fatMin, Max: fatMax},
+	}
+}
@@ -0,0 +1,252 @@
+package glycemic
+
+import "CalorieCompass/internal/pkg/textmatch"
+
+// Sources of a glycemic index match
+const (
+	SourceName     = "name"
+	SourceCategory = "category"
+)
+
+// Glycemic index classes (glucose = 100)
+const (
+	LowMax    = 55
+	MediumMax = 69
+)
+
+// Glycemic load classes, per serving and per day
+const (
+	LoadLowMax         = 10.0
+	LoadMediumMax      = 19.0
+	DailyLoadLowMax    = 80.0
+	DailyLoadMediumMax = 120.0
+)
+
+// Classes of glycemic index and load values
+const (
+	ClassLow    = "low"
+	ClassMedium = "medium"
+	ClassHigh   = "high"
+)
+
+// Match is the reference food or category a food's glycemic index was taken from
+type Match struct {
+	Reference string
+	Index     int
+	Source    string
+}
+
+// reference is a food of the table with the phrases that identify it in food names. The
+// longest matching phrase wins, so "brown rice" is preferred over "rice".
+type reference struct {
+	name     string
+	index    int
+	keywords []string
+}
+
+// references holds representative glycemic index values (glucose = 100), rounded means
+// from the International Tables of Glycemic Index and Glycemic Load Values
+var references = []reference{
+	{"White bread", 75, []string{"white bread", "bread", "toast", "baguette", "bun"}},
+	{"Wholemeal bread", 74, []string{"whole wheat bread", "wholemeal bread", "whole grain bread", "wholegrain bread", "brown bread"}},
+	{"Rye bread", 58, []string{"rye bread", "rye"}},
+	{"Pumpernickel", 50, []string{"pumpernickel"}},
+	{"Sourdough bread", 54, []string{"sourdough"}},
+	{"Bagel", 72, []string{"bagel"}},
+	{"Pita bread", 68, []string{"pita", "pitta"}},
+	{"Wheat tortilla", 30, []string{"tortilla", "wrap"}},
+	{"Corn tortilla", 46, []string{"corn tortilla"}},
+	{"Croissant", 67, []string{"croissant"}},
+	{"Doughnut", 76, []string{"doughnut", "donut"}},
+	{"Muffin", 60, []string{"muffin"}},
+	{"Sponge cake", 46, []string{"cake"}},
+	{"Pancakes", 66, []string{"pancake", "crepe"}},
+	{"Waffles", 76, []string{"waffle"}},
+	{"Cookies", 55, []string{"cookie", "biscuit"}},
+	{"Crackers", 74, []string{"cracker"}},
+	{"Rice crackers", 87, []string{"rice cracker", "rice cake"}},
+	{"Pretzels", 83, []string{"pretzel"}},
+	{"Pizza", 60, []string{"pizza"}},
+	{"White rice", 73, []string{"rice", "white rice"}},
+	{"Brown rice", 68, []string{"brown rice"}},
+	{"Basmati rice", 58, []string{"basmati"}},
+	{"Jasmine rice", 89, []string{"jasmine rice"}},
+	{"Sticky rice", 87, []string{"sticky rice", "glutinous rice"}},
+	{"Spaghetti", 49, []string{"pasta", "spaghetti", "penne", "fusilli", "linguine", "tagliatelle", "lasagna", "lasagne"}},
+	{"Macaroni", 47, []string{"macaroni"}},
+	{"Wheat noodles", 47, []string{"noodle", "udon", "ramen"}},
+	{"Rice noodles", 53, []string{"rice noodle", "vermicelli"}},
+	{"Couscous", 65, []string{"couscous"}},
+	{"Quinoa", 53, []string{"quinoa"}},
+	{"Bulgur", 47, []string{"bulgur", "bulgar"}},
+	{"Pearl barley", 28, []string{"barley"}},
+	{"Buckwheat", 54, []string{"buckwheat"}},
+	{"Millet", 71, []string{"millet"}},
+	{"Porridge oats", 55, []string{"oat", "oatmeal", "porridge", "rolled oat"}},
+	{"Instant oatmeal", 79, []string{"instant oat", "instant oatmeal", "instant porridge"}},
+	{"Cornflakes", 81, []string{"cornflakes", "corn flakes"}},
+	{"Muesli", 57, []string{"muesli", "granola"}},
+	{"Bran cereal", 44, []string{"bran"}},
+	{"Puffed rice cereal", 82, []string{"puffed rice", "rice krispies"}},
+	{"Boiled potato", 78, []string{"potato", "potatoes"}},
+	{"Mashed potato", 87, []string{"mashed potato", "mash"}},
+	{"Baked potato", 85, []string{"baked potato", "jacket potato"}},
+	{"French fries", 63, []string{"fries", "french fries", "chips"}},
+	{"Potato crisps", 56, []string{"crisps", "potato chips"}},
+	{"Sweet potato", 63, []string{"sweet potato"}},
+	{"Popcorn", 65, []string{"popcorn"}},
+	{"Sweet corn", 52, []string{"corn", "sweetcorn", "sweet corn"}},
+	{"Green peas", 51, []string{"peas", "green peas"}},
+	{"Carrots", 39, []string{"carrot"}},
+	{"Pumpkin", 64, []string{"pumpkin", "squash"}},
+	{"Beetroot", 64, []string{"beetroot", "beet"}},
+	{"Chickpeas", 28, []string{"chickpea", "garbanzo"}},
+	{"Lentils", 32, []string{"lentil", "dal", "dhal"}},
+	{"Kidney beans", 24, []string{"kidney bean", "red bean"}},
+	{"Black beans", 30, []string{"black bean"}},
+	{"Baked beans", 40, []string{"baked bean"}},
+	{"Soybeans", 16, []string{"soybean", "soy bean", "edamame"}},
+	{"Hummus", 6, []string{"hummus", "houmous"}},
+	{"Apple", 36, []string{"apple"}},
+	{"Banana", 51, []string{"banana"}},
+	{"Orange", 43, []string{"orange", "clementine", "mandarin"}},
+	{"Grapes", 59, []string{"grape"}},
+	{"Mango", 51, []string{"mango"}},
+	{"Pineapple", 59, []string{"pineapple"}},
+	{"Watermelon", 76, []string{"watermelon"}},
+	{"Cantaloupe", 65, []string{"melon", "cantaloupe"}},
+	{"Strawberries", 41, []string{"strawberry", "strawberries"}},
+	{"Blueberries", 53, []string{"blueberry", "blueberries"}},
+	{"Cherries", 22, []string{"cherry", "cherries"}},
+	{"Pear", 38, []string{"pear"}},
+	{"Peach", 42, []string{"peach", "peaches", "nectarine"}},
+	{"Kiwifruit", 50, []string{"kiwi", "kiwifruit"}},
+	{"Dates", 42, []string{"date", "dates"}},
+	{"Raisins", 64, []string{"raisin", "sultana"}},
+	{"Dried apricots", 31, []string{"dried apricot"}},
+	{"Apple juice", 41, []string{"apple juice"}},
+	{"Orange juice", 50, []string{"orange juice", "juice"}},
+	{"Milk", 39, []string{"milk"}},
+	{"Skim milk", 37, []string{"skim milk", "skimmed milk"}},
+	{"Soy milk", 34, []string{"soy milk", "soya milk"}},
+	{"Oat milk", 69, []string{"oat milk", "oat drink"}},
+	{"Rice milk", 86, []string{"rice milk", "rice drink"}},
+	{"Yogurt", 41, []string{"yogurt", "yoghurt"}},
+	{"Ice cream", 51, []string{"ice cream"}},
+	{"Glucose", 103, []string{"glucose", "dextrose"}},
+	// Sugar only as a food of its own: "sugar" alone is in too many names, and USDA names
+	// read "Sugars, granulated"
+	{"Sucrose", 65, []string{"sucrose", "table sugar", "white sugar", "brown sugar", "cane sugar", "granulated sugar",
+		"caster sugar", "icing sugar", "sugars granulated", "sugars brown", "sugars powdered"}},
+	{"Fructose", 15, []string{"fructose"}},
+	{"Honey", 61, []string{"honey"}},
+	{"Maple syrup", 54, []string{"maple syrup"}},
+	{"Cola", 63, []string{"cola", "soda", "soft drink", "lemonade"}},
+	{"Sports drink", 78, []string{"sports drink", "gatorade", "powerade"}},
+	{"Chocolate", 40, []string{"chocolate"}},
+	{"Jelly beans", 78, []string{"jelly bean", "gummy", "gummies"}},
+	{"Peanuts", 7, []string{"peanut"}},
+	{"Cashews", 25, []string{"cashew"}},
+}
+
+// categories holds typical glycemic index values for dataset categories, used when a
+// food's name matches no reference
+var categories = []reference{
+	{"Breakfast cereals", 70, []string{"breakfast cereal", "cereal"}},
+	{"Baked products", 70, []string{"baked product", "bakery", "breads", "biscuits", "cakes"}},
+	{"Cereal grains and pasta", 60, []string{"cereal grain", "grain", "pasta"}},
+	{"Legumes", 30, []string{"legume", "pulse"}},
+	{"Fruits", 45, []string{"fruit"}},
+	{"Vegetables", 40, []string{"vegetable"}},
+	{"Dairy", 35, []string{"dairy", "dairies", "yogurt", "milk"}},
+	{"Sweets", 65, []string{"sweet", "candy", "candies", "confectionery", "confectioneries"}},
+	{"Snacks", 60, []string{"snack"}},
+	{"Sodas", 63, []string{"soda", "soft drink", "carbonated drink"}},
+	{"Juices", 50, []string{"juice"}},
+}
+
+// Lookup finds the glycemic index of a food by its name, falling back to its category when
+// the name matches no reference
+func Lookup(name, category string) (Match, bool) {
+	if match, ok := lookup(references, textmatch.Tokenize(name)); ok {
+		match.Source = SourceName
+		return match, true
+	}
+	if category == "" {
+		return Match{}, false
+	}
+	tokens := textmatch.Tokenize(category)
+	if match, ok := lookup(references, tokens); ok {
+		match.Source = SourceCategory
+		return match, true
+	}
+	if match, ok := lookup(categories, tokens); ok {
+		match.Source = SourceCategory
+		return match, true
+	}
+	return Match{}, false
+}
+
+// Load is the glycemic load of a food: its glycemic index times its available
+// carbohydrates (carbs minus fiber) in grams, divided by 100
+func Load(index int, netCarbs float64) float64 {
+	return float64(index) * netCarbs / 100
+}
+
+// IndexClass classifies a glycemic index as low (55 or less), medium (56-69) or high
+func IndexClass(index int) string {
+	switch {
+	case index <= LowMax:
+		return ClassLow
+	case index <= MediumMax:
+		return ClassMedium
+	}
+	return ClassHigh
+}
+
+// LoadClass classifies the glycemic load of a serving as low (10 or less), medium (11-19)
+// or high
+func LoadClass(load float64) string {
+	return loadClass(load, LoadLowMax, LoadMediumMax)
+}
+
+// DailyLoadClass classifies a day's glycemic load as low (below 80), medium (80-120) or high
+func DailyLoadClass(load float64) string {
+	return loadClass(load, DailyLoadLowMax, DailyLoadMediumMax)
+}
+
+func loadClass(load, lowMax, mediumMax float64) string {
+	switch {
+	case load <= lowMax:
+		return ClassLow
+	case load <= mediumMax:
+		return ClassMedium
+	}
+	return ClassHigh
+}
+
+// lookup returns the reference with the longest keyword found in the tokens. Between
+// keywords of the same length the one ending last wins, as English names end with the
+// food itself: "banana bread" is bread. Keywords followed by "free", as in "sugar free
+// cola", name what the food lacks and are skipped.
+func lookup(table []reference, tokens []string) (Match, bool) {
+	var best Match
+	bestLength, bestEnd := 0, 0
+	for _, ref := range table {
+		for _, keyword := range ref.keywords {
+			phrase := textmatch.Tokenize(keyword)
+			if len(phrase) < bestLength {
+				continue
+			}
+			for _, start := range textmatch.Find(tokens, phrase, true) {
+				end := start + len(phrase)
+				if textmatch.FreeOf(tokens, end) || (len(phrase) == bestLength && end <= bestEnd) {
+					continue
+				}
+				best = Match{Reference: ref.name, Index: ref.index}
+				bestLength, bestEnd = len(phrase), end
+			}
+		}
+	}
+	return best, bestLength > 0
+}
@@ -0,0 +1,43 @@
+package hydration
+
+import "math"
+
+const (
+	// MlPerKg is the daily total water need per kg of body weight, from drinks and food
+	MlPerKg = 35
+	// ExerciseMlPerHour is the extra water needed per hour of exercise (ACSM recommends
+	// 0.4-0.8 l/h depending on sweat rate)
+	ExerciseMlPerHour = 500
+	// HighWaterFraction is the water content above which a food counts towards hydration
+	HighWaterFraction = 0.8
+)
+
+// Adequate total water intakes for adults set by EFSA, used without a body weight
+const (
+	DefaultTargetFemale = 2000
+	DefaultTargetMale   = 2500
+)
+
+// BaseTarget returns the daily water target for a body weight in kg. Without a weight it
+// falls back to the EFSA adequate intake, the female value when the sex is unknown.
+func BaseTarget(weightKg float64, male bool) float64 {
+	if weightKg > 0 {
+		return math.Round(weightKg * MlPerKg)
+	}
+	if male {
+		return DefaultTargetMale
+	}
+	return DefaultTargetFemale
+}
+
+// ExerciseExtra returns the extra water needed for minutes of exercise
+func ExerciseExtra(minutes float64) float64 {
+	return math.Round(minutes / 60 * ExerciseMlPerHour)
+}
+
+// FoodWater estimates the water in a food from its mass and macronutrients in grams. What is
+// not carbohydrate, protein or fat is taken as water; ash and alcohol are ignored, which
+// slightly overestimates salty foods and alcoholic drinks.
+func FoodWater(grams, carbs, protein, fat float64) float64 {
+	return math.Max(0, grams-carbs-protein-fat)
+}
@@ -0,0 +1,324 @@
+package ingredient
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"CalorieCompass/internal/pkg/units"
+)
+
+// Line is a parsed ingredient line such as "1 1/2 cups all-purpose flour, sifted"
+type Line struct {
+	Raw string `json:"raw"`
+	// Quantity is 0 when the line has no quantity, e.g. "salt to taste"
+	Quantity float64 `json:"quantity"`
+	// Unit is a mass or volume unit understood by the units package ("g", "cup"),
+	// a count unit such as "slice" or "clove", or empty for plain counts ("2 eggs")
+	Unit string `json:"unit,omitempty"`
+	// Size is "small", "medium" or "large" when given
+	Size string `json:"size,omitempty"`
+	Food string `json:"food"`
+	Note string `json:"note,omitempty"`
+}
+
+// Measured reports whether the line's unit is a mass or volume unit that can be converted
+func (l Line) Measured() bool {
+	_, err := units.Parse(l.Unit)
+	return err == nil
+}
+
+// CountUnits are household units that count pieces rather than measure mass or volume
+var CountUnits = map[string]string{
+	"slice": "slice", "slices": "slice",
+	"piece": "piece", "pieces": "piece", "pc": "piece", "pcs": "piece",
+	"clove": "clove", "cloves": "clove",
+	"can": "can", "cans": "can", "tin": "can", "tins": "can",
+	"jar": "jar", "jars": "jar",
+	"bottle": "bottle", "bottles": "bottle",
+	"packet": "packet", "packets": "packet", "package": "packet", "packages": "packet", "pack": "packet",
+	"pinch": "pinch", "pinches": "pinch",
+	"dash": "dash", "dashes": "dash",
+	"handful": "handful", "handfuls": "handful",
+	"stick": "stick", "sticks": "stick",
+	"bunch": "bunch", "bunches": "bunch",
+	"sprig": "sprig", "sprigs": "sprig",
+	"head": "head", "heads": "head",
+	"stalk": "stalk", "stalks": "stalk",
+	"fillet": "fillet", "fillets": "fillet",
+	"scoop": "scoop", "scoops": "scoop",
+	"bowl": "bowl", "bowls": "bowl",
+	"glass": "glass", "glasses": "glass",
+	"mug": "mug", "mugs": "mug",
+	"bar": "bar", "bars": "bar",
+	"serving": "serving", "servings": "serving",
+	"portion": "serving", "portions": "serving",
+}
+
+// Sizes maps size words to their canonical form
+var Sizes = map[string]string{
+	"small": "small", "medium": "medium", "large": "large",
+	"big": "large", "extra-large": "large", "xl": "large", "jumbo": "large",
+}
+
+// NumberWords maps quantity words to their value
+var NumberWords = map[string]float64{
+	"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+	"half": 0.5, "quarter": 0.25, "dozen": 12, "couple": 2, "few": 3,
+}
+
+// vulgarFractions maps unicode fraction characters to their value
+var vulgarFractions = map[rune]float64{
+	'½': 1.0 / 2, '⅓': 1.0 / 3, '⅔': 2.0 / 3, '¼': 1.0 / 4, '¾': 3.0 / 4,
+	'⅕': 1.0 / 5, '⅖': 2.0 / 5, '⅗': 3.0 / 5, '⅘': 4.0 / 5, '⅙': 1.0 / 6,
+	'⅚': 5.0 / 6, '⅛': 1.0 / 8, '⅜': 3.0 / 8, '⅝': 5.0 / 8, '⅞': 7.0 / 8,
+}
+
+// preparationWords are dropped from the food phrase, e.g. "finely chopped onion"
+var preparationWords = map[string]bool{
+	"chopped": true, "diced": true, "minced": true, "sliced": true, "grated": true,
+	"shredded": true, "crushed": true, "peeled": true, "finely": true, "roughly": true,
+	"freshly": true, "thinly": true, "halved": true, "cubed": true, "melted": true,
+	"softened": true, "beaten": true, "sifted": true, "packed": true, "heaping": true,
+	"heaped": true, "level": true, "about": true, "approximately": true, "approx": true,
+	"of": true, "to": true, "taste": true, "optional": true, "plus": true, "more": true,
+	"for": true, "serving": true, "garnish": true,
+}
+
+var (
+	parentheses = regexp.MustCompile(`\([^)]*\)`)
+	// "200g", "1.5kg" or "2tbsp" glue the unit to the number
+	gluedUnit = regexp.MustCompile(`^(\d+(?:[.,]\d+)?)([a-zA-Z]+\.?)$`)
+)
+
+// Parse splits an ingredient line into quantity, unit, size, food phrase and note.
+// Ranges such as "1-2" or "1 to 2" use their midpoint.
+func Parse(raw string) Line {
+	line := Line{Raw: strings.TrimSpace(raw)}
+
+	text := strings.TrimLeft(line.Raw, "-*•·▢ \t")
+	var notes []string
+	for _, match := range parentheses.FindAllString(text, -1) {
+		notes = append(notes, strings.Trim(match, "() "))
+	}
+	text = parentheses.ReplaceAllString(text, " ")
+	if i := strings.Index(text, ","); i >= 0 {
+		notes = append(notes, strings.TrimSpace(text[i+1:]))
+		text = text[:i]
+	}
+	line.Note = strings.Join(nonEmpty(notes), "; ")
+
+	tokens := Tokenize(text)
+	quantity, rest := ParseQuantity(tokens)
+	line.Quantity = quantity
+
+	// A size may precede or follow the unit: "1 large can", "2 cans large"
+	for len(rest) > 0 {
+		word := strings.ToLower(rest[0])
+		if size, ok := Sizes[word]; ok && line.Size == "" {
+			line.Size = size
+			rest = rest[1:]
+			continue
+		}
+		if line.Unit == "" {
+			if unit, n := matchUnit(rest); n > 0 {
+				line.Unit = unit
+				rest = rest[n:]
+				continue
+			}
+		}
+		break
+	}
+
+	words := make([]string, 0, len(rest))
+	for _, token := range rest {
+		word := strings.ToLower(token)
+		if preparationWords[word] || isPunctuation(word) {
+			continue
+		}
+		words = append(words, word)
+	}
+	line.Food = strings.Join(words, " ")
+
+	return line
+}
+
+// Tokenize splits text into words and numbers, separating numbers glued to units ("200g")
+// and unicode fractions glued to integers ("1½")
+func Tokenize(text string) []string {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ';' || r == ':'
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		field = strings.TrimRight(field, ".!?")
+		if field == "" {
+			continue
+		}
+		if match := gluedUnit.FindStringSubmatch(field); match != nil {
+			if _, err := units.Parse(match[2]); err == nil {
+				tokens = append(tokens, match[1], match[2])
+				continue
+			}
+		}
+		// "1½" becomes "1" and "½"
+		if runes := []rune(field); len(runes) > 1 {
+			if _, ok := vulgarFractions[runes[len(runes)-1]]; ok && unicode.IsDigit(runes[len(runes)-2]) {
+				tokens = append(tokens, string(runes[:len(runes)-1]), string(runes[len(runes)-1]))
+				continue
+			}
+		}
+		tokens = append(tokens, field)
+	}
+	return tokens
+}
+
+// ParseQuantity reads a leading quantity from tokens, e.g. "2", "1 1/2", "1.5", "½",
+// "1-2", "one", "half a" or "a dozen", and returns it with the remaining tokens.
+// It returns 0 and the original tokens when there is no quantity.
+func ParseQuantity(tokens []string) (float64, []string) {
+	total := 0.0
+	i := 0
+	for i < len(tokens) {
+		value, ok := parseNumber(tokens[i])
+		if !ok {
+			break
+		}
+		word := strings.ToLower(tokens[i])
+		switch {
+		case word == "dozen" && total > 0:
+			total *= 12
+		case (word == "half" || word == "quarter") && total > 0:
+			// "one and a half" is handled by the "and" branch below; "two half" is not a quantity
+			return total, tokens[i:]
+		case (word == "a" || word == "an") && total > 0:
+			// "half a cup": the article belongs to the unit
+			i++
+			continue
+		default:
+			total += value
+		}
+		i++
+
+		// Ranges "1-2" (split as a single token) and "1 to 2" / "1 or 2" use the midpoint
+		if i+1 < len(tokens) && (strings.EqualFold(tokens[i], "to") || strings.EqualFold(tokens[i], "or") || tokens[i] == "-") {
+			if upper, ok := parseNumber(tokens[i+1]); ok && upper > total {
+				total = (total + upper) / 2
+				i += 2
+			}
+		}
+		// "one and a half"
+		if i+2 < len(tokens) && strings.EqualFold(tokens[i], "and") && isArticle(tokens[i+1]) {
+			if fraction, ok := parseNumber(tokens[i+2]); ok && fraction < 1 {
+				total += fraction
+				i += 3
+			}
+		}
+	}
+
+	if i == 0 {
+		return 0, tokens
+	}
+	return total, tokens[i:]
+}
+
+// parseNumber parses a single numeric token: integers, decimals (with "." or ","), simple
+// fractions, unicode fractions, ranges such as "1-2" and number words
+func parseNumber(token string) (float64, bool) {
+	word := strings.ToLower(token)
+	if value, ok := NumberWords[word]; ok {
+		return value, true
+	}
+
+	runes := []rune(token)
+	if len(runes) == 1 {
+		if value, ok := vulgarFractions[runes[0]]; ok {
+			return value, true
+		}
+	}
+
+	if low, high, ok := strings.Cut(token, "-"); ok && low != "" && high != "" {
+		lowValue, okLow := parseNumber(low)
+		highValue, okHigh := parseNumber(high)
+		if okLow && okHigh && highValue > lowValue {
+			return (lowValue + highValue) / 2, true
+		}
+		return 0, false
+	}
+
+	if numerator, denominator, ok := strings.Cut(token, "/"); ok {
+		n, okN := parseDecimal(numerator)
+		d, okD := parseDecimal(denominator)
+		if !okN || !okD || d == 0 {
+			return 0, false
+		}
+		return n / d, true
+	}
+
+	return parseDecimal(token)
+}
+
+// parseDecimal parses digits with at most one "." or "," decimal separator. Anything else
+// strconv.ParseFloat would take, such as "nan" (naan), "inf" or exponents, is not a number
+// in an ingredient line.
+func parseDecimal(token string) (float64, bool) {
+	digits, separators := 0, 0
+	for _, r := range token {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '.' || r == ',':
+			separators++
+		default:
+			return 0, false
+		}
+	}
+	if digits == 0 || separators > 1 {
+		return 0, false
+	}
+
+	value, err := strconv.ParseFloat(strings.Replace(token, ",", ".", 1), 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+// matchUnit recognises a mass/volume unit (including two-word units such as "fl oz" and
+// "fluid ounces") or a count unit at the start of tokens. It returns the canonical unit
+// name and the number of tokens consumed.
+func matchUnit(tokens []string) (string, int) {
+	if len(tokens) >= 2 {
+		if unit, err := units.Parse(tokens[0] + " " + tokens[1]); err == nil {
+			return unit.Name, 2
+		}
+	}
+	if unit, err := units.Parse(tokens[0]); err == nil {
+		return unit.Name, 1
+	}
+	if unit, ok := CountUnits[strings.ToLower(strings.TrimSuffix(tokens[0], "."))]; ok {
+		return unit, 1
+	}
+	return "", 0
+}
+
+func isArticle(token string) bool {
+	return strings.EqualFold(token, "a") || strings.EqualFold(token, "an")
+}
+
+func isPunctuation(word string) bool {
+	return strings.IndexFunc(word, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0
+}
+
+func nonEmpty(values []string) []string {
+	result := values[:0]
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,74 @@
+package ingredient
+
+import (
+	"strings"
+	"unicode"
+)
+
+// stopWords carry no meaning when comparing food names
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "the": true, "with": true, "of": true, "in": true,
+	"or": true, "fresh": true, "raw": true, "plain": true,
+}
+
+// Words normalises a food phrase into comparable words: lower case, letters and digits only,
+// stop words removed and simple plurals reduced ("tomatoes" -> "tomato", "eggs" -> "egg")
+func Words(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	words := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if stopWords[field] {
+			continue
+		}
+		words = append(words, singular(field))
+	}
+	return words
+}
+
+// Similarity scores how well a food name matches a query between 0 and 1. It is the share
+// of query words found in the name, discounted by extra words in the name, so that "egg"
+// matches "Egg" better than "Egg Noodles".
+func Similarity(query, name string) float64 {
+	queryWords := Words(query)
+	nameWords := Words(name)
+	if len(queryWords) == 0 || len(nameWords) == 0 {
+		return 0
+	}
+
+	inName := make(map[string]bool, len(nameWords))
+	for _, word := range nameWords {
+		inName[word] = true
+	}
+
+	matched := 0
+	for _, word := range queryWords {
+		if inName[word] {
+			matched++
+		}
+	}
+	if matched == 0 {
+		return 0
+	}
+
+	recall := float64(matched) / float64(len(queryWords))
+	precision := float64(matched) / float64(len(nameWords))
+	// Weighted towards recall: every query word should be present, extra name words cost less
+	return 0.7*recall + 0.3*precision
+}
+
+// singular strips common English plural endings
+func singular(word string) string {
+	switch {
+	case len(word) > 4 && strings.HasSuffix(word, "ies"):
+		return word[:len(word)-3] + "y"
+	case len(word) > 4 && (strings.HasSuffix(word, "oes") || strings.HasSuffix(word, "ches") ||
+		strings.HasSuffix(word, "shes") || strings.HasSuffix(word, "sses")):
+		return word[:len(word)-2]
+	case len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us"):
+		return word[:len(word)-1]
+	}
+	return word
+}
@@ -0,0 +1,56 @@
+package ingredient
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// Commas, semicolons, new lines and "plus" always start a new food. "with" does not, since
+	// it mostly names part of one food ("coffee with milk", "yogurt with honey").
+	phraseSeparator = regexp.MustCompile(`(?i)\s*(?:[,;\n]|\bplus\b)\s*`)
+	// "and"/"&" only separate foods when a quantity follows, so "mac and cheese" stays whole
+	conjunction = regexp.MustCompile(`(?i)\s+(?:and|&)\s+`)
+)
+
+// SplitPhrases splits a free-text meal description such as "2 scrambled eggs, a slice of
+// toast and a coffee with milk" into one phrase per food
+func SplitPhrases(text string) []string {
+	var phrases []string
+	for _, part := range phraseSeparator.Split(text, -1) {
+		part = strings.TrimSpace(part)
+		// Oxford comma: "eggs, toast, and coffee"
+		if strings.HasPrefix(strings.ToLower(part), "and ") {
+			part = strings.TrimSpace(part[4:])
+		}
+		if part == "" {
+			continue
+		}
+		phrases = append(phrases, splitConjunctions(part)...)
+	}
+	return phrases
+}
+
+// splitConjunctions splits on "and" where the right-hand side starts with a quantity
+func splitConjunctions(text string) []string {
+	var phrases []string
+	start := 0
+	for _, loc := range conjunction.FindAllStringIndex(text, -1) {
+		if quantity, _ := ParseQuantity(Tokenize(text[loc[1]:])); quantity == 0 {
+			continue
+		}
+		// "one and a half" is a single quantity, not two foods
+		if strings.TrimSpace(text[start:loc[0]]) == "" || isQuantityOnly(text[start:loc[0]]) {
+			continue
+		}
+		phrases = append(phrases, strings.TrimSpace(text[start:loc[0]]))
+		start = loc[1]
+	}
+	return append(phrases, strings.TrimSpace(text[start:]))
+}
+
+func isQuantityOnly(text string) bool {
+	tokens := Tokenize(text)
+	_, rest := ParseQuantity(tokens)
+	return len(tokens) > 0 && len(rest) == 0
+}
@@ -0,0 +1,204 @@
+package nutriscore
+
+import (
+	"math"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/nutrition"
+)
+
+// Categories with their own thresholds in the 2023 algorithm
+const (
+	CategoryGeneral  = "general"
+	CategoryBeverage = "beverage"
+	CategoryFat      = "fat"
+)
+
+// Grades from best to worst
+const (
+	GradeA = "a"
+	GradeB = "b"
+	GradeC = "c"
+	GradeD = "d"
+	GradeE = "e"
+)
+
+// Grades lists the grades from best to worst
+var Grades = []string{GradeA, GradeB, GradeC, GradeD, GradeE}
+
+const (
+	// FatCategoryMin is the fat content per 100 g from which a food is scored as a fat, oil,
+	// nut or seed; the category itself is not known from nutrients alone
+	FatCategoryMin = 40.0
+	// kJPerKcal converts energy to kilojoules
+	kJPerKcal = 4.184
+	// kJPerGramSaturatedFat is the energy of saturated fat used by the fat category
+	kJPerGramSaturatedFat = 37.0
+	// saltPerSodium converts sodium to salt
+	saltPerSodium = 2.5
+	// proteinCapGeneral and proteinCapFat are the negative points from which protein no
+	// longer counts, for general foods and fats respectively
+	proteinCapGeneral = 11
+	proteinCapFat     = 7
+)
+
+// Result is a Nutri-Score with the points it is made of
+type Result struct {
+	Category string
+	Score    int
+	Grade    string
+	Negative int
+	Positive int
+}
+
+// Thresholds per 100 g or 100 ml: a value above the i-th threshold scores i+1 points
+var (
+	energyGeneral      = []float64{335, 670, 1005, 1340, 1675, 2010, 2345, 2680, 3015, 3350}
+	energyBeverage     = []float64{30, 90, 150, 210, 240, 270, 300, 330, 360, 390}
+	energySaturatedFat = []float64{120, 240, 360, 480, 600, 720, 840, 960, 1080, 1200}
+	sugarsGeneral      = []float64{3.4, 6.8, 10, 14, 17, 20, 24, 27, 31, 34, 37, 41, 44, 48, 51}
+	sugarsBeverage     = []float64{0.5, 2, 3.5, 5, 6, 7, 8, 9, 10, 11}
+	saturatedFat       = []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	saturatedFatRatio  = []float64{10, 16, 22, 28, 34, 40, 46, 52, 58, 64}
+	salt               = []float64{0.2, 0.4, 0.6, 0.8, 1, 1.2, 1.4, 1.6, 1.8, 2, 2.2, 2.4, 2.6, 2.8, 3, 3.2, 3.4, 3.6, 3.8, 4}
+	fiber              = []float64{3.0, 4.1, 5.2, 6.3, 7.4}
+	proteinGeneral     = []float64{2.4, 4.8, 7.2, 9.6, 12, 14, 17}
+	proteinBeverage    = []float64{1.2, 1.5, 1.8, 2.1, 2.4, 2.7, 3.0}
+)
+
+// Classify picks the category of per-100 g/ml values: foods with at least FatCategoryMin
+// of fat are fats, other liquids beverages and everything else general foods
+func Classify(per100 entity.Serving) string {
+	switch {
+	case per100.Fat >= FatCategoryMin:
+		return CategoryFat
+	case per100.MetricServingUnit == "ml":
+		return CategoryBeverage
+	}
+	return CategoryGeneral
+}
+
+// Compute scores per-100 g/ml values with the 2023 Nutri-Score algorithm. The share of
+// fruit, vegetables and legumes and the presence of sweeteners are not known from the
+// nutrients and count as none; a beverage without energy, sugars or saturated fat is graded
+// as water.
+func Compute(per100 entity.Serving) Result {
+	category := Classify(per100)
+	energy := per100.Calories * kJPerKcal
+	saltGrams := per100.Sodium * saltPerSodium / 1000
+
+	result := Result{Category: category}
+	var protein int
+	switch category {
+	case CategoryBeverage:
+		result.Negative = points(energy, energyBeverage) + points(per100.Sugar, sugarsBeverage) +
+			points(per100.SaturatedFat, saturatedFat) + points(saltGrams, salt)
+		protein = points(per100.Protein, proteinBeverage)
+	case CategoryFat:
+		ratio := 0.0
+		if per100.Fat > 0 {
+			ratio = per100.SaturatedFat / per100.Fat * 100
+		}
+		result.Negative = points(per100.SaturatedFat*kJPerGramSaturatedFat, energySaturatedFat) +
+			points(per100.Sugar, sugarsGeneral) + ratioPoints(ratio) + points(saltGrams, salt)
+		protein = points(per100.Protein, proteinGeneral)
+		if result.Negative >= proteinCapFat {
+			protein = 0
+		}
+	default:
+		result.Negative = points(energy, energyGeneral) + points(per100.Sugar, sugarsGeneral) +
+			points(per100.SaturatedFat, saturatedFat) + points(saltGrams, salt)
+		protein = points(per100.Protein, proteinGeneral)
+		if result.Negative >= proteinCapGeneral {
+			protein = 0
+		}
+	}
+	result.Positive = points(per100.Fiber, fiber) + protein
+	result.Score = result.Negative - result.Positive
+
+	if category == CategoryBeverage && per100.Calories <= 0 && per100.Sugar <= 0 && per100.SaturatedFat <= 0 {
+		result.Grade = GradeA
+		return result
+	}
+	result.Grade = grade(category, result.Score)
+	return result
+}
+
+// Quality rates per-100 g/ml values with their Nutri-Score and nutrient density index
+func Quality(per100 entity.Serving) *entity.FoodQuality {
+	result := Compute(per100)
+	quality := &entity.FoodQuality{
+		NutriScore: entity.NutriScore{
+			Grade:    result.Grade,
+			Score:    result.Score,
+			Category: result.Category,
+			Negative: result.Negative,
+			Positive: result.Positive,
+		},
+	}
+	if density, ok := nutrition.NutrientDensity(per100); ok {
+		density = math.Round(density*10) / 10
+		quality.NutrientDensity = &density
+	}
+	return quality
+}
+
+// DetailsQuality rates a food from its first serving with a metric amount; it returns nil
+// when no serving has one
+func DetailsQuality(details entity.FoodDetails) *entity.FoodQuality {
+	per100, ok := nutrition.DetailsPer100(details)
+	if !ok {
+		return nil
+	}
+	return Quality(per100)
+}
+
+// GradeValue maps a grade to 1 (A) through 5 (E), or 0 for an unknown grade
+func GradeValue(grade string) int {
+	for i, g := range Grades {
+		if g == grade {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// points counts the thresholds a value exceeds
+func points(value float64, thresholds []float64) int {
+	for i, threshold := range thresholds {
+		if value <= threshold {
+			return i
+		}
+	}
+	return len(thresholds)
+}
+
+// ratioPoints scores the share of fat that is saturated; its bands include the lower bound
+func ratioPoints(ratio float64) int {
+	for i, threshold := range saturatedFatRatio {
+		if ratio < threshold {
+			return i
+		}
+	}
+	return len(saturatedFatRatio)
+}
+
+// grade maps a score to a grade with the category's boundaries
+func grade(category string, score int) string {
+	var bounds [4]int
+	switch category {
+	case CategoryBeverage:
+		// Grade A is reserved for water
+		bounds = [4]int{math.MinInt, 2, 6, 9}
+	case CategoryFat:
+		bounds = [4]int{-6, 2, 10, 18}
+	default:
+		bounds = [4]int{0, 2, 10, 18}
+	}
+	for i, bound := range bounds {
+		if score <= bound {
+			return Grades[i]
+		}
+	}
+	return GradeE
+}
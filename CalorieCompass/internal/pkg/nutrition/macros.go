@@ -0,0 +1,34 @@
+package nutrition
+
+import "math"
+
+// Atwater energy factors in kcal per gram
+const (
+	KcalPerGramCarbs   = 4.0
+	KcalPerGramProtein = 4.0
+	KcalPerGramFat     = 9.0
+)
+
+// MacroCalories is the energy of the given grams of carbs, protein and fat
+func MacroCalories(carbs, protein, fat float64) float64 {
+	return carbs*KcalPerGramCarbs + protein*KcalPerGramProtein + fat*KcalPerGramFat
+}
+
+// MacroGrams converts a share of calories in percent to grams of a macronutrient with the
+// given energy factor
+func MacroGrams(calories, percent, kcalPerGram float64) float64 {
+	return calories * percent / 100 / kcalPerGram
+}
+
+// MacroPercent is the share of calories in percent that grams of a macronutrient provide
+func MacroPercent(calories, grams, kcalPerGram float64) float64 {
+	if calories <= 0 {
+		return 0
+	}
+	return grams * kcalPerGram / calories * 100
+}
+
+// NetCarbs is total carbs minus fiber, never negative
+func NetCarbs(carbs, fiber float64) float64 {
+	return math.Max(0, carbs-fiber)
+}
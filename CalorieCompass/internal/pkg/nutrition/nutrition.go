@@ -0,0 +1,181 @@
+package nutrition
+
+import (
+	"strings"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/units"
+)
+
+// Thresholds for the dietary tags, per 100 g (liquids per 100 ml), following the
+// EU nutrition claims regulation where one exists
+const (
+	HighProteinEnergyShare = 0.20
+	HighFiberMin           = 6.0
+	LowCalorieMax          = 40.0
+	LowCalorieLiquidMax    = 20.0
+	LowCarbMax             = 10.0
+	LowFatMax              = 3.0
+	LowFatLiquidMax        = 1.5
+	LowSugarMax            = 5.0
+	LowSugarLiquidMax      = 2.5
+	LowSodiumMax           = 120.0
+)
+
+// Tags lists every supported dietary tag
+var Tags = []string{
+	entity.TagHighProtein,
+	entity.TagHighFiber,
+	entity.TagLowCalorie,
+	entity.TagLowCarb,
+	entity.TagLowFat,
+	entity.TagLowSugar,
+	entity.TagLowSodium,
+}
+
+// IsTag reports whether tag is a supported dietary tag
+func IsTag(tag string) bool {
+	for _, t := range Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Per100 scales a serving to 100 g (or 100 ml for volume servings). It reports false when
+// the serving has no usable metric amount.
+func Per100(serving entity.Serving) (entity.Serving, bool) {
+	unit, err := units.Parse(serving.MetricServingUnit)
+	if err != nil || serving.MetricServingAmount <= 0 {
+		return entity.Serving{}, false
+	}
+
+	base := serving.MetricServingAmount * unit.Factor
+	per100 := units.MultiplyServing(serving, 100/base)
+	per100.MetricServingAmount = 100
+	per100.MetricServingUnit = "g"
+	if unit.Kind == units.Volume {
+		per100.MetricServingUnit = "ml"
+	}
+	return per100, true
+}
+
+// DetailsPer100 returns the per-100 g/ml values of the first serving with a metric amount
+func DetailsPer100(details entity.FoodDetails) (entity.Serving, bool) {
+	for _, serving := range details.Servings {
+		if per100, ok := Per100(serving); ok {
+			return per100, true
+		}
+	}
+	return entity.Serving{}, false
+}
+
+// ProteinDensity is the share of energy that comes from protein (0-1); it does not depend
+// on the serving size
+func ProteinDensity(calories, protein float64) float64 {
+	if calories <= 0 {
+		return 0
+	}
+	return protein * 4 / calories
+}
+
+// Daily values the nutrient density index is measured against: protein and fiber are
+// encouraged, saturated fat, sugar (g) and sodium (mg) limited
+const (
+	DensityProteinDV      = 50.0
+	DensityFiberDV        = 28.0
+	DensitySaturatedFatDV = 20.0
+	DensitySugarDV        = 50.0
+	DensitySodiumDV       = 2300.0
+)
+
+// NutrientDensity is a Nutrient Rich Foods style index over the nutrients servings carry:
+// the percent daily values of protein and fiber in 100 kcal, each capped at 100, minus
+// those of saturated fat, sugar and sodium. It reports false for a serving without energy.
+func NutrientDensity(serving entity.Serving) (float64, bool) {
+	if serving.Calories <= 0 {
+		return 0, false
+	}
+	per100kcal := 100 / serving.Calories
+	share := func(amount, dailyValue float64) float64 {
+		return amount * per100kcal / dailyValue * 100
+	}
+	encouraged := min(share(serving.Protein, DensityProteinDV), 100) + min(share(serving.Fiber, DensityFiberDV), 100)
+	limited := share(serving.SaturatedFat, DensitySaturatedFatDV) + share(serving.Sugar, DensitySugarDV) +
+		share(serving.Sodium, DensitySodiumDV)
+	return encouraged - limited, true
+}
+
+// HasTag reports whether per-100 g/ml values qualify for a dietary tag
+func HasTag(per100 entity.Serving, tag string) bool {
+	liquid := per100.MetricServingUnit == "ml"
+	switch tag {
+	case entity.TagHighProtein:
+		return per100.Calories > 0 && ProteinDensity(per100.Calories, per100.Protein) >= HighProteinEnergyShare
+	case entity.TagHighFiber:
+		return per100.Fiber >= HighFiberMin
+	case entity.TagLowCalorie:
+		return per100.Calories <= pick(liquid, LowCalorieLiquidMax, LowCalorieMax)
+	case entity.TagLowCarb:
+		return per100.Carbs <= LowCarbMax
+	case entity.TagLowFat:
+		return per100.Fat <= pick(liquid, LowFatLiquidMax, LowFatMax)
+	case entity.TagLowSugar:
+		return per100.Sugar <= pick(liquid, LowSugarLiquidMax, LowSugarMax)
+	case entity.TagLowSodium:
+		return per100.Sodium <= LowSodiumMax
+	}
+	return false
+}
+
+// MatchesType reports whether a food's FatSecret-style type ("Brand"/"Generic") matches the filter
+func MatchesType(food entity.Food, filterType string) bool {
+	return filterType == "" || strings.EqualFold(food.Type, filterType)
+}
+
+// Matches reports whether per-100 g/ml values satisfy every nutrient range and tag in filters
+func Matches(filters entity.FoodSearchFilters, per100 entity.Serving) bool {
+	if !filters.Calories.Contains(per100.Calories) ||
+		!filters.Protein.Contains(per100.Protein) ||
+		!filters.Carbs.Contains(per100.Carbs) ||
+		!filters.Fat.Contains(per100.Fat) {
+		return false
+	}
+	for _, tag := range filters.Tags {
+		if !HasTag(per100, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func pick(liquid bool, liquidValue, solidValue float64) float64 {
+	if liquid {
+		return liquidValue
+	}
+	return solidValue
+}
+
+// Sum adds up the nutrients of several servings, e.g. the entries of a meal or a day
+func Sum(servings ...entity.Serving) entity.Serving {
+	var total entity.Serving
+	for _, s := range servings {
+		total.Calories += s.Calories
+		total.Carbs += s.Carbs
+		total.Protein += s.Protein
+		total.Fat += s.Fat
+		total.SaturatedFat += s.SaturatedFat
+		total.Fiber += s.Fiber
+		total.Cholesterol += s.Cholesterol
+		total.Sodium += s.Sodium
+		total.Sugar += s.Sugar
+		total.Calcium += s.Calcium
+		total.Iron += s.Iron
+		total.Potassium += s.Potassium
+		total.VitaminA += s.VitaminA
+		total.VitaminC += s.VitaminC
+		total.VitaminD += s.VitaminD
+	}
+	return total
+}
@@ -0,0 +1,295 @@
+package restriction
+
+import (
+	"strings"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/textmatch"
+)
+
+// rule matches a restriction in a food's text. Keywords are words or phrases that indicate
+// it, also in plurals ending in "s" or "es". A keyword does not count inside an exception,
+// e.g. "milk" in "coconut milk(s)", or when followed by "free". A label, e.g. "gluten free",
+// only clears the ambiguous keywords that name foods also made without the restriction,
+// e.g. "bread" but not "wheat", and does not count after "not" or "non".
+type rule struct {
+	keywords   [][]string
+	exceptions [][]string
+	labels     [][]string
+	ambiguous  []string
+	// allergens are the allergens whose declared presence also rules a diet out
+	allergens []string
+}
+
+// Curated keyword lists; they favour flagging a doubtful food over missing an allergen
+var (
+	meatWords = []string{
+		"beef", "pork", "chicken", "turkey", "lamb", "mutton", "veal", "ham", "bacon", "sausage", "salami",
+		"pepperoni", "prosciutto", "pancetta", "chorizo", "duck", "goose", "venison", "rabbit", "meat",
+		"meatball", "steak", "mince", "lard", "tallow", "suet", "gelatin", "gelatine", "jerky", "liver",
+		"bone broth", "rennet", "carmine", "cochineal",
+	}
+	fishWords = []string{
+		"fish", "salmon", "tuna", "cod", "haddock", "trout", "sardine", "anchovy", "anchovies", "mackerel",
+		"herring", "tilapia", "pollock", "halibut", "hake", "bonito", "caviar", "roe", "dashi",
+		"worcestershire",
+	}
+	crustaceanWords = []string{
+		"shrimp", "prawn", "crab", "lobster", "crayfish", "langoustine", "krill", "scampi", "shellfish",
+	}
+	molluscWords = []string{
+		"mussel", "clam", "oyster", "scallop", "squid", "calamari", "octopus", "snail", "escargot",
+		"cuttlefish", "whelk", "abalone", "shellfish",
+	}
+	glutenWords = []string{
+		"gluten", "wheat", "barley", "rye", "oat", "spelt", "kamut", "triticale", "semolina", "durum",
+		"couscous", "bulgur", "bulgar", "farro", "freekeh", "seitan", "malt", "flour", "bread",
+		"breadcrumb", "pasta", "spaghetti", "macaroni", "noodle", "cracker", "biscuit", "croissant",
+		"bagel", "baguette", "pizza", "pastry", "brioche", "crouton", "beer",
+	}
+	eggWords = []string{
+		"egg", "albumen", "albumin", "ovalbumin", "lysozyme", "mayonnaise", "mayo", "meringue", "yolk",
+		"custard", "aioli", "eggnog",
+	}
+	lactoseWords = []string{
+		"milk", "buttermilk", "cream", "yogurt", "yoghurt", "whey", "lactose", "kefir", "curd", "cheese",
+		"ricotta", "mozzarella", "paneer", "mascarpone", "custard", "skyr", "quark", "dairy",
+	}
+	milkWords = append([]string{
+		"butter", "casein", "caseinate", "ghee", "cheddar", "parmesan", "brie", "feta", "gouda",
+		"lactalbumin",
+	}, lactoseWords...)
+	plantMilks = []string{
+		"coconut milk", "almond milk", "soy milk", "soya milk", "oat milk", "rice milk", "cashew milk",
+		"coconut cream", "peanut butter", "almond butter", "cashew butter", "nut butter", "cocoa butter",
+		"shea butter", "apple butter", "cream of tartar", "vegan cheese", "soy yogurt", "coconut yogurt",
+		"bean curd",
+	}
+	treeNutWords = []string{
+		"nut", "almond", "hazelnut", "walnut", "cashew", "pecan", "pistachio", "macadamia", "brazil nut",
+		"praline", "marzipan", "gianduja", "nutella",
+	}
+	alcoholWords = []string{
+		"wine", "beer", "rum", "vodka", "whisky", "whiskey", "brandy", "liqueur", "alcohol", "sake", "mirin",
+		"cider",
+	}
+
+	// Keywords naming foods that are also sold made without the restriction, which a label
+	// such as "gluten free" or "vegetarian" in the same text clears
+	glutenFreeFoods = []string{
+		"flour", "bread", "breadcrumb", "pasta", "spaghetti", "macaroni", "noodle", "cracker", "biscuit",
+		"croissant", "bagel", "baguette", "pizza", "pastry", "brioche", "crouton", "beer", "oat",
+	}
+	dairyFreeFoods = []string{
+		"milk", "cream", "yogurt", "yoghurt", "cheese", "butter", "kefir", "dairy",
+	}
+	meatSubstitutes = []string{
+		"meat", "meatball", "mince", "sausage", "bacon", "jerky", "steak", "salami", "pepperoni",
+	}
+)
+
+var allergenRules = map[string]rule{
+	entity.AllergenGluten: withLabels(newRule(glutenWords, []string{
+		"rice flour", "almond flour", "coconut flour", "corn flour", "chickpea flour", "buckwheat flour",
+		"tapioca flour", "potato flour", "rice noodle", "rice pasta", "rice cracker", "root beer",
+		"ginger beer",
+	}), []string{"gluten free"}, glutenFreeFoods),
+	entity.AllergenCrustaceans: newRule(crustaceanWords, nil),
+	entity.AllergenEggs: withLabels(newRule(eggWords, nil), []string{"egg free"},
+		[]string{"mayonnaise", "mayo", "meringue", "custard", "aioli", "eggnog"}),
+	entity.AllergenFish:    newRule(fishWords, nil),
+	entity.AllergenPeanuts: newRule([]string{"peanut", "groundnut", "arachis"}, nil),
+	entity.AllergenSoybeans: newRule([]string{
+		"soy", "soya", "soybean", "tofu", "tempeh", "edamame", "miso", "tamari",
+	}, nil),
+	entity.AllergenMilk:     withLabels(newRule(milkWords, plantMilks), []string{"dairy free", "milk free"}, dairyFreeFoods),
+	entity.AllergenTreeNuts: newRule(treeNutWords, []string{"pine nut", "tiger nut"}),
+	entity.AllergenCelery:   newRule([]string{"celery", "celeriac"}, nil),
+	entity.AllergenMustard:  newRule([]string{"mustard"}, nil),
+	entity.AllergenSesame:   newRule([]string{"sesame", "tahini", "tahina", "halva", "halvah", "gomasio"}, nil),
+	entity.AllergenSulphites: newRule([]string{
+		"sulphite", "sulfite", "bisulphite", "bisulfite", "metabisulphite", "metabisulfite",
+		"sulphur dioxide", "sulfur dioxide", "e220", "e221", "e222", "e223", "e224", "e225", "e226",
+		"e227", "e228", "wine",
+	}, nil),
+	entity.AllergenLupin:    newRule([]string{"lupin", "lupine", "lupini"}, nil),
+	entity.AllergenMolluscs: newRule(molluscWords, []string{"oyster mushroom"}),
+}
+
+var dietRules = map[string]rule{
+	entity.DietVegetarian: withAllergens(withLabels(newRule(
+		concat(meatWords, fishWords, crustaceanWords, molluscWords),
+		[]string{"coconut meat", "oyster mushroom"},
+	), []string{"vegetarian", "vegan", "veggie", "meatless", "meat free", "plant based"}, meatSubstitutes,
+	), entity.AllergenFish, entity.AllergenCrustaceans, entity.AllergenMolluscs),
+	entity.DietVegan: withAllergens(withLabels(newRule(
+		concat(meatWords, fishWords, crustaceanWords, molluscWords, eggWords, milkWords,
+			[]string{"honey", "beeswax", "shellac", "royal jelly", "propolis", "lanolin"}),
+		concat([]string{"coconut meat", "oyster mushroom"}, plantMilks),
+	), []string{"vegan", "plant based"}, concat(meatSubstitutes, dairyFreeFoods,
+		[]string{"mayonnaise", "mayo", "meringue", "custard", "aioli", "honey"}),
+	), entity.AllergenFish, entity.AllergenCrustaceans, entity.AllergenMolluscs, entity.AllergenMilk,
+		entity.AllergenEggs),
+	entity.DietHalal: withLabels(newRule(
+		concat([]string{
+			"pork", "bacon", "ham", "lard", "gelatin", "gelatine", "prosciutto", "pancetta", "chorizo",
+			"salami", "pepperoni", "pork rind",
+		}, alcoholWords),
+		[]string{
+			"turkey bacon", "turkey ham", "beef bacon", "chicken ham", "beef salami", "beef pepperoni",
+			"root beer", "ginger beer", "alcohol free beer", "alcohol free wine", "non alcoholic beer",
+			"non alcoholic wine", "wine vinegar",
+		},
+	), []string{"halal"}, []string{"gelatin", "gelatine", "chorizo", "salami", "pepperoni"}),
+	entity.DietGlutenFree: withAllergens(allergenRules[entity.AllergenGluten], entity.AllergenGluten),
+	entity.DietLactoseFree: withLabels(newRule(lactoseWords, plantMilks),
+		[]string{"lactose free", "dairy free", "milk free"}, dairyFreeFoods),
+}
+
+// Check flags a food for each restriction of the profile that it conflicts with. Declared
+// attributes come first: a food declared free of an allergen or suitable for a diet is not
+// checked any further for it. Otherwise the ingredients and then the name are matched
+// against curated keywords.
+func Check(profile entity.RestrictionProfile, name, ingredients string, attributes *entity.FoodAttributes) []entity.FoodFlag {
+	if !profile.Active() {
+		return nil
+	}
+	if attributes == nil {
+		attributes = &entity.FoodAttributes{}
+	}
+	texts := []struct {
+		source string
+		tokens []string
+	}{
+		{entity.FlagSourceIngredients, textmatch.Tokenize(ingredients)},
+		{entity.FlagSourceName, textmatch.Tokenize(name)},
+	}
+
+	var flags []entity.FoodFlag
+	check := func(code, kind string, r rule, declared, cleared bool) {
+		if declared {
+			flags = append(flags, entity.FoodFlag{Restriction: code, Kind: kind, Source: entity.FlagSourceAttribute})
+			return
+		}
+		if cleared {
+			return
+		}
+		for _, text := range texts {
+			if match, ok := r.match(text.tokens); ok {
+				flags = append(flags, entity.FoodFlag{Restriction: code, Kind: kind, Source: text.source, Match: match})
+				return
+			}
+		}
+	}
+
+	for _, code := range profile.Allergens {
+		r, ok := allergenRules[code]
+		if !ok {
+			continue
+		}
+		check(code, entity.RestrictionAllergen, r, contains(attributes.Allergens, code), contains(attributes.FreeFrom, code))
+	}
+	for _, code := range profile.Diets {
+		r, ok := dietRules[code]
+		if !ok {
+			continue
+		}
+		declared := contains(attributes.UnsuitableDiets, code)
+		for _, allergen := range r.allergens {
+			declared = declared || contains(attributes.Allergens, allergen)
+		}
+		if code == entity.DietVegan {
+			declared = declared || contains(attributes.UnsuitableDiets, entity.DietVegetarian)
+		}
+		check(code, entity.RestrictionDiet, r, declared, contains(attributes.SuitableDiets, code))
+	}
+	return flags
+}
+
+// match returns the first keyword found in the tokens outside the exceptions
+func (r rule) match(tokens []string) (string, bool) {
+	if len(tokens) == 0 {
+		return "", false
+	}
+	labelled := false
+	for _, label := range r.labels {
+		for _, start := range textmatch.Find(tokens, label, false) {
+			labelled = labelled || !negated(tokens, start)
+		}
+	}
+
+	excluded := make([]bool, len(tokens))
+	for _, exception := range r.exceptions {
+		for _, start := range textmatch.Find(tokens, exception, true) {
+			for i := start; i < start+len(exception); i++ {
+				excluded[i] = true
+			}
+		}
+	}
+
+	for _, keyword := range r.keywords {
+		if labelled && contains(r.ambiguous, strings.Join(keyword, " ")) {
+			continue
+		}
+	occurrences:
+		for _, start := range textmatch.Find(tokens, keyword, true) {
+			end := start + len(keyword)
+			for i := start; i < end; i++ {
+				if excluded[i] {
+					continue occurrences
+				}
+			}
+			if textmatch.FreeOf(tokens, end) && !negated(tokens, start) {
+				continue
+			}
+			return strings.Join(tokens[start:end], " "), true
+		}
+	}
+	return "", false
+}
+
+// negated reports whether the words starting at start follow "not" or "non", as in "not
+// gluten free" or "non-vegetarian"
+func negated(tokens []string, start int) bool {
+	return start > 0 && (tokens[start-1] == "not" || tokens[start-1] == "non")
+}
+
+func newRule(keywords, exceptions []string) rule {
+	return rule{keywords: phrases(keywords), exceptions: phrases(exceptions)}
+}
+
+func withLabels(r rule, labels, ambiguous []string) rule {
+	r.labels = phrases(labels)
+	r.ambiguous = ambiguous
+	return r
+}
+
+func withAllergens(r rule, allergens ...string) rule {
+	r.allergens = allergens
+	return r
+}
+
+func phrases(values []string) [][]string {
+	result := make([][]string, 0, len(values))
+	for _, value := range values {
+		result = append(result, textmatch.Tokenize(value))
+	}
+	return result
+}
+
+func concat(lists ...[]string) []string {
+	var result []string
+	for _, list := range lists {
+		result = append(result, list...)
+	}
+	return result
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
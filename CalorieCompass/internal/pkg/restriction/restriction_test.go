@@ -0,0 +1,87 @@
+package restriction
+
+import (
+	"testing"
+
+	"CalorieCompass/internal/entity"
+)
+
+func TestCheckLabels(t *testing.T) {
+	tests := []struct {
+		name        string
+		profile     entity.RestrictionProfile
+		food        string
+		ingredients string
+		want        string
+		source      string
+	}{
+		{
+			name:    "negated diet label",
+			profile: entity.RestrictionProfile{Diets: []string{entity.DietVegetarian}},
+			food:    "Non-vegetarian chicken curry",
+			want:    entity.DietVegetarian,
+			source:  entity.FlagSourceName,
+		},
+		{
+			name:        "negated allergen label",
+			profile:     entity.RestrictionProfile{Allergens: []string{entity.AllergenTreeNuts}},
+			food:        "Trail mix",
+			ingredients: "almonds, butter, raisins. Not nut free",
+			want:        entity.AllergenTreeNuts,
+			source:      entity.FlagSourceIngredients,
+		},
+		{
+			name:        "negated free-from label",
+			profile:     entity.RestrictionProfile{Allergens: []string{entity.AllergenGluten}},
+			food:        "Cookies",
+			ingredients: "wheat flour, sugar, butter (not gluten free)",
+			want:        entity.AllergenGluten,
+			source:      entity.FlagSourceIngredients,
+		},
+		{
+			name:    "label beside an explicit keyword",
+			profile: entity.RestrictionProfile{Diets: []string{entity.DietVegetarian}},
+			food:    "Chicken & veggie stir fry",
+			want:    entity.DietVegetarian,
+			source:  entity.FlagSourceName,
+		},
+		{
+			name:        "label beside an explicit ingredient",
+			profile:     entity.RestrictionProfile{Allergens: []string{entity.AllergenGluten}},
+			food:        "Gluten free bread",
+			ingredients: "wheat starch, water, yeast",
+			want:        entity.AllergenGluten,
+			source:      entity.FlagSourceIngredients,
+		},
+		{
+			name:    "label clears an ambiguous keyword",
+			profile: entity.RestrictionProfile{Allergens: []string{entity.AllergenGluten}},
+			food:    "Gluten free bread",
+		},
+		{
+			name:    "diet label clears a meat substitute",
+			profile: entity.RestrictionProfile{Diets: []string{entity.DietVegetarian}},
+			food:    "Vegetarian sausages",
+		},
+		{
+			name:    "keyword followed by free",
+			profile: entity.RestrictionProfile{Allergens: []string{entity.AllergenPeanuts}},
+			food:    "Peanut-free granola bar",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := Check(tt.profile, tt.food, tt.ingredients, nil)
+			if tt.want == "" {
+				if len(flags) > 0 {
+					t.Fatalf("got flags %+v, want none", flags)
+				}
+				return
+			}
+			if len(flags) != 1 || flags[0].Restriction != tt.want || flags[0].Source != tt.source {
+				t.Fatalf("got flags %+v, want %s from %s", flags, tt.want, tt.source)
+			}
+		})
+	}
+}
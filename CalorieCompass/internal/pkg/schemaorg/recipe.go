@@ -0,0 +1,196 @@
+package schemaorg
+
+import (
+	"encoding/json"
+	"errors"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrNoRecipe is returned when content contains no schema.org Recipe
+var ErrNoRecipe = errors.New("no schema.org Recipe found")
+
+// Recipe holds the fields of a schema.org Recipe that are needed to draft a user recipe
+type Recipe struct {
+	Name         string
+	Yield        string
+	Ingredients  []string
+	Instructions []string
+}
+
+var (
+	ldJSONScript = regexp.MustCompile(`(?is)<script[^>]*type\s*=\s*["']?application/ld\+json["']?[^>]*>(.*?)</script>`)
+	blockTag     = regexp.MustCompile(`(?i)<(br|/p|/li|/div|/h[1-6])[^>]*>`)
+	htmlTag      = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// ExtractRecipe finds a Recipe in JSON-LD or in the ld+json script blocks of an HTML page.
+// Recipes nested in arrays or an @graph are found as well.
+func ExtractRecipe(content string) (*Recipe, error) {
+	content = strings.TrimSpace(content)
+
+	var blocks []string
+	if strings.HasPrefix(content, "{") || strings.HasPrefix(content, "[") {
+		blocks = append(blocks, content)
+	} else {
+		for _, match := range ldJSONScript.FindAllStringSubmatch(content, -1) {
+			blocks = append(blocks, match[1])
+		}
+	}
+
+	for _, block := range blocks {
+		var document interface{}
+		if err := json.Unmarshal([]byte(strings.TrimSpace(block)), &document); err != nil {
+			continue
+		}
+		if node := findRecipe(document); node != nil {
+			return toRecipe(node), nil
+		}
+	}
+
+	return nil, ErrNoRecipe
+}
+
+// findRecipe walks a JSON-LD document depth-first for a node whose @type includes Recipe
+func findRecipe(value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if hasType(v["@type"], "Recipe") {
+			return v
+		}
+		for _, key := range []string{"@graph", "mainEntity", "mainEntityOfPage", "itemListElement"} {
+			if found := findRecipe(v[key]); found != nil {
+				return found
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if found := findRecipe(item); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+func hasType(value interface{}, want string) bool {
+	switch v := value.(type) {
+	case string:
+		return strings.EqualFold(v, want) || strings.HasSuffix(v, "/"+want)
+	case []interface{}:
+		for _, item := range v {
+			if hasType(item, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func toRecipe(node map[string]interface{}) *Recipe {
+	recipe := &Recipe{
+		Name:  text(node["name"]),
+		Yield: firstText(node["recipeYield"]),
+	}
+
+	ingredients := node["recipeIngredient"]
+	if ingredients == nil {
+		// Deprecated property still used by older sites
+		ingredients = node["ingredients"]
+	}
+	recipe.Ingredients = texts(ingredients)
+	recipe.Instructions = instructions(node["recipeInstructions"])
+
+	return recipe
+}
+
+// instructions flattens a string, a list of strings, HowToStep objects and HowToSection
+// objects into a list of steps
+func instructions(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		var steps []string
+		for _, line := range strings.Split(clean(v), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				steps = append(steps, line)
+			}
+		}
+		return steps
+	case []interface{}:
+		var steps []string
+		for _, item := range v {
+			steps = append(steps, instructions(item)...)
+		}
+		return steps
+	case map[string]interface{}:
+		if elements, ok := v["itemListElement"]; ok {
+			return instructions(elements)
+		}
+		if step := text(v["text"]); step != "" {
+			return []string{step}
+		}
+		if step := text(v["name"]); step != "" {
+			return []string{step}
+		}
+	}
+	return nil
+}
+
+func texts(value interface{}) []string {
+	var result []string
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if s := text(item); s != "" {
+				result = append(result, s)
+			}
+		}
+	default:
+		for _, line := range strings.Split(text(v), "\n") {
+			if line != "" {
+				result = append(result, line)
+			}
+		}
+	}
+	return result
+}
+
+// firstText returns the first non-empty text of a value that may be a list, e.g. recipeYield
+// given as ["4", "4 servings"]
+func firstText(value interface{}) string {
+	if list, ok := value.([]interface{}); ok {
+		for _, item := range list {
+			if s := text(item); s != "" {
+				return s
+			}
+		}
+		return ""
+	}
+	return text(value)
+}
+
+func text(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return clean(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]interface{}:
+		return text(v["text"])
+	}
+	return ""
+}
+
+// clean strips HTML tags and entities that sites leave in JSON-LD strings
+func clean(value string) string {
+	value = blockTag.ReplaceAllString(value, "\n")
+	value = htmlTag.ReplaceAllString(value, "")
+	value = html.UnescapeString(value)
+	lines := strings.Split(value, "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,41 @@
+package textmatch
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tokenize lowercases text and splits it into words, so that "Gluten-free" and "gluten
+// free" read the same
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Find returns the positions where a phrase starts in the tokens. With plural set, its last
+// word may also carry an "s" or "es" ending.
+func Find(tokens, phrase []string, plural bool) []int {
+	var starts []int
+	for start := 0; start+len(phrase) <= len(tokens); start++ {
+		matched := true
+		for i, word := range phrase {
+			token := tokens[start+i]
+			if token == word || (plural && i == len(phrase)-1 && (token == word+"s" || token == word+"es")) {
+				continue
+			}
+			matched = false
+			break
+		}
+		if matched {
+			starts = append(starts, start)
+		}
+	}
+	return starts
+}
+
+// FreeOf reports whether the words ending at end are followed by "free", as in "sugar free"
+// or "gluten-free", so that they name what the food lacks
+func FreeOf(tokens []string, end int) bool {
+	return end < len(tokens) && tokens[end] == "free"
+}
@@ -0,0 +1,123 @@
+package units
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"CalorieCompass/internal/entity"
+)
+
+// ScaleServing returns a copy of serving with every nutrient scaled to the given amount
+// of unit, using the serving's metric amount as the reference. Density (g/ml) is used for
+// mass/volume conversions and may be 0 when unknown.
+func ScaleServing(serving entity.Serving, amount float64, unit Unit, density float64) (entity.Serving, error) {
+	base, err := Parse(serving.MetricServingUnit)
+	if err != nil {
+		return entity.Serving{}, fmt.Errorf("serving %s: %w", serving.ID, err)
+	}
+	if serving.MetricServingAmount <= 0 {
+		return entity.Serving{}, fmt.Errorf("serving %s has no metric amount", serving.ID)
+	}
+
+	converted, err := Convert(amount, unit, base, density)
+	if err != nil {
+		return entity.Serving{}, err
+	}
+
+	scaled := MultiplyServing(serving, converted/serving.MetricServingAmount)
+	scaled.Description = fmt.Sprintf("%s %s", strconv.FormatFloat(amount, 'f', -1, 64), unit.Name)
+	scaled.MeasurementDescription = unit.Name
+	scaled.NumberOfUnits = amount
+	scaled.URL = ""
+	return scaled, nil
+}
+
+// MultiplyServing returns a copy of serving with its metric amount and nutrients multiplied by factor
+func MultiplyServing(serving entity.Serving, factor float64) entity.Serving {
+	serving.MetricServingAmount *= factor
+	serving.NumberOfUnits *= factor
+	serving.Calories *= factor
+	serving.Carbs *= factor
+	serving.Protein *= factor
+	serving.Fat *= factor
+	serving.SaturatedFat *= factor
+	serving.Fiber *= factor
+	serving.Cholesterol *= factor
+	serving.Sodium *= factor
+	serving.Sugar *= factor
+	serving.Calcium *= factor
+	serving.Iron *= factor
+	serving.Potassium *= factor
+	serving.VitaminA *= factor
+	serving.VitaminC *= factor
+	serving.VitaminD *= factor
+	return serving
+}
+
+// Density derives a food's density in g/ml from servings that pair a household volume
+// measure (e.g. "cup") with a metric mass, or a mass measure with a metric volume.
+// It returns 0 when no serving carries enough information.
+func Density(servings []entity.Serving) float64 {
+	for _, serving := range servings {
+		if serving.MetricServingAmount <= 0 || serving.NumberOfUnits <= 0 {
+			continue
+		}
+		metric, err := Parse(serving.MetricServingUnit)
+		if err != nil {
+			continue
+		}
+		measure, err := Parse(measurementUnit(serving.MeasurementDescription))
+		if err != nil || measure.Kind == metric.Kind {
+			continue
+		}
+
+		metricBase := serving.MetricServingAmount * metric.Factor
+		measureBase := serving.NumberOfUnits * measure.Factor
+		if metric.Kind == Mass {
+			return metricBase / measureBase
+		}
+		return measureBase / metricBase
+	}
+	return 0
+}
+
+// FindServing picks the serving to scale from: the one with servingID if given, otherwise
+// the first serving whose metric unit can reach unit (directly or through density).
+func FindServing(servings []entity.Serving, servingID string, unit Unit, density float64) (entity.Serving, error) {
+	for _, serving := range servings {
+		if servingID != "" {
+			if serving.ID == servingID {
+				return serving, nil
+			}
+			continue
+		}
+		base, err := Parse(serving.MetricServingUnit)
+		if err != nil || serving.MetricServingAmount <= 0 {
+			continue
+		}
+		if base.Kind == unit.Kind || density > 0 {
+			return serving, nil
+		}
+	}
+	if servingID != "" {
+		return entity.Serving{}, fmt.Errorf("serving %s not found", servingID)
+	}
+	return entity.Serving{}, fmt.Errorf("%w: no serving can be expressed in %s", ErrIncompatibleUnits, unit.Name)
+}
+
+// IsMeasured reports whether a serving is measured in a mass or volume unit ("1 cup",
+// "100 g") rather than counted ("1 large", "2 slices")
+func IsMeasured(serving entity.Serving) bool {
+	_, err := Parse(measurementUnit(serving.MeasurementDescription))
+	return err == nil
+}
+
+// measurementUnit strips qualifiers such as "cup, chopped" or "tbsp (15 ml)" down to the unit name
+func measurementUnit(description string) string {
+	description = strings.ToLower(description)
+	if i := strings.IndexAny(description, ",("); i >= 0 {
+		description = description[:i]
+	}
+	return strings.TrimSpace(description)
+}
@@ -0,0 +1,93 @@
+package units
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Kind is the physical dimension a unit measures
+type Kind string
+
+const (
+	Mass   Kind = "mass"
+	Volume Kind = "volume"
+)
+
+var (
+	// ErrUnknownUnit is returned when a unit name is not recognised
+	ErrUnknownUnit = errors.New("unknown unit")
+	// ErrIncompatibleUnits is returned when converting between mass and volume without a density
+	ErrIncompatibleUnits = errors.New("incompatible units")
+)
+
+// Unit is a measurement unit expressed relative to its base unit (g for mass, ml for volume)
+type Unit struct {
+	Name   string
+	Kind   Kind
+	Factor float64
+}
+
+var (
+	Gram        = Unit{Name: "g", Kind: Mass, Factor: 1}
+	Kilogram    = Unit{Name: "kg", Kind: Mass, Factor: 1000}
+	Ounce       = Unit{Name: "oz", Kind: Mass, Factor: 28.349523125}
+	Pound       = Unit{Name: "lb", Kind: Mass, Factor: 453.59237}
+	Milliliter  = Unit{Name: "ml", Kind: Volume, Factor: 1}
+	Liter       = Unit{Name: "l", Kind: Volume, Factor: 1000}
+	Teaspoon    = Unit{Name: "tsp", Kind: Volume, Factor: 4.92892159375}
+	Tablespoon  = Unit{Name: "tbsp", Kind: Volume, Factor: 14.78676478125}
+	Cup         = Unit{Name: "cup", Kind: Volume, Factor: 236.5882365}
+	FluidOunce  = Unit{Name: "fl oz", Kind: Volume, Factor: 29.5735295625}
+	aliases     = map[string]Unit{}
+	aliasByUnit = map[Unit][]string{
+		Gram:       {"g", "gr", "gram", "grams", "gramme", "grammes"},
+		Kilogram:   {"kg", "kilo", "kilos", "kilogram", "kilograms"},
+		Ounce:      {"oz", "ounce", "ounces"},
+		Pound:      {"lb", "lbs", "pound", "pounds"},
+		Milliliter: {"ml", "mls", "milliliter", "milliliters", "millilitre", "millilitres"},
+		Liter:      {"l", "liter", "liters", "litre", "litres"},
+		Teaspoon:   {"tsp", "tsps", "teaspoon", "teaspoons", "t"},
+		Tablespoon: {"tbsp", "tbsps", "tbs", "tablespoon", "tablespoons", "T"},
+		Cup:        {"cup", "cups", "c"},
+		FluidOunce: {"fl oz", "floz", "fl. oz", "fl.oz", "fluid ounce", "fluid ounces"},
+	}
+)
+
+func init() {
+	for unit, names := range aliasByUnit {
+		for _, name := range names {
+			aliases[name] = unit
+		}
+	}
+}
+
+// Parse resolves a unit name or alias such as "grams", "Tbsp" or "fl oz"
+func Parse(name string) (Unit, error) {
+	trimmed := strings.Join(strings.Fields(name), " ")
+	// "T" and "t" are the only case-sensitive aliases (tablespoon vs teaspoon)
+	if unit, ok := aliases[trimmed]; ok {
+		return unit, nil
+	}
+	if unit, ok := aliases[strings.TrimSuffix(strings.ToLower(trimmed), ".")]; ok {
+		return unit, nil
+	}
+	return Unit{}, fmt.Errorf("%w: %q", ErrUnknownUnit, name)
+}
+
+// Convert converts amount from one unit to another. Density is in g/ml and is only
+// needed when converting between mass and volume; pass 0 when it is unknown.
+func Convert(amount float64, from, to Unit, density float64) (float64, error) {
+	base := amount * from.Factor
+	if from.Kind != to.Kind {
+		if density <= 0 {
+			return 0, fmt.Errorf("%w: %s to %s requires a known density", ErrIncompatibleUnits, from.Name, to.Name)
+		}
+		if from.Kind == Volume {
+			base *= density
+		} else {
+			base /= density
+		}
+	}
+	return base / to.Factor, nil
+}
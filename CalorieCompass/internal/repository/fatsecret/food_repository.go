@@ -5,6 +5,7 @@ import (
 	"CalorieCompass/internal/service"
 	"context"
 	"strconv"
+	"strings"
 )
 
 // FoodRepository implements the food data operations using the FatSecret API
@@ -20,8 +21,8 @@ func NewFoodRepository(service *service.FatSecretService) *FoodRepository {
 }
 
 // SearchFoods searches for foods in the FatSecret API
-func (r *FoodRepository) SearchFoods(ctx context.Context, query string, page, limit int) ([]entity.Food, int, error) {
-	response, err := r.service.SearchFoods(query, page, limit)
+func (r *FoodRepository) SearchFoods(ctx context.Context, request entity.FoodSearchRequest) ([]entity.Food, int, error) {
+	response, err := r.service.SearchFoods(ctx, request.Query, request.Page, request.Limit)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -59,7 +60,7 @@ func (r *FoodRepository) SearchFoods(ctx context.Context, query string, page, li
 
 // GetFoodDetails gets detailed information about a specific food
 func (r *FoodRepository) GetFoodDetails(ctx context.Context, foodID string) (*entity.FoodDetails, error) {
-	response, err := r.service.GetFoodDetails(foodID)
+	response, err := r.service.GetFoodDetails(ctx, foodID)
 	if err != nil {
 		return nil, err
 	}
@@ -79,6 +80,14 @@ func (r *FoodRepository) GetFoodDetails(ctx context.Context, foodID string) (*en
 			cholesterol, _ := strconv.ParseFloat(srv.Cholesterol, 64)
 			sodium, _ := strconv.ParseFloat(srv.Sodium, 64)
 			sugar, _ := strconv.ParseFloat(srv.Sugar, 64)
+			// food.get.v4 reports calcium, iron, potassium and vitamin C in mg and
+			// vitamins A and D in mcg, as Serving stores them
+			calcium, _ := strconv.ParseFloat(srv.Calcium, 64)
+			iron, _ := strconv.ParseFloat(srv.Iron, 64)
+			potassium, _ := strconv.ParseFloat(srv.Potassium, 64)
+			vitaminA, _ := strconv.ParseFloat(srv.VitaminA, 64)
+			vitaminC, _ := strconv.ParseFloat(srv.VitaminC, 64)
+			vitaminD, _ := strconv.ParseFloat(srv.VitaminD, 64)
 			metricAmount, _ := strconv.ParseFloat(srv.MetricServingAmount, 64)
 			numberOfUnits, _ := strconv.ParseFloat(srv.NumberOfUnits, 64)
 
@@ -99,14 +108,104 @@ func (r *FoodRepository) GetFoodDetails(ctx context.Context, foodID string) (*en
 				Cholesterol:            cholesterol,
 				Sodium:                 sodium,
 				Sugar:                  sugar,
+				Calcium:                calcium,
+				Iron:                   iron,
+				Potassium:              potassium,
+				VitaminA:               vitaminA,
+				VitaminC:               vitaminC,
+				VitaminD:               vitaminD,
 			})
 		}
 	}
 
 	return &entity.FoodDetails{
-		ID:        food.FoodID,
-		Name:      food.FoodName,
-		BrandName: food.BrandName,
-		Servings:  servings,
+		ID:         food.FoodID,
+		Name:       food.FoodName,
+		BrandName:  food.BrandName,
+		Servings:   servings,
+		Attributes: foodAttributes(response),
 	}, nil
 }
+
+// attributeAllergens maps FatSecret allergen names to the EU allergens they cover
+var attributeAllergens = map[string][]string{
+	"egg":       {entity.AllergenEggs},
+	"fish":      {entity.AllergenFish},
+	"gluten":    {entity.AllergenGluten},
+	"milk":      {entity.AllergenMilk},
+	"nuts":      {entity.AllergenTreeNuts},
+	"peanuts":   {entity.AllergenPeanuts},
+	"sesame":    {entity.AllergenSesame},
+	"shellfish": {entity.AllergenCrustaceans, entity.AllergenMolluscs},
+	"soy":       {entity.AllergenSoybeans},
+}
+
+// attributeDiets maps FatSecret allergens and preferences to the diets they decide; an
+// allergen rules its diet out when present
+var attributeDiets = map[string]string{
+	"gluten":     entity.DietGlutenFree,
+	"lactose":    entity.DietLactoseFree,
+	"vegan":      entity.DietVegan,
+	"vegetarian": entity.DietVegetarian,
+}
+
+// foodAttributes collects the declared allergens and preferences, skipping unknown values;
+// it returns nil when nothing is declared
+func foodAttributes(response *service.FoodDetails) *entity.FoodAttributes {
+	attributes := &entity.FoodAttributes{}
+	known := false
+
+	for _, allergen := range response.Food.FoodAttributes.Allergens.Allergen {
+		name := strings.ToLower(allergen.Name)
+		contains, ok := attributeValue(allergen.Value)
+		if !ok {
+			continue
+		}
+		if codes, ok := attributeAllergens[name]; ok {
+			known = true
+			if contains {
+				attributes.Allergens = append(attributes.Allergens, codes...)
+			} else {
+				attributes.FreeFrom = append(attributes.FreeFrom, codes...)
+			}
+		}
+		if diet, ok := attributeDiets[name]; ok {
+			known = true
+			if contains {
+				attributes.UnsuitableDiets = append(attributes.UnsuitableDiets, diet)
+			} else {
+				attributes.SuitableDiets = append(attributes.SuitableDiets, diet)
+			}
+		}
+	}
+
+	for _, preference := range response.Food.FoodAttributes.Preferences.Preference {
+		suitable, ok := attributeValue(preference.Value)
+		diet, isDiet := attributeDiets[strings.ToLower(preference.Name)]
+		if !ok || !isDiet {
+			continue
+		}
+		known = true
+		if suitable {
+			attributes.SuitableDiets = append(attributes.SuitableDiets, diet)
+		} else {
+			attributes.UnsuitableDiets = append(attributes.UnsuitableDiets, diet)
+		}
+	}
+
+	if !known {
+		return nil
+	}
+	return attributes
+}
+
+// attributeValue reads a declared value, reporting false for unknown ones
+func attributeValue(value string) (bool, bool) {
+	switch strings.TrimSpace(value) {
+	case "1":
+		return true, true
+	case "0":
+		return false, true
+	}
+	return false, false
+}
@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+// ProfileRepo stores the body profile of users
+type ProfileRepo struct {
+	db *sqlx.DB
+}
+
+// NewProfileRepo creates a new profile repository
+func NewProfileRepo(db *sqlx.DB) *ProfileRepo {
+	return &ProfileRepo{db: db}
+}
+
+type profileRow struct {
+	Sex       string       `db:"sex"`
+	BirthDate sql.NullTime `db:"birth_date"`
+	HeightCm  float64      `db:"height_cm"`
+	UpdatedAt time.Time    `db:"updated_at"`
+}
+
+// Get returns the user's profile, or nil if it has not been filled in
+func (r *ProfileRepo) Get(ctx context.Context, userID int64) (*entity.Profile, error) {
+	query := `SELECT sex, birth_date, height_cm, updated_at FROM body.profiles WHERE user_id = $1`
+
+	var row profileRow
+	err := r.db.GetContext(ctx, &row, query, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get profile error: %w", err)
+	}
+
+	profile := &entity.Profile{
+		Sex:       row.Sex,
+		Height:    row.HeightCm,
+		UpdatedAt: row.UpdatedAt,
+	}
+	if row.BirthDate.Valid {
+		profile.BirthDate = row.BirthDate.Time.Format(entity.DateLayout)
+	}
+	return profile, nil
+}
+
+// Upsert replaces the user's profile
+func (r *ProfileRepo) Upsert(ctx context.Context, userID int64, profile entity.Profile) error {
+	query := `
+        INSERT INTO body.profiles (user_id, sex, birth_date, height_cm, updated_at)
+        VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+        ON CONFLICT (user_id) DO UPDATE
+        SET sex = EXCLUDED.sex, birth_date = EXCLUDED.birth_date, height_cm = EXCLUDED.height_cm,
+            updated_at = CURRENT_TIMESTAMP
+    `
+
+	var birthDate interface{}
+	if profile.BirthDate != "" {
+		birthDate = profile.BirthDate
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, userID, profile.Sex, birthDate, profile.Height); err != nil {
+		return fmt.Errorf("save profile error: %w", err)
+	}
+	return nil
+}
+
+// MeasurementRepo stores body measurements
+type MeasurementRepo struct {
+	db *sqlx.DB
+}
+
+// NewMeasurementRepo creates a new measurement repository
+func NewMeasurementRepo(db *sqlx.DB) *MeasurementRepo {
+	return &MeasurementRepo{db: db}
+}
+
+type measurementRow struct {
+	ID        int64     `db:"id"`
+	Date      time.Time `db:"date"`
+	Type      string    `db:"type"`
+	Value     float64   `db:"value"`
+	Unit      string    `db:"unit"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// Save records measurements in one transaction, replacing measurements of the same type
+// already recorded on the same date
+func (r *MeasurementRepo) Save(ctx context.Context, userID int64, measurements []entity.Measurement) ([]int64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction error: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO body.measurements (user_id, date, type, value, unit, created_at)
+        VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
+        ON CONFLICT (user_id, date, type) DO UPDATE
+        SET value = EXCLUDED.value, unit = EXCLUDED.unit
+        RETURNING id
+    `
+
+	ids := make([]int64, 0, len(measurements))
+	for _, measurement := range measurements {
+		var id int64
+		err := tx.QueryRowContext(ctx, query,
+			userID, measurement.Date, measurement.Type, measurement.Value, measurement.Unit).Scan(&id)
+		if err != nil {
+			return nil, fmt.Errorf("save measurement error: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction error: %w", err)
+	}
+
+	return ids, nil
+}
+
+// Delete removes a measurement
+func (r *MeasurementRepo) Delete(ctx context.Context, userID, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM body.measurements WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("delete measurement error: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("measurement %d: %w", id, entity.ErrNotFound)
+	}
+	return nil
+}
+
+// List returns a user's measurements of the given types (all types when empty) between two
+// dates (inclusive), ordered by date. A zero from returns the whole history up to to.
+func (r *MeasurementRepo) List(ctx context.Context, userID int64, types []string, from, to time.Time) ([]entity.Measurement, error) {
+	where := `WHERE user_id = ? AND date <= ?`
+	args := []interface{}{userID, to.Format(entity.DateLayout)}
+	if !from.IsZero() {
+		where += ` AND date >= ?`
+		args = append(args, from.Format(entity.DateLayout))
+	}
+	if len(types) > 0 {
+		where += ` AND type IN (?)`
+		args = append(args, types)
+	}
+
+	query, args, err := sqlx.In(`
+        SELECT id, date, type, value, unit, created_at
+        FROM body.measurements
+        `+where+`
+        ORDER BY date, type
+    `, args...)
+	if err != nil {
+		return nil, fmt.Errorf("build measurements query error: %w", err)
+	}
+
+	var rows []measurementRow
+	if err := r.db.SelectContext(ctx, &rows, r.db.Rebind(query), args...); err != nil {
+		return nil, fmt.Errorf("list measurements error: %w", err)
+	}
+
+	measurements := make([]entity.Measurement, 0, len(rows))
+	for _, row := range rows {
+		measurements = append(measurements, entity.Measurement{
+			ID:        row.ID,
+			Date:      row.Date.Format(entity.DateLayout),
+			Type:      row.Type,
+			Value:     row.Value,
+			Unit:      row.Unit,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	return measurements, nil
+}
@@ -0,0 +1,585 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/nutriscore"
+	"CalorieCompass/internal/pkg/nutrition"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// CatalogRepo reads and imports foods in the local offline catalog. When source is set
+// (e.g. "usda" or "off") reads are restricted to that dataset.
+type CatalogRepo struct {
+	db     *sqlx.DB
+	source string
+}
+
+// NewCatalogRepo creates a new catalog repository; an empty source reads every dataset
+func NewCatalogRepo(db *sqlx.DB, source string) *CatalogRepo {
+	return &CatalogRepo{db: db, source: source}
+}
+
+const catalogColumns = `id, source, source_id, name, brand_name, category, is_liquid, serving_size,
+        serving_description, calories, carbs, protein, fat, saturated_fat, fiber, cholesterol,
+        sodium, sugar, calcium, iron, potassium, vitamin_a, vitamin_c, vitamin_d, ingredients, content_hash`
+
+var catalogImportColumns = []string{
+	"source", "source_id", "name", "brand_name", "category", "is_liquid", "serving_size",
+	"serving_description", "calories", "carbs", "protein", "fat", "saturated_fat", "fiber",
+	"cholesterol", "sodium", "sugar", "calcium", "iron", "potassium", "vitamin_a", "vitamin_c",
+	"vitamin_d", "ingredients", "content_hash",
+}
+
+// prefixColumns qualifies a comma-separated column list with a table alias
+func prefixColumns(alias, columns string) string {
+	fields := strings.Split(columns, ",")
+	for i, field := range fields {
+		fields[i] = alias + "." + strings.TrimSpace(field)
+	}
+	return strings.Join(fields, ", ")
+}
+
+type catalogSearchRow struct {
+	entity.CatalogFood
+	TotalResults int `db:"total_results"`
+}
+
+// SearchFoods ranks catalog foods by full-text relevance (name over brand over category),
+// trigram similarity so that typos such as "banan" or "chiken brest" still match, and how
+// often the requesting user has logged the food under either of its IDs. Filters and sorting
+// are applied in SQL.
+func (r *CatalogRepo) SearchFoods(ctx context.Context, request entity.FoodSearchRequest) ([]entity.Food, int, error) {
+	args := []interface{}{r.source, request.Query, request.UserID}
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	conditions := append([]string{
+		`($1 = '' OR f.source = $1)`,
+		`(f.search_vector @@ q.tsq
+               OR lower(f.name) % q.text
+               OR q.text <% lower(f.name)
+               OR lower(f.brand_name) % q.text)`,
+	}, catalogFilterConditions(request.Filters, arg)...)
+
+	relevance := `ts_rank_cd(f.search_vector, q.tsq)
+            + similarity(lower(f.name), q.text)
+            + 0.5 * word_similarity(q.text, lower(f.name))
+            + 0.3 * similarity(lower(f.brand_name), q.text)
+            + CASE WHEN lower(f.name) = q.text THEN 1 ELSE 0 END
+            + 0.25 * ln(1 + COALESCE(u.log_count, 0))`
+
+	orderBy := relevance + ` DESC, length(f.name)`
+	switch request.Sort {
+	case entity.SortCalories:
+		orderBy = `f.calories, ` + relevance + ` DESC`
+	case entity.SortProteinDensity:
+		orderBy = `CASE WHEN f.calories > 0 THEN f.protein * 4 / f.calories ELSE 0 END DESC, ` + relevance + ` DESC`
+	}
+
+	sqlQuery := `
+        WITH q AS (
+            SELECT websearch_to_tsquery('english', $2) AS tsq, lower(trim($2)) AS text
+        )
+        SELECT ` + prefixColumns("f", catalogColumns) + `, COUNT(*) OVER () AS total_results
+        FROM catalog.foods f
+        CROSS JOIN q
+        LEFT JOIN LATERAL (
+            SELECT SUM(log_count) AS log_count
+            FROM food.food_usage
+            WHERE user_id = $3 AND food_id IN ('` + entity.FoodSourceCatalog + `:' || f.id, f.source || ':' || f.id)
+        ) u ON TRUE
+        WHERE ` + strings.Join(conditions, "\n          AND ") + `
+        ORDER BY ` + orderBy + `, f.id
+        LIMIT ` + arg(request.Limit) + ` OFFSET ` + arg(request.Page*request.Limit)
+
+	var rows []catalogSearchRow
+	if err := r.db.SelectContext(ctx, &rows, sqlQuery, args...); err != nil {
+		return nil, 0, fmt.Errorf("search catalog error: %w", err)
+	}
+
+	foods := make([]entity.Food, 0, len(rows))
+	totalResults := 0
+	for _, row := range rows {
+		foods = append(foods, catalogFoodSummary(row.CatalogFood))
+		totalResults = row.TotalResults
+	}
+
+	return foods, totalResults, nil
+}
+
+// AppliesFilters tells the food registry that search filters and sorting are handled in SQL
+func (r *CatalogRepo) AppliesFilters() bool {
+	return true
+}
+
+// catalogFilterConditions translates search filters into SQL over the per-100 g columns
+func catalogFilterConditions(filters entity.FoodSearchFilters, arg func(value interface{}) string) []string {
+	var conditions []string
+
+	switch strings.ToLower(filters.Type) {
+	case entity.FoodTypeBrand:
+		conditions = append(conditions, `f.brand_name <> ''`)
+	case entity.FoodTypeGeneric:
+		conditions = append(conditions, `f.brand_name = ''`)
+	}
+
+	ranges := []struct {
+		column string
+		bounds entity.NutrientRange
+	}{
+		{"f.calories", filters.Calories},
+		{"f.protein", filters.Protein},
+		{"f.carbs", filters.Carbs},
+		{"f.fat", filters.Fat},
+	}
+	for _, r := range ranges {
+		if r.bounds.Min != nil {
+			conditions = append(conditions, r.column+" >= "+arg(*r.bounds.Min))
+		}
+		if r.bounds.Max != nil {
+			conditions = append(conditions, r.column+" <= "+arg(*r.bounds.Max))
+		}
+	}
+
+	for _, tag := range filters.Tags {
+		if condition, ok := catalogTagConditions[tag]; ok {
+			conditions = append(conditions, condition)
+		}
+	}
+
+	return conditions
+}
+
+// catalogTagConditions mirrors nutrition.HasTag in SQL
+var catalogTagConditions = map[string]string{
+	entity.TagHighProtein: fmt.Sprintf(`(f.calories > 0 AND f.protein * 4 >= %g * f.calories)`, nutrition.HighProteinEnergyShare),
+	entity.TagHighFiber:   fmt.Sprintf(`f.fiber >= %g`, nutrition.HighFiberMin),
+	entity.TagLowCalorie:  fmt.Sprintf(`f.calories <= CASE WHEN f.is_liquid THEN %g ELSE %g END`, nutrition.LowCalorieLiquidMax, nutrition.LowCalorieMax),
+	entity.TagLowCarb:     fmt.Sprintf(`f.carbs <= %g`, nutrition.LowCarbMax),
+	entity.TagLowFat:      fmt.Sprintf(`f.fat <= CASE WHEN f.is_liquid THEN %g ELSE %g END`, nutrition.LowFatLiquidMax, nutrition.LowFatMax),
+	entity.TagLowSugar:    fmt.Sprintf(`f.sugar <= CASE WHEN f.is_liquid THEN %g ELSE %g END`, nutrition.LowSugarLiquidMax, nutrition.LowSugarMax),
+	entity.TagLowSodium:   fmt.Sprintf(`f.sodium <= %g`, nutrition.LowSodiumMax),
+}
+
+// GetFoodDetails returns a catalog food with a 100 g/ml serving and, when known, its labelled serving
+func (r *CatalogRepo) GetFoodDetails(ctx context.Context, foodID string) (*entity.FoodDetails, error) {
+	id, err := strconv.ParseInt(foodID, 10, 64)
+	if err != nil {
+		return nil, nil
+	}
+
+	query := `
+        SELECT ` + catalogColumns + `
+        FROM catalog.foods
+        WHERE id = $1 AND ($2 = '' OR source = $2)
+    `
+
+	var food entity.CatalogFood
+	if err := r.db.GetContext(ctx, &food, query, id, r.source); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get catalog food error: %w", err)
+	}
+
+	return catalogFoodDetails(food), nil
+}
+
+// SuggestFoods returns catalog foods whose split of energy between carbs (net carbs when
+// netCarbs is set), protein and fat is closest to that of budget, so that a scaled amount
+// can fill it. Only foods matching the filters are returned.
+func (r *CatalogRepo) SuggestFoods(ctx context.Context, budget entity.MacroBudget, netCarbs bool, filters entity.FoodSearchFilters, limit int) ([]entity.FoodDetails, error) {
+	args := []interface{}{r.source}
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	conditions := append([]string{
+		`($1 = '' OR f.source = $1)`,
+		`f.calories > 0`,
+	}, catalogFilterConditions(filters, arg)...)
+
+	share := func(grams, kcalPerGram float64) float64 {
+		if budget.Calories <= 0 {
+			return 0
+		}
+		return math.Max(grams, 0) * kcalPerGram / budget.Calories
+	}
+	carbs := `f.carbs`
+	if netCarbs {
+		carbs = `GREATEST(f.carbs - f.fiber, 0)`
+	}
+	distance := fmt.Sprintf(`power(%s * %g / f.calories - %s, 2)
+            + power(f.protein * %g / f.calories - %s, 2)
+            + power(f.fat * %g / f.calories - %s, 2)`,
+		carbs, nutrition.KcalPerGramCarbs, arg(share(budget.Carbs, nutrition.KcalPerGramCarbs)),
+		nutrition.KcalPerGramProtein, arg(share(budget.Protein, nutrition.KcalPerGramProtein)),
+		nutrition.KcalPerGramFat, arg(share(budget.Fat, nutrition.KcalPerGramFat)))
+
+	query := `
+        SELECT ` + prefixColumns("f", catalogColumns) + `
+        FROM catalog.foods f
+        WHERE ` + strings.Join(conditions, "\n          AND ") + `
+        ORDER BY ` + distance + `, f.id
+        LIMIT ` + arg(limit)
+
+	var rows []entity.CatalogFood
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("suggest catalog foods error: %w", err)
+	}
+
+	foods := make([]entity.FoodDetails, 0, len(rows))
+	for _, row := range rows {
+		details := catalogFoodDetails(row)
+		details.ID = entity.FoodSourceCatalog + ":" + details.ID
+		details.Source = entity.FoodSourceCatalog
+		foods = append(foods, *details)
+	}
+	return foods, nil
+}
+
+type similarFoodRow struct {
+	entity.CatalogFood
+	Similarity float64 `db:"similarity"`
+}
+
+// SimilarFoods returns catalog foods sharing a word with name or close to it by trigram
+// similarity, most similar first. They are restricted to the category of the catalog food
+// catalogID or, when it is 0, of the catalog food whose name is closest to name; the food
+// itself is left out.
+func (r *CatalogRepo) SimilarFoods(ctx context.Context, name string, catalogID int64, limit int) ([]entity.SimilarFood, error) {
+	query := `
+        WITH q AS (
+            SELECT lower(trim($2)) AS text,
+                   to_tsquery('english', replace(plainto_tsquery('english', $2)::text, '&', '|')) AS tsq
+        ), ref AS (
+            SELECT f.category
+            FROM catalog.foods f
+            CROSS JOIN q
+            WHERE ($3 > 0 AND f.id = $3) OR ($3 = 0 AND lower(f.name) % q.text)
+            ORDER BY similarity(lower(f.name), q.text) DESC
+            LIMIT 1
+        )
+        SELECT ` + prefixColumns("f", catalogColumns) + `, similarity(lower(f.name), q.text) AS similarity
+        FROM catalog.foods f
+        CROSS JOIN q
+        LEFT JOIN ref ON TRUE
+        WHERE ($1 = '' OR f.source = $1)
+          AND f.id <> $3
+          AND f.calories > 0
+          AND (COALESCE(ref.category, '') = '' OR f.category = ref.category)
+          AND (f.search_vector @@ q.tsq OR lower(f.name) % q.text)
+        ORDER BY similarity DESC, f.id
+        LIMIT $4
+    `
+
+	var rows []similarFoodRow
+	if err := r.db.SelectContext(ctx, &rows, query, r.source, name, catalogID, limit); err != nil {
+		return nil, fmt.Errorf("get similar catalog foods error: %w", err)
+	}
+
+	foods := make([]entity.SimilarFood, 0, len(rows))
+	for _, row := range rows {
+		foods = append(foods, entity.SimilarFood{Food: row.CatalogFood, Similarity: row.Similarity})
+	}
+	return foods, nil
+}
+
+type catalogCategoryRow struct {
+	ID       int64  `db:"id"`
+	Category string `db:"category"`
+}
+
+// Categories returns the dataset category of each catalog food, keyed by ID; foods without
+// a category are left out
+func (r *CatalogRepo) Categories(ctx context.Context, ids []int64) (map[int64]string, error) {
+	categories := make(map[int64]string, len(ids))
+	if len(ids) == 0 {
+		return categories, nil
+	}
+
+	var rows []catalogCategoryRow
+	if err := r.db.SelectContext(ctx, &rows, `
+        SELECT id, category
+        FROM catalog.foods
+        WHERE id = ANY($1) AND category <> ''
+    `, pq.Array(ids)); err != nil {
+		return nil, fmt.Errorf("get catalog categories error: %w", err)
+	}
+
+	for _, row := range rows {
+		categories[row.ID] = row.Category
+	}
+	return categories, nil
+}
+
+// FindRun returns the latest import run for the given file, or nil if it was never imported
+func (r *CatalogRepo) FindRun(ctx context.Context, run entity.ImportRun) (*entity.ImportRun, error) {
+	query := `
+        SELECT id, source, file_name, file_size, file_modified_at, checkpoint, imported, status, started_at, finished_at
+        FROM catalog.import_runs
+        WHERE source = $1 AND file_name = $2 AND file_size = $3 AND file_modified_at = $4
+        ORDER BY id DESC
+        LIMIT 1
+    `
+
+	var existing entity.ImportRun
+	err := r.db.GetContext(ctx, &existing, query, run.Source, run.FileName, run.FileSize, run.FileModifiedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("find import run error: %w", err)
+	}
+
+	return &existing, nil
+}
+
+// StartRun records a new import run and returns its ID
+func (r *CatalogRepo) StartRun(ctx context.Context, run entity.ImportRun) (int64, error) {
+	query := `
+        INSERT INTO catalog.import_runs (source, file_name, file_size, file_modified_at, status, started_at)
+        VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
+        RETURNING id
+    `
+
+	var id int64
+	err := r.db.QueryRowContext(ctx, query, run.Source, run.FileName, run.FileSize, run.FileModifiedAt, entity.ImportRunning).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("start import run error: %w", err)
+	}
+
+	return id, nil
+}
+
+// ImportBatch COPYs a batch into a staging table and upserts it into catalog.foods, skipping
+// rows whose content hash is unchanged. The run's checkpoint advances in the same transaction,
+// so an interrupted import resumes exactly after the last committed batch. It returns the
+// number of rows inserted or updated.
+func (r *CatalogRepo) ImportBatch(ctx context.Context, runID, checkpoint int64, foods []entity.CatalogFood) (int64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction error: %w", err)
+	}
+	defer tx.Rollback()
+
+	staging := `
+        CREATE TEMP TABLE catalog_import ON COMMIT DROP AS
+        SELECT ` + strings.Join(catalogImportColumns, ", ") + `
+        FROM catalog.foods
+        WITH NO DATA
+    `
+	if _, err := tx.ExecContext(ctx, staging); err != nil {
+		return 0, fmt.Errorf("create staging table error: %w", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn("catalog_import", catalogImportColumns...))
+	if err != nil {
+		return 0, fmt.Errorf("prepare copy error: %w", err)
+	}
+
+	for _, f := range foods {
+		_, err := stmt.ExecContext(ctx,
+			f.Source, f.SourceID, f.Name, f.BrandName, f.Category, f.IsLiquid, f.ServingSize,
+			f.ServingDescription, f.Calories, f.Carbs, f.Protein, f.Fat, f.SaturatedFat, f.Fiber,
+			f.Cholesterol, f.Sodium, f.Sugar, f.Calcium, f.Iron, f.Potassium, f.VitaminA, f.VitaminC,
+			f.VitaminD, f.Ingredients, f.ContentHash,
+		)
+		if err != nil {
+			stmt.Close()
+			return 0, fmt.Errorf("copy catalog food %s:%s error: %w", f.Source, f.SourceID, err)
+		}
+	}
+
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		stmt.Close()
+		return 0, fmt.Errorf("flush copy error: %w", err)
+	}
+	if err := stmt.Close(); err != nil {
+		return 0, fmt.Errorf("close copy error: %w", err)
+	}
+
+	upsert := `
+        INSERT INTO catalog.foods (source, source_id, name, brand_name, category, is_liquid, serving_size,
+            serving_description, calories, carbs, protein, fat, saturated_fat, fiber, cholesterol,
+            sodium, sugar, calcium, iron, potassium, vitamin_a, vitamin_c, vitamin_d, ingredients, content_hash)
+        SELECT DISTINCT ON (source, source_id)
+            source, source_id, name, brand_name, category, is_liquid, serving_size,
+            serving_description, calories, carbs, protein, fat, saturated_fat, fiber, cholesterol,
+            sodium, sugar, calcium, iron, potassium, vitamin_a, vitamin_c, vitamin_d, ingredients, content_hash
+        FROM catalog_import
+        ORDER BY source, source_id
+        ON CONFLICT (source, source_id) DO UPDATE SET
+            name = EXCLUDED.name,
+            brand_name = EXCLUDED.brand_name,
+            category = EXCLUDED.category,
+            is_liquid = EXCLUDED.is_liquid,
+            serving_size = EXCLUDED.serving_size,
+            serving_description = EXCLUDED.serving_description,
+            calories = EXCLUDED.calories,
+            carbs = EXCLUDED.carbs,
+            protein = EXCLUDED.protein,
+            fat = EXCLUDED.fat,
+            saturated_fat = EXCLUDED.saturated_fat,
+            fiber = EXCLUDED.fiber,
+            cholesterol = EXCLUDED.cholesterol,
+            sodium = EXCLUDED.sodium,
+            sugar = EXCLUDED.sugar,
+            calcium = EXCLUDED.calcium,
+            iron = EXCLUDED.iron,
+            potassium = EXCLUDED.potassium,
+            vitamin_a = EXCLUDED.vitamin_a,
+            vitamin_c = EXCLUDED.vitamin_c,
+            vitamin_d = EXCLUDED.vitamin_d,
+            ingredients = EXCLUDED.ingredients,
+            content_hash = EXCLUDED.content_hash,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE catalog.foods.content_hash <> EXCLUDED.content_hash
+    `
+
+	result, err := tx.ExecContext(ctx, upsert)
+	if err != nil {
+		return 0, fmt.Errorf("upsert catalog foods error: %w", err)
+	}
+	changed, _ := result.RowsAffected()
+
+	progress := `
+        UPDATE catalog.import_runs
+        SET checkpoint = $1, imported = imported + $2
+        WHERE id = $3
+    `
+	if _, err := tx.ExecContext(ctx, progress, checkpoint, changed, runID); err != nil {
+		return 0, fmt.Errorf("update import checkpoint error: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit transaction error: %w", err)
+	}
+
+	return changed, nil
+}
+
+// FinishRun marks an import run as completed
+func (r *CatalogRepo) FinishRun(ctx context.Context, runID int64) error {
+	query := `
+        UPDATE catalog.import_runs
+        SET status = $1, finished_at = CURRENT_TIMESTAMP
+        WHERE id = $2
+    `
+
+	if _, err := r.db.ExecContext(ctx, query, entity.ImportCompleted, runID); err != nil {
+		return fmt.Errorf("finish import run error: %w", err)
+	}
+	return nil
+}
+
+// catalogFoodSummary builds a search result with nutrients per 100 g/ml
+func catalogFoodSummary(food entity.CatalogFood) entity.Food {
+	foodType := "Generic"
+	if food.BrandName != "" {
+		foodType = "Brand"
+	}
+
+	return entity.Food{
+		ID:          strconv.FormatInt(food.ID, 10),
+		Name:        food.Name,
+		BrandName:   food.BrandName,
+		Type:        foodType,
+		Calories:    food.Calories,
+		Carbs:       food.Carbs,
+		Protein:     food.Protein,
+		Fat:         food.Fat,
+		Quality:     nutriscore.Quality(catalogBaseServing(food)),
+		Ingredients: food.Ingredients,
+		Per100g:     true,
+	}
+}
+
+// catalogBaseServing exposes a catalog food's per-100 g/ml values as a serving
+func catalogBaseServing(food entity.CatalogFood) entity.Serving {
+	unit := "g"
+	if food.IsLiquid {
+		unit = "ml"
+	}
+
+	return entity.Serving{
+		ID:                     "100" + unit,
+		Description:            "100 " + unit,
+		MetricServingAmount:    100,
+		MetricServingUnit:      unit,
+		NumberOfUnits:          100,
+		MeasurementDescription: unit,
+		Calories:               food.Calories,
+		Carbs:                  food.Carbs,
+		Protein:                food.Protein,
+		Fat:                    food.Fat,
+		SaturatedFat:           food.SaturatedFat,
+		Fiber:                  food.Fiber,
+		Cholesterol:            food.Cholesterol,
+		Sodium:                 food.Sodium,
+		Sugar:                  food.Sugar,
+		Calcium:                food.Calcium,
+		Iron:                   food.Iron,
+		Potassium:              food.Potassium,
+		VitaminA:               food.VitaminA,
+		VitaminC:               food.VitaminC,
+		VitaminD:               food.VitaminD,
+	}
+}
+
+// catalogFoodDetails exposes the per-100 g/ml values as a serving, plus the labelled serving size
+func catalogFoodDetails(food entity.CatalogFood) *entity.FoodDetails {
+	base := catalogBaseServing(food)
+	unit := base.MetricServingUnit
+	servings := []entity.Serving{base}
+
+	if food.ServingSize > 0 {
+		factor := food.ServingSize / 100
+		serving := entity.Serving{
+			ID:                     entity.CatalogServingID,
+			Description:            food.ServingDescription,
+			MetricServingAmount:    food.ServingSize,
+			MetricServingUnit:      unit,
+			NumberOfUnits:          1,
+			MeasurementDescription: "serving",
+			Calories:               food.Calories * factor,
+			Carbs:                  food.Carbs * factor,
+			Protein:                food.Protein * factor,
+			Fat:                    food.Fat * factor,
+			SaturatedFat:           food.SaturatedFat * factor,
+			Fiber:                  food.Fiber * factor,
+			Cholesterol:            food.Cholesterol * factor,
+			Sodium:                 food.Sodium * factor,
+			Sugar:                  food.Sugar * factor,
+			Calcium:                food.Calcium * factor,
+			Iron:                   food.Iron * factor,
+			Potassium:              food.Potassium * factor,
+			VitaminA:               food.VitaminA * factor,
+			VitaminC:               food.VitaminC * factor,
+			VitaminD:               food.VitaminD * factor,
+		}
+		if serving.Description == "" {
+			serving.Description = strconv.FormatFloat(food.ServingSize, 'f', -1, 64) + " " + unit
+		}
+		servings = append(servings, serving)
+	}
+
+	return &entity.FoodDetails{
+		ID:          strconv.FormatInt(food.ID, 10),
+		Name:        food.Name,
+		BrandName:   food.BrandName,
+		Servings:    servings,
+		Ingredients: food.Ingredients,
+	}
+}
@@ -0,0 +1,452 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/nutriscore"
+	"CalorieCompass/internal/pkg/nutrition"
+	"github.com/jmoiron/sqlx"
+)
+
+// CustomFoodRepo stores user-defined foods and their servings
+type CustomFoodRepo struct {
+	db *sqlx.DB
+}
+
+// NewCustomFoodRepo creates a new custom food repository
+func NewCustomFoodRepo(db *sqlx.DB) *CustomFoodRepo {
+	return &CustomFoodRepo{db: db}
+}
+
+type customFoodRow struct {
+	ID        int64     `db:"id"`
+	UserID    int64     `db:"user_id"`
+	Name      string    `db:"name"`
+	BrandName string    `db:"brand_name"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type customServingRow struct {
+	ID                     int64   `db:"id"`
+	CustomFoodID           int64   `db:"custom_food_id"`
+	Description            string  `db:"description"`
+	MetricServingAmount    float64 `db:"metric_serving_amount"`
+	MetricServingUnit      string  `db:"metric_serving_unit"`
+	NumberOfUnits          float64 `db:"number_of_units"`
+	MeasurementDescription string  `db:"measurement_description"`
+	Calories               float64 `db:"calories"`
+	Carbs                  float64 `db:"carbs"`
+	Protein                float64 `db:"protein"`
+	Fat                    float64 `db:"fat"`
+	SaturatedFat           float64 `db:"saturated_fat"`
+	Fiber                  float64 `db:"fiber"`
+	Cholesterol            float64 `db:"cholesterol"`
+	Sodium                 float64 `db:"sodium"`
+	Sugar                  float64 `db:"sugar"`
+	Calcium                float64 `db:"calcium"`
+	Iron                   float64 `db:"iron"`
+	Potassium              float64 `db:"potassium"`
+	VitaminA               float64 `db:"vitamin_a"`
+	VitaminC               float64 `db:"vitamin_c"`
+	VitaminD               float64 `db:"vitamin_d"`
+}
+
+const customServingColumns = `id, custom_food_id, description, metric_serving_amount, metric_serving_unit,
+        number_of_units, measurement_description, calories, carbs, protein, fat,
+        saturated_fat, fiber, cholesterol, sodium, sugar, calcium, iron, potassium, vitamin_a,
+        vitamin_c, vitamin_d`
+
+// Create inserts a custom food with its servings and returns the new ID
+func (r *CustomFoodRepo) Create(ctx context.Context, userID int64, input entity.CustomFoodInput) (int64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction error: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO food.custom_foods (user_id, name, brand_name, created_at, updated_at)
+        VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+        RETURNING id
+    `
+
+	var id int64
+	if err := tx.QueryRowContext(ctx, query, userID, input.Name, input.BrandName).Scan(&id); err != nil {
+		return 0, fmt.Errorf("create custom food error: %w", err)
+	}
+
+	if err := insertCustomServings(ctx, tx, id, input.Servings); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit transaction error: %w", err)
+	}
+
+	return id, nil
+}
+
+// Update replaces a custom food's name, brand and servings. Servings keep their IDs, which
+// diary entries, recipes, favorites and saved meals refer to: each is matched to an existing
+// serving by its ID or else by position and updated in place, and only the difference is
+// inserted or deleted.
+func (r *CustomFoodRepo) Update(ctx context.Context, userID, id int64, input entity.CustomFoodInput) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction error: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        UPDATE food.custom_foods
+        SET name = $1, brand_name = $2, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $3 AND user_id = $4
+    `
+
+	result, err := tx.ExecContext(ctx, query, input.Name, input.BrandName, id, userID)
+	if err != nil {
+		return fmt.Errorf("update custom food error: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("custom food %d: %w", id, entity.ErrNotFound)
+	}
+
+	if err := updateCustomServings(ctx, tx, id, input.Servings); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction error: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a custom food and its servings
+func (r *CustomFoodRepo) Delete(ctx context.Context, userID, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM food.custom_foods WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("delete custom food error: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("custom food %d: %w", id, entity.ErrNotFound)
+	}
+	return nil
+}
+
+// GetByID returns a user's custom food with its servings, or nil if it does not exist
+func (r *CustomFoodRepo) GetByID(ctx context.Context, userID, id int64) (*entity.FoodDetails, error) {
+	foods, err := r.load(ctx, `WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(foods) == 0 {
+		return nil, nil
+	}
+	return &foods[0], nil
+}
+
+// List returns all custom foods owned by a user
+func (r *CustomFoodRepo) List(ctx context.Context, userID int64) ([]entity.FoodDetails, error) {
+	return r.load(ctx, `WHERE user_id = $1 ORDER BY name`, userID)
+}
+
+// SearchFoods lists the requesting user's custom foods whose name or brand contains the
+// query as search results, filtered, sorted and paged like the food registry's other
+// sources; anonymous searches find none
+func (r *CustomFoodRepo) SearchFoods(ctx context.Context, request entity.FoodSearchRequest) ([]entity.Food, int, error) {
+	if request.UserID == 0 {
+		return []entity.Food{}, 0, nil
+	}
+	details, err := r.load(ctx, `WHERE user_id = $1 AND (name ILIKE '%' || $2 || '%' OR brand_name ILIKE '%' || $2 || '%') ORDER BY name, id`,
+		request.UserID, escapeLike(request.Query))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	foods := make([]entity.Food, 0, len(details))
+	for _, food := range details {
+		summary := customFoodSummary(food)
+		if !nutrition.MatchesType(summary, request.Filters.Type) {
+			continue
+		}
+		if request.Filters.NeedsNutrients() {
+			per100, ok := nutrition.DetailsPer100(food)
+			if !ok || !nutrition.Matches(request.Filters, per100) {
+				continue
+			}
+		}
+		foods = append(foods, summary)
+	}
+
+	switch request.Sort {
+	case entity.SortCalories:
+		// Foods listed only per serving go last rather than be compared per 100 g
+		sort.SliceStable(foods, func(i, j int) bool {
+			if foods[i].Per100g != foods[j].Per100g {
+				return foods[i].Per100g
+			}
+			return foods[i].Per100g && foods[i].Calories < foods[j].Calories
+		})
+	case entity.SortProteinDensity:
+		sort.SliceStable(foods, func(i, j int) bool {
+			return nutrition.ProteinDensity(foods[i].Calories, foods[i].Protein) >
+				nutrition.ProteinDensity(foods[j].Calories, foods[j].Protein)
+		})
+	}
+
+	total := len(foods)
+	start := min(request.Page*request.Limit, total)
+	end := min(start+request.Limit, total)
+	return foods[start:end], total, nil
+}
+
+// escapeLike escapes the LIKE wildcards in a user's query, using the default \ escape
+// character, so that "%" and "_" match themselves
+func escapeLike(query string) string {
+	return likeEscaper.Replace(query)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// AppliesFilters tells the food registry that custom food searches are filtered and sorted here
+func (r *CustomFoodRepo) AppliesFilters() bool {
+	return true
+}
+
+// customFoodSummary builds a search result from a custom food's per-100 g values, like the
+// catalog's, or from its first serving when none has a metric amount
+func customFoodSummary(details entity.FoodDetails) entity.Food {
+	food := entity.Food{
+		ID:        details.ID,
+		Name:      details.Name,
+		BrandName: details.BrandName,
+		Type:      "Custom",
+	}
+	if details.BrandName != "" {
+		food.Type = "Brand"
+	}
+
+	if per100, ok := nutrition.DetailsPer100(details); ok {
+		food.Calories = per100.Calories
+		food.Carbs = per100.Carbs
+		food.Protein = per100.Protein
+		food.Fat = per100.Fat
+		food.Quality = nutriscore.Quality(per100)
+		food.Per100g = true
+	} else if len(details.Servings) > 0 {
+		food.Calories = details.Servings[0].Calories
+		food.Carbs = details.Servings[0].Carbs
+		food.Protein = details.Servings[0].Protein
+		food.Fat = details.Servings[0].Fat
+	}
+	return food
+}
+
+// load fetches custom foods matching the given WHERE/ORDER clause together with their servings
+func (r *CustomFoodRepo) load(ctx context.Context, where string, args ...interface{}) ([]entity.FoodDetails, error) {
+	query := `
+        SELECT id, user_id, name, brand_name, created_at, updated_at
+        FROM food.custom_foods
+        ` + where
+
+	var rows []customFoodRow
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("get custom foods error: %w", err)
+	}
+	if len(rows) == 0 {
+		return []entity.FoodDetails{}, nil
+	}
+
+	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+
+	servingsQuery, servingsArgs, err := sqlx.In(`
+        SELECT `+customServingColumns+`
+        FROM food.custom_food_servings
+        WHERE custom_food_id IN (?)
+        ORDER BY custom_food_id, position, id
+    `, ids)
+	if err != nil {
+		return nil, fmt.Errorf("build custom servings query error: %w", err)
+	}
+
+	var servingRows []customServingRow
+	if err := r.db.SelectContext(ctx, &servingRows, r.db.Rebind(servingsQuery), servingsArgs...); err != nil {
+		return nil, fmt.Errorf("get custom servings error: %w", err)
+	}
+
+	servings := make(map[int64][]entity.Serving, len(rows))
+	for _, row := range servingRows {
+		servings[row.CustomFoodID] = append(servings[row.CustomFoodID], row.toEntity())
+	}
+
+	foods := make([]entity.FoodDetails, 0, len(rows))
+	for _, row := range rows {
+		foodServings := servings[row.ID]
+		if foodServings == nil {
+			foodServings = make([]entity.Serving, 0)
+		}
+		foods = append(foods, entity.FoodDetails{
+			ID:        strconv.FormatInt(row.ID, 10),
+			Name:      row.Name,
+			BrandName: row.BrandName,
+			Source:    entity.FoodSourceCustom,
+			Servings:  foodServings,
+		})
+	}
+
+	return foods, nil
+}
+
+func insertCustomServings(ctx context.Context, tx *sqlx.Tx, foodID int64, servings []entity.Serving) error {
+	for i, s := range servings {
+		if err := insertCustomServing(ctx, tx, foodID, i, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertCustomServing(ctx context.Context, tx *sqlx.Tx, foodID int64, position int, s entity.Serving) error {
+	query := `
+        INSERT INTO food.custom_food_servings (
+            custom_food_id, position, description, metric_serving_amount, metric_serving_unit,
+            number_of_units, measurement_description, calories, carbs, protein, fat,
+            saturated_fat, fiber, cholesterol, sodium, sugar, calcium, iron, potassium, vitamin_a,
+            vitamin_c, vitamin_d
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19,
+                $20, $21, $22)
+    `
+
+	_, err := tx.ExecContext(ctx, query,
+		foodID, position, s.Description, s.MetricServingAmount, s.MetricServingUnit,
+		s.NumberOfUnits, s.MeasurementDescription, s.Calories, s.Carbs, s.Protein, s.Fat,
+		s.SaturatedFat, s.Fiber, s.Cholesterol, s.Sodium, s.Sugar, s.Calcium, s.Iron, s.Potassium,
+		s.VitaminA, s.VitaminC, s.VitaminD,
+	)
+	if err != nil {
+		return fmt.Errorf("create custom serving error: %w", err)
+	}
+	return nil
+}
+
+// updateCustomServings replaces a custom food's servings while keeping the IDs of those that
+// remain. A serving whose ID is one of the food's keeps that row; the others take the
+// unclaimed rows in position order, and servings left without a row are inserted. Rows no
+// serving took are deleted.
+func updateCustomServings(ctx context.Context, tx *sqlx.Tx, foodID int64, servings []entity.Serving) error {
+	var existing []int64
+	if err := tx.SelectContext(ctx, &existing,
+		`SELECT id FROM food.custom_food_servings WHERE custom_food_id = $1 ORDER BY position, id`, foodID); err != nil {
+		return fmt.Errorf("get custom servings error: %w", err)
+	}
+
+	owned := make(map[int64]bool, len(existing))
+	for _, servingID := range existing {
+		owned[servingID] = true
+	}
+	rows := make([]int64, len(servings))
+	claimed := make(map[int64]bool, len(existing))
+	for i, serving := range servings {
+		servingID, err := strconv.ParseInt(serving.ID, 10, 64)
+		if err == nil && owned[servingID] && !claimed[servingID] {
+			rows[i] = servingID
+			claimed[servingID] = true
+		}
+	}
+	next := 0
+	for i := range servings {
+		if rows[i] != 0 {
+			continue
+		}
+		for next < len(existing) && claimed[existing[next]] {
+			next++
+		}
+		if next < len(existing) {
+			rows[i] = existing[next]
+			claimed[existing[next]] = true
+		}
+	}
+
+	var unclaimed []int64
+	for _, servingID := range existing {
+		if !claimed[servingID] {
+			unclaimed = append(unclaimed, servingID)
+		}
+	}
+	if len(unclaimed) > 0 {
+		query, args, err := sqlx.In(`DELETE FROM food.custom_food_servings WHERE id IN (?)`, unclaimed)
+		if err != nil {
+			return fmt.Errorf("build delete custom servings query error: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, tx.Rebind(query), args...); err != nil {
+			return fmt.Errorf("delete custom servings error: %w", err)
+		}
+	}
+
+	query := `
+        UPDATE food.custom_food_servings
+        SET position = $1, description = $2, metric_serving_amount = $3, metric_serving_unit = $4,
+            number_of_units = $5, measurement_description = $6, calories = $7, carbs = $8,
+            protein = $9, fat = $10, saturated_fat = $11, fiber = $12, cholesterol = $13,
+            sodium = $14, sugar = $15, calcium = $16, iron = $17, potassium = $18, vitamin_a = $19,
+            vitamin_c = $20, vitamin_d = $21
+        WHERE id = $22 AND custom_food_id = $23
+    `
+	for i, s := range servings {
+		if rows[i] == 0 {
+			if err := insertCustomServing(ctx, tx, foodID, i, s); err != nil {
+				return err
+			}
+			continue
+		}
+		_, err := tx.ExecContext(ctx, query,
+			i, s.Description, s.MetricServingAmount, s.MetricServingUnit,
+			s.NumberOfUnits, s.MeasurementDescription, s.Calories, s.Carbs, s.Protein, s.Fat,
+			s.SaturatedFat, s.Fiber, s.Cholesterol, s.Sodium, s.Sugar, s.Calcium, s.Iron, s.Potassium,
+			s.VitaminA, s.VitaminC, s.VitaminD, rows[i], foodID,
+		)
+		if err != nil {
+			return fmt.Errorf("update custom serving error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (row customServingRow) toEntity() entity.Serving {
+	return entity.Serving{
+		ID:                     strconv.FormatInt(row.ID, 10),
+		Description:            row.Description,
+		MetricServingAmount:    row.MetricServingAmount,
+		MetricServingUnit:      row.MetricServingUnit,
+		NumberOfUnits:          row.NumberOfUnits,
+		MeasurementDescription: row.MeasurementDescription,
+		Calories:               row.Calories,
+		Carbs:                  row.Carbs,
+		Protein:                row.Protein,
+		Fat:                    row.Fat,
+		SaturatedFat:           row.SaturatedFat,
+		Fiber:                  row.Fiber,
+		Cholesterol:            row.Cholesterol,
+		Sodium:                 row.Sodium,
+		Sugar:                  row.Sugar,
+		Calcium:                row.Calcium,
+		Iron:                   row.Iron,
+		Potassium:              row.Potassium,
+		VitaminA:               row.VitaminA,
+		VitaminC:               row.VitaminC,
+		VitaminD:               row.VitaminD,
+	}
+}
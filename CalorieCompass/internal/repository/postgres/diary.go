@@ -0,0 +1,426 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+// Recent food ranking: logs older than the window are ignored, each log's weight halves
+// roughly every recentDecayDays*ln(2) days, and logs within timeOfDayWindowHours of the
+// current time of day weigh up to 1+timeOfDayBoost times as much
+const (
+	recentWindowDays     = 90
+	recentDecayDays      = 30.0
+	timeOfDayBoost       = 2.0
+	timeOfDayWindowHours = 3.0
+)
+
+// DiaryRepo stores food diary entries
+type DiaryRepo struct {
+	db *sqlx.DB
+}
+
+// NewDiaryRepo creates a new diary repository
+func NewDiaryRepo(db *sqlx.DB) *DiaryRepo {
+	return &DiaryRepo{db: db}
+}
+
+type diaryEntryRow struct {
+	ID           int64     `db:"id"`
+	Date         time.Time `db:"date"`
+	Meal         string    `db:"meal"`
+	FoodID       string    `db:"food_id"`
+	FoodName     string    `db:"food_name"`
+	BrandName    string    `db:"brand_name"`
+	ServingID    string    `db:"serving_id"`
+	Amount       float64   `db:"amount"`
+	Unit         string    `db:"unit"`
+	MetricAmount float64   `db:"metric_amount"`
+	MetricUnit   string    `db:"metric_unit"`
+	EatenAt      time.Time `db:"eaten_at"`
+	Calories     float64   `db:"calories"`
+	Carbs        float64   `db:"carbs"`
+	Protein      float64   `db:"protein"`
+	Fat          float64   `db:"fat"`
+	SaturatedFat float64   `db:"saturated_fat"`
+	Fiber        float64   `db:"fiber"`
+	Cholesterol  float64   `db:"cholesterol"`
+	Sodium       float64   `db:"sodium"`
+	Sugar        float64   `db:"sugar"`
+	Calcium      float64   `db:"calcium"`
+	Iron         float64   `db:"iron"`
+	Potassium    float64   `db:"potassium"`
+	VitaminA     float64   `db:"vitamin_a"`
+	VitaminC     float64   `db:"vitamin_c"`
+	VitaminD     float64   `db:"vitamin_d"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+const diaryEntryColumns = `id, date, meal, food_id, food_name, brand_name, serving_id, amount, unit,
+        metric_amount, metric_unit, eaten_at, calories, carbs, protein, fat, saturated_fat, fiber, cholesterol, sodium, sugar,
+        calcium, iron, potassium, vitamin_a, vitamin_c, vitamin_d, created_at`
+
+// Create inserts a diary entry and counts the log towards the user's food usage
+func (r *DiaryRepo) Create(ctx context.Context, userID int64, entry entity.DiaryEntry) (int64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction error: %w", err)
+	}
+	defer tx.Rollback()
+
+	id, err := insertDiaryEntry(ctx, tx, userID, entry)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit transaction error: %w", err)
+	}
+
+	return id, nil
+}
+
+// CreateBatch inserts several entries in one transaction, so either all of them are logged
+// or none are
+func (r *DiaryRepo) CreateBatch(ctx context.Context, userID int64, entries []entity.DiaryEntry) ([]int64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction error: %w", err)
+	}
+	defer tx.Rollback()
+
+	ids := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		id, err := insertDiaryEntry(ctx, tx, userID, entry)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction error: %w", err)
+	}
+
+	return ids, nil
+}
+
+// Delete removes a diary entry and takes it back out of the user's food usage
+func (r *DiaryRepo) Delete(ctx context.Context, userID, id int64) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction error: %w", err)
+	}
+	defer tx.Rollback()
+
+	var foodID string
+	err = tx.QueryRowContext(ctx, `DELETE FROM food.diary_entries WHERE id = $1 AND user_id = $2 RETURNING food_id`,
+		id, userID).Scan(&foodID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("diary entry %d: %w", id, entity.ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("delete diary entry error: %w", err)
+	}
+
+	query := `
+        UPDATE food.food_usage
+        SET log_count = GREATEST(log_count - 1, 0)
+        WHERE user_id = $1 AND food_id = $2
+    `
+	if _, err := tx.ExecContext(ctx, query, userID, foodID); err != nil {
+		return fmt.Errorf("update food usage error: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction error: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID returns a user's diary entry, or an entity.ErrNotFound error
+func (r *DiaryRepo) GetByID(ctx context.Context, userID, id int64) (*entity.DiaryEntry, error) {
+	query := `SELECT ` + diaryEntryColumns + ` FROM food.diary_entries WHERE id = $1 AND user_id = $2`
+
+	var row diaryEntryRow
+	err := r.db.GetContext(ctx, &row, query, id, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("diary entry %d: %w", id, entity.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get diary entry error: %w", err)
+	}
+
+	entry := row.toEntity()
+	return &entry, nil
+}
+
+// ListByDate returns a user's entries for a date in the order they were eaten
+func (r *DiaryRepo) ListByDate(ctx context.Context, userID int64, date time.Time) ([]entity.DiaryEntry, error) {
+	query := `
+        SELECT ` + diaryEntryColumns + `
+        FROM food.diary_entries
+        WHERE user_id = $1 AND date = $2
+        ORDER BY eaten_at, id
+    `
+
+	var rows []diaryEntryRow
+	if err := r.db.SelectContext(ctx, &rows, query, userID, date.Format(entity.DateLayout)); err != nil {
+		return nil, fmt.Errorf("list diary entries error: %w", err)
+	}
+
+	entries := make([]entity.DiaryEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, row.toEntity())
+	}
+	return entries, nil
+}
+
+// ListEatenBetween returns a user's entries eaten in [from, to) in the order they were eaten
+func (r *DiaryRepo) ListEatenBetween(ctx context.Context, userID int64, from, to time.Time) ([]entity.DiaryEntry, error) {
+	query := `
+        SELECT ` + diaryEntryColumns + `
+        FROM food.diary_entries
+        WHERE user_id = $1 AND eaten_at >= $2 AND eaten_at < $3
+        ORDER BY eaten_at, id
+    `
+
+	var rows []diaryEntryRow
+	if err := r.db.SelectContext(ctx, &rows, query, userID, from, to); err != nil {
+		return nil, fmt.Errorf("list diary entries error: %w", err)
+	}
+
+	entries := make([]entity.DiaryEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, row.toEntity())
+	}
+	return entries, nil
+}
+
+type dailyIntakeRow struct {
+	Date         time.Time `db:"date"`
+	Entries      int       `db:"entries"`
+	Meals        int       `db:"meals"`
+	Calories     float64   `db:"calories"`
+	Carbs        float64   `db:"carbs"`
+	Protein      float64   `db:"protein"`
+	Fat          float64   `db:"fat"`
+	SaturatedFat float64   `db:"saturated_fat"`
+	Fiber        float64   `db:"fiber"`
+	Cholesterol  float64   `db:"cholesterol"`
+	Sodium       float64   `db:"sodium"`
+	Sugar        float64   `db:"sugar"`
+	Calcium      float64   `db:"calcium"`
+	Iron         float64   `db:"iron"`
+	Potassium    float64   `db:"potassium"`
+	VitaminA     float64   `db:"vitamin_a"`
+	VitaminC     float64   `db:"vitamin_c"`
+	VitaminD     float64   `db:"vitamin_d"`
+}
+
+// DailyIntake sums a user's logged nutrition per date between two dates (inclusive). Dates
+// with nothing logged are omitted.
+func (r *DiaryRepo) DailyIntake(ctx context.Context, userID int64, from, to time.Time) ([]entity.DailyIntake, error) {
+	query := `
+        SELECT date, COUNT(*) AS entries, COUNT(DISTINCT meal) AS meals,
+               SUM(calories) AS calories, SUM(carbs) AS carbs, SUM(protein) AS protein, SUM(fat) AS fat,
+               SUM(saturated_fat) AS saturated_fat, SUM(fiber) AS fiber, SUM(cholesterol) AS cholesterol,
+               SUM(sodium) AS sodium, SUM(sugar) AS sugar, SUM(calcium) AS calcium, SUM(iron) AS iron,
+               SUM(potassium) AS potassium, SUM(vitamin_a) AS vitamin_a, SUM(vitamin_c) AS vitamin_c,
+               SUM(vitamin_d) AS vitamin_d
+        FROM food.diary_entries
+        WHERE user_id = $1 AND date BETWEEN $2 AND $3
+        GROUP BY date
+        ORDER BY date
+    `
+
+	var rows []dailyIntakeRow
+	err := r.db.SelectContext(ctx, &rows, query, userID, from.Format(entity.DateLayout), to.Format(entity.DateLayout))
+	if err != nil {
+		return nil, fmt.Errorf("get daily intake error: %w", err)
+	}
+
+	days := make([]entity.DailyIntake, 0, len(rows))
+	for _, row := range rows {
+		days = append(days, entity.DailyIntake{
+			Date:    row.Date.Format(entity.DateLayout),
+			Entries: row.Entries,
+			Meals:   row.Meals,
+			Nutrition: entity.Serving{
+				Description:  "daily total",
+				Calories:     row.Calories,
+				Carbs:        row.Carbs,
+				Protein:      row.Protein,
+				Fat:          row.Fat,
+				SaturatedFat: row.SaturatedFat,
+				Fiber:        row.Fiber,
+				Cholesterol:  row.Cholesterol,
+				Sodium:       row.Sodium,
+				Sugar:        row.Sugar,
+				Calcium:      row.Calcium,
+				Iron:         row.Iron,
+				Potassium:    row.Potassium,
+				VitaminA:     row.VitaminA,
+				VitaminC:     row.VitaminC,
+				VitaminD:     row.VitaminD,
+			},
+		})
+	}
+	return days, nil
+}
+
+type recentFoodRow struct {
+	FoodID       string    `db:"food_id"`
+	FoodName     string    `db:"food_name"`
+	BrandName    string    `db:"brand_name"`
+	ServingID    string    `db:"serving_id"`
+	Amount       float64   `db:"amount"`
+	Unit         string    `db:"unit"`
+	Calories     float64   `db:"calories"`
+	LogCount     int       `db:"log_count"`
+	LastLoggedAt time.Time `db:"last_logged_at"`
+	Score        float64   `db:"score"`
+}
+
+// RecentFoods ranks the foods a user logged in the last recentWindowDays. Each log adds a
+// weight that decays with age and is boosted when it was eaten near the time of day of at,
+// measured in at's UTC offset. The latest log supplies the snapshot and default amount.
+func (r *DiaryRepo) RecentFoods(ctx context.Context, userID int64, at time.Time, limit int) ([]entity.RecentFood, error) {
+	_, offset := at.Zone()
+	hour := float64(at.Hour()) + float64(at.Minute())/60
+
+	query := `
+        WITH logged AS (
+            SELECT food_id, food_name, brand_name, serving_id, amount, unit, calories, eaten_at,
+                   EXTRACT(EPOCH FROM (eaten_at AT TIME ZONE make_interval(secs => $3))::time) / 3600 AS hour,
+                   GREATEST(EXTRACT(EPOCH FROM ($2 - eaten_at)) / 86400, 0) AS age_days
+            FROM food.diary_entries
+            WHERE user_id = $1
+              AND eaten_at > $2 - make_interval(days => $4)
+        )
+        SELECT food_id,
+               (array_agg(food_name ORDER BY eaten_at DESC))[1] AS food_name,
+               (array_agg(brand_name ORDER BY eaten_at DESC))[1] AS brand_name,
+               (array_agg(serving_id ORDER BY eaten_at DESC))[1] AS serving_id,
+               (array_agg(amount ORDER BY eaten_at DESC))[1] AS amount,
+               (array_agg(unit ORDER BY eaten_at DESC))[1] AS unit,
+               (array_agg(calories ORDER BY eaten_at DESC))[1] AS calories,
+               COUNT(*) AS log_count,
+               MAX(eaten_at) AS last_logged_at,
+               SUM(
+                   exp(-age_days / $5)
+                   * (1 + $6 * GREATEST(0, 1 - LEAST(ABS(hour - $7), 24 - ABS(hour - $7)) / $8))
+               ) AS score
+        FROM logged
+        GROUP BY food_id
+        ORDER BY score DESC, last_logged_at DESC
+        LIMIT $9
+    `
+
+	var rows []recentFoodRow
+	err := r.db.SelectContext(ctx, &rows, query,
+		userID, at, offset, recentWindowDays, recentDecayDays, timeOfDayBoost, hour, timeOfDayWindowHours, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get recent foods error: %w", err)
+	}
+
+	foods := make([]entity.RecentFood, 0, len(rows))
+	for _, row := range rows {
+		foods = append(foods, entity.RecentFood{
+			FoodID:       row.FoodID,
+			FoodName:     row.FoodName,
+			BrandName:    row.BrandName,
+			ServingID:    row.ServingID,
+			Amount:       row.Amount,
+			Unit:         row.Unit,
+			Calories:     row.Calories,
+			LogCount:     row.LogCount,
+			LastLoggedAt: row.LastLoggedAt,
+			Score:        row.Score,
+		})
+	}
+	return foods, nil
+}
+
+// insertDiaryEntry inserts an entry within tx and upserts the user's usage count for the food
+func insertDiaryEntry(ctx context.Context, tx *sqlx.Tx, userID int64, entry entity.DiaryEntry) (int64, error) {
+	query := `
+        INSERT INTO food.diary_entries (
+            user_id, date, meal, food_id, food_name, brand_name, serving_id, amount, unit, eaten_at,
+            calories, carbs, protein, fat, saturated_fat, fiber, cholesterol, sodium, sugar,
+            calcium, iron, potassium, vitamin_a, vitamin_c, vitamin_d, metric_amount, metric_unit
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21,
+                $22, $23, $24, $25, $26, $27)
+        RETURNING id
+    `
+
+	n := entry.Nutrition
+	var id int64
+	err := tx.QueryRowContext(ctx, query,
+		userID, entry.Date, entry.Meal, entry.FoodID, entry.FoodName, entry.BrandName, entry.ServingID,
+		entry.Amount, entry.Unit, entry.EatenAt,
+		n.Calories, n.Carbs, n.Protein, n.Fat, n.SaturatedFat, n.Fiber, n.Cholesterol, n.Sodium, n.Sugar,
+		n.Calcium, n.Iron, n.Potassium, n.VitaminA, n.VitaminC, n.VitaminD, n.MetricServingAmount, n.MetricServingUnit,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("create diary entry error: %w", err)
+	}
+
+	usageQuery := `
+        INSERT INTO food.food_usage (user_id, food_id, log_count, last_logged_at)
+        VALUES ($1, $2, 1, $3)
+        ON CONFLICT (user_id, food_id) DO UPDATE
+        SET log_count = food.food_usage.log_count + 1,
+            last_logged_at = GREATEST(food.food_usage.last_logged_at, EXCLUDED.last_logged_at)
+    `
+	if _, err := tx.ExecContext(ctx, usageQuery, userID, entry.FoodID, entry.EatenAt); err != nil {
+		return 0, fmt.Errorf("update food usage error: %w", err)
+	}
+
+	return id, nil
+}
+
+func (row diaryEntryRow) toEntity() entity.DiaryEntry {
+	return entity.DiaryEntry{
+		ID:        row.ID,
+		Date:      row.Date.Format(entity.DateLayout),
+		Meal:      row.Meal,
+		FoodID:    row.FoodID,
+		FoodName:  row.FoodName,
+		BrandName: row.BrandName,
+		ServingID: row.ServingID,
+		Amount:    row.Amount,
+		Unit:      row.Unit,
+		EatenAt:   row.EatenAt,
+		Nutrition: entity.Serving{
+			ID:                  row.ServingID,
+			Description:         fmt.Sprintf("%g %s", row.Amount, row.Unit),
+			MetricServingAmount: row.MetricAmount,
+			MetricServingUnit:   row.MetricUnit,
+			Calories:            row.Calories,
+			Carbs:               row.Carbs,
+			Protein:             row.Protein,
+			Fat:                 row.Fat,
+			SaturatedFat:        row.SaturatedFat,
+			Fiber:               row.Fiber,
+			Cholesterol:         row.Cholesterol,
+			Sodium:              row.Sodium,
+			Sugar:               row.Sugar,
+			Calcium:             row.Calcium,
+			Iron:                row.Iron,
+			Potassium:           row.Potassium,
+			VitaminA:            row.VitaminA,
+			VitaminC:            row.VitaminC,
+			VitaminD:            row.VitaminD,
+		},
+		CreatedAt: row.CreatedAt,
+	}
+}
@@ -0,0 +1,284 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+// ExerciseRepo stores the exercise catalog, user-defined exercises, logged exercise and
+// exercise settings
+type ExerciseRepo struct {
+	db *sqlx.DB
+}
+
+// NewExerciseRepo creates a new exercise repository
+func NewExerciseRepo(db *sqlx.DB) *ExerciseRepo {
+	return &ExerciseRepo{db: db}
+}
+
+type exerciseRow struct {
+	ID          int64         `db:"id"`
+	UserID      sql.NullInt64 `db:"user_id"`
+	Code        string        `db:"code"`
+	Name        string        `db:"name"`
+	Category    string        `db:"category"`
+	LightMET    float64       `db:"met_light"`
+	MET         float64       `db:"met"`
+	VigorousMET float64       `db:"met_vigorous"`
+	SpeedKmh    float64       `db:"speed_kmh"`
+}
+
+const exerciseColumns = `id, user_id, code, name, category, met_light, met, met_vigorous, speed_kmh`
+
+type exerciseEntryRow struct {
+	ID          int64           `db:"id"`
+	ExerciseID  sql.NullInt64   `db:"exercise_id"`
+	Date        time.Time       `db:"date"`
+	Name        string          `db:"name"`
+	Intensity   string          `db:"intensity"`
+	DurationMin float64         `db:"duration_min"`
+	DistanceKm  sql.NullFloat64 `db:"distance_km"`
+	MET         float64         `db:"met"`
+	WeightKg    float64         `db:"weight_kg"`
+	Calories    float64         `db:"calories"`
+	Note        string          `db:"note"`
+	CreatedAt   time.Time       `db:"created_at"`
+}
+
+const exerciseEntryColumns = `id, exercise_id, date, name, intensity, duration_min, distance_km, met, weight_kg,
+        calories, note, created_at`
+
+// Search returns catalog exercises and the user's own exercises whose name contains the
+// query, optionally limited to a category, ordered by name
+func (r *ExerciseRepo) Search(ctx context.Context, userID int64, query, category string, limit int) ([]entity.Exercise, error) {
+	sqlQuery := `
+        SELECT ` + exerciseColumns + `
+        FROM activity.exercises
+        WHERE (user_id IS NULL OR user_id = $1)
+          AND ($2 = '' OR name ILIKE '%' || $2 || '%')
+          AND ($3 = '' OR category = $3)
+        ORDER BY user_id IS NULL, lower(name)
+        LIMIT $4
+    `
+
+	var rows []exerciseRow
+	if err := r.db.SelectContext(ctx, &rows, sqlQuery, userID, escapeLike(query), category, limit); err != nil {
+		return nil, fmt.Errorf("search exercises error: %w", err)
+	}
+
+	exercises := make([]entity.Exercise, 0, len(rows))
+	for _, row := range rows {
+		exercises = append(exercises, row.toEntity())
+	}
+	return exercises, nil
+}
+
+// GetByID returns a catalog exercise or one of the user's exercises, or an
+// entity.ErrNotFound error
+func (r *ExerciseRepo) GetByID(ctx context.Context, userID, id int64) (*entity.Exercise, error) {
+	query := `SELECT ` + exerciseColumns + ` FROM activity.exercises WHERE id = $1 AND (user_id IS NULL OR user_id = $2)`
+
+	var row exerciseRow
+	err := r.db.GetContext(ctx, &row, query, id, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("exercise %d: %w", id, entity.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get exercise error: %w", err)
+	}
+
+	exercise := row.toEntity()
+	return &exercise, nil
+}
+
+// Create stores a user-defined exercise
+func (r *ExerciseRepo) Create(ctx context.Context, userID int64, exercise entity.Exercise) (int64, error) {
+	query := `
+        INSERT INTO activity.exercises (user_id, name, category, met_light, met, met_vigorous, speed_kmh,
+            created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+        RETURNING id
+    `
+
+	var id int64
+	err := r.db.QueryRowContext(ctx, query, userID, exercise.Name, exercise.Category,
+		exercise.LightMET, exercise.MET, exercise.VigorousMET, exercise.SpeedKmh).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("create exercise error: %w", err)
+	}
+	return id, nil
+}
+
+// Update replaces one of the user's exercises; catalog exercises cannot be changed
+func (r *ExerciseRepo) Update(ctx context.Context, userID int64, exercise entity.Exercise) error {
+	query := `
+        UPDATE activity.exercises
+        SET name = $1, category = $2, met_light = $3, met = $4, met_vigorous = $5, speed_kmh = $6,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE id = $7 AND user_id = $8
+    `
+
+	result, err := r.db.ExecContext(ctx, query, exercise.Name, exercise.Category,
+		exercise.LightMET, exercise.MET, exercise.VigorousMET, exercise.SpeedKmh, exercise.ID, userID)
+	if err != nil {
+		return fmt.Errorf("update exercise error: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("exercise %d: %w", exercise.ID, entity.ErrNotFound)
+	}
+	return nil
+}
+
+// Delete removes one of the user's exercises. Logged entries keep their snapshot.
+func (r *ExerciseRepo) Delete(ctx context.Context, userID, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM activity.exercises WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("delete exercise error: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("exercise %d: %w", id, entity.ErrNotFound)
+	}
+	return nil
+}
+
+// CreateEntry logs an exercise
+func (r *ExerciseRepo) CreateEntry(ctx context.Context, userID int64, entry entity.ExerciseEntry) (int64, error) {
+	query := `
+        INSERT INTO activity.exercise_entries (user_id, exercise_id, date, name, intensity, duration_min,
+            distance_km, met, weight_kg, calories, note, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, CURRENT_TIMESTAMP)
+        RETURNING id
+    `
+
+	var id int64
+	err := r.db.QueryRowContext(ctx, query, userID, entry.ExerciseID, entry.Date, entry.Name, entry.Intensity,
+		entry.Duration, entry.Distance, entry.MET, entry.Weight, entry.Calories, entry.Note).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("create exercise entry error: %w", err)
+	}
+	return id, nil
+}
+
+// DeleteEntry removes a logged exercise
+func (r *ExerciseRepo) DeleteEntry(ctx context.Context, userID, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM activity.exercise_entries WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("delete exercise entry error: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("exercise entry %d: %w", id, entity.ErrNotFound)
+	}
+	return nil
+}
+
+// GetEntry returns a logged exercise, or an entity.ErrNotFound error
+func (r *ExerciseRepo) GetEntry(ctx context.Context, userID, id int64) (*entity.ExerciseEntry, error) {
+	query := `SELECT ` + exerciseEntryColumns + ` FROM activity.exercise_entries WHERE id = $1 AND user_id = $2`
+
+	var row exerciseEntryRow
+	err := r.db.GetContext(ctx, &row, query, id, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("exercise entry %d: %w", id, entity.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get exercise entry error: %w", err)
+	}
+
+	entry := row.toEntity()
+	return &entry, nil
+}
+
+// ListEntries returns the exercise logged between two dates (inclusive) in logging order
+func (r *ExerciseRepo) ListEntries(ctx context.Context, userID int64, from, to time.Time) ([]entity.ExerciseEntry, error) {
+	query := `
+        SELECT ` + exerciseEntryColumns + `
+        FROM activity.exercise_entries
+        WHERE user_id = $1 AND date BETWEEN $2 AND $3
+        ORDER BY date, created_at, id
+    `
+
+	var rows []exerciseEntryRow
+	err := r.db.SelectContext(ctx, &rows, query, userID, from.Format(entity.DateLayout), to.Format(entity.DateLayout))
+	if err != nil {
+		return nil, fmt.Errorf("list exercise entries error: %w", err)
+	}
+
+	entries := make([]entity.ExerciseEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, row.toEntity())
+	}
+	return entries, nil
+}
+
+// GetSettings returns the user's exercise settings, the defaults when never saved
+func (r *ExerciseRepo) GetSettings(ctx context.Context, userID int64) (*entity.ExerciseSettings, error) {
+	var settings entity.ExerciseSettings
+	err := r.db.QueryRowContext(ctx, `SELECT add_burned_calories, updated_at FROM activity.settings WHERE user_id = $1`, userID).
+		Scan(&settings.AddBurnedCalories, &settings.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &entity.ExerciseSettings{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get exercise settings error: %w", err)
+	}
+	return &settings, nil
+}
+
+// SaveSettings replaces the user's exercise settings
+func (r *ExerciseRepo) SaveSettings(ctx context.Context, userID int64, settings entity.ExerciseSettings) error {
+	query := `
+        INSERT INTO activity.settings (user_id, add_burned_calories, updated_at)
+        VALUES ($1, $2, CURRENT_TIMESTAMP)
+        ON CONFLICT (user_id) DO UPDATE
+        SET add_burned_calories = EXCLUDED.add_burned_calories, updated_at = CURRENT_TIMESTAMP
+    `
+
+	if _, err := r.db.ExecContext(ctx, query, userID, settings.AddBurnedCalories); err != nil {
+		return fmt.Errorf("save exercise settings error: %w", err)
+	}
+	return nil
+}
+
+func (row exerciseRow) toEntity() entity.Exercise {
+	return entity.Exercise{
+		ID:          row.ID,
+		Code:        row.Code,
+		Name:        row.Name,
+		Category:    row.Category,
+		LightMET:    row.LightMET,
+		MET:         row.MET,
+		VigorousMET: row.VigorousMET,
+		SpeedKmh:    row.SpeedKmh,
+		Custom:      row.UserID.Valid,
+	}
+}
+
+func (row exerciseEntryRow) toEntity() entity.ExerciseEntry {
+	entry := entity.ExerciseEntry{
+		ID:        row.ID,
+		Date:      row.Date.Format(entity.DateLayout),
+		Name:      row.Name,
+		Intensity: row.Intensity,
+		Duration:  row.DurationMin,
+		MET:       row.MET,
+		Weight:    row.WeightKg,
+		Calories:  row.Calories,
+		Note:      row.Note,
+		CreatedAt: row.CreatedAt,
+	}
+	if row.ExerciseID.Valid {
+		id := row.ExerciseID.Int64
+		entry.ExerciseID = &id
+	}
+	if row.DistanceKm.Valid {
+		distance := row.DistanceKm.Float64
+		entry.Distance = &distance
+	}
+	return entry
+}
@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+// FastingRepo stores fasting sessions
+type FastingRepo struct {
+	db *sqlx.DB
+}
+
+// NewFastingRepo creates a new fasting repository
+func NewFastingRepo(db *sqlx.DB) *FastingRepo {
+	return &FastingRepo{db: db}
+}
+
+type fastingSessionRow struct {
+	ID           int64        `db:"id"`
+	Protocol     string       `db:"protocol"`
+	StartedAt    time.Time    `db:"started_at"`
+	PlannedHours float64      `db:"planned_hours"`
+	EndedAt      sql.NullTime `db:"ended_at"`
+	Note         string       `db:"note"`
+	CreatedAt    time.Time    `db:"created_at"`
+}
+
+const fastingSessionColumns = `id, protocol, started_at, planned_hours, ended_at, note, created_at`
+
+// Create stores a fast in progress
+func (r *FastingRepo) Create(ctx context.Context, userID int64, session entity.FastingSession) (int64, error) {
+	query := `
+        INSERT INTO body.fasting_sessions (user_id, protocol, started_at, planned_hours, note, created_at)
+        VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
+        RETURNING id
+    `
+
+	var id int64
+	err := r.db.QueryRowContext(ctx, query, userID, session.Protocol, session.StartedAt, session.PlannedHours,
+		session.Note).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("create fasting session error: %w", err)
+	}
+	return id, nil
+}
+
+// End sets the end of a fast in progress
+func (r *FastingRepo) End(ctx context.Context, userID, id int64, endedAt time.Time) error {
+	query := `UPDATE body.fasting_sessions SET ended_at = $1 WHERE id = $2 AND user_id = $3 AND ended_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, endedAt, id, userID)
+	if err != nil {
+		return fmt.Errorf("end fasting session error: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("active fasting session %d: %w", id, entity.ErrNotFound)
+	}
+	return nil
+}
+
+// Delete removes a fast
+func (r *FastingRepo) Delete(ctx context.Context, userID, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM body.fasting_sessions WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("delete fasting session error: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("fasting session %d: %w", id, entity.ErrNotFound)
+	}
+	return nil
+}
+
+// Get returns a fast, or an entity.ErrNotFound error
+func (r *FastingRepo) Get(ctx context.Context, userID, id int64) (*entity.FastingSession, error) {
+	query := `SELECT ` + fastingSessionColumns + ` FROM body.fasting_sessions WHERE id = $1 AND user_id = $2`
+
+	var row fastingSessionRow
+	err := r.db.GetContext(ctx, &row, query, id, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("fasting session %d: %w", id, entity.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get fasting session error: %w", err)
+	}
+
+	session := row.toEntity()
+	return &session, nil
+}
+
+// Latest returns the most recently started fast, which may be in progress, nil if there is none
+func (r *FastingRepo) Latest(ctx context.Context, userID int64) (*entity.FastingSession, error) {
+	query := `
+        SELECT ` + fastingSessionColumns + `
+        FROM body.fasting_sessions
+        WHERE user_id = $1
+        ORDER BY started_at DESC
+        LIMIT 1
+    `
+
+	var row fastingSessionRow
+	err := r.db.GetContext(ctx, &row, query, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get latest fasting session error: %w", err)
+	}
+
+	session := row.toEntity()
+	return &session, nil
+}
+
+// List returns the fasts started between two dates (inclusive, UTC) in the order they started
+func (r *FastingRepo) List(ctx context.Context, userID int64, from, to time.Time) ([]entity.FastingSession, error) {
+	query := `
+        SELECT ` + fastingSessionColumns + `
+        FROM body.fasting_sessions
+        WHERE user_id = $1 AND started_at >= $2 AND started_at < $3
+        ORDER BY started_at
+    `
+
+	var rows []fastingSessionRow
+	if err := r.db.SelectContext(ctx, &rows, query, userID, from, to.AddDate(0, 0, 1)); err != nil {
+		return nil, fmt.Errorf("list fasting sessions error: %w", err)
+	}
+
+	sessions := make([]entity.FastingSession, 0, len(rows))
+	for _, row := range rows {
+		sessions = append(sessions, row.toEntity())
+	}
+	return sessions, nil
+}
+
+func (row fastingSessionRow) toEntity() entity.FastingSession {
+	session := entity.FastingSession{
+		ID:           row.ID,
+		Protocol:     row.Protocol,
+		StartedAt:    row.StartedAt,
+		PlannedHours: row.PlannedHours,
+		Note:         row.Note,
+		CreatedAt:    row.CreatedAt,
+	}
+	if row.EndedAt.Valid {
+		session.EndedAt = &row.EndedAt.Time
+	}
+	return session
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+// FavoriteRepo stores users' favorite foods as IDs with a snapshot of name and macros
+type FavoriteRepo struct {
+	db *sqlx.DB
+}
+
+// NewFavoriteRepo creates a new favorite food repository
+func NewFavoriteRepo(db *sqlx.DB) *FavoriteRepo {
+	return &FavoriteRepo{db: db}
+}
+
+// Upsert adds a favorite or replaces the default amount and snapshot of an existing one
+func (r *FavoriteRepo) Upsert(ctx context.Context, userID int64, favorite entity.FavoriteFood) error {
+	query := `
+        INSERT INTO food.favorite_foods (
+            user_id, food_id, food_name, brand_name, serving_id, amount, unit,
+            calories, carbs, protein, fat, created_at
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, CURRENT_TIMESTAMP)
+        ON CONFLICT (user_id, food_id) DO UPDATE
+        SET food_name = EXCLUDED.food_name,
+            brand_name = EXCLUDED.brand_name,
+            serving_id = EXCLUDED.serving_id,
+            amount = EXCLUDED.amount,
+            unit = EXCLUDED.unit,
+            calories = EXCLUDED.calories,
+            carbs = EXCLUDED.carbs,
+            protein = EXCLUDED.protein,
+            fat = EXCLUDED.fat
+    `
+
+	_, err := r.db.ExecContext(ctx, query,
+		userID, favorite.FoodID, favorite.FoodName, favorite.BrandName, favorite.ServingID, favorite.Amount, favorite.Unit,
+		favorite.Calories, favorite.Carbs, favorite.Protein, favorite.Fat,
+	)
+	if err != nil {
+		return fmt.Errorf("save favorite food error: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a favorite
+func (r *FavoriteRepo) Delete(ctx context.Context, userID int64, foodID string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM food.favorite_foods WHERE user_id = $1 AND food_id = $2`, userID, foodID)
+	if err != nil {
+		return fmt.Errorf("delete favorite food error: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("favorite food %s: %w", foodID, entity.ErrNotFound)
+	}
+	return nil
+}
+
+type favoriteRow struct {
+	FoodID    string    `db:"food_id"`
+	FoodName  string    `db:"food_name"`
+	BrandName string    `db:"brand_name"`
+	ServingID string    `db:"serving_id"`
+	Amount    float64   `db:"amount"`
+	Unit      string    `db:"unit"`
+	Calories  float64   `db:"calories"`
+	Carbs     float64   `db:"carbs"`
+	Protein   float64   `db:"protein"`
+	Fat       float64   `db:"fat"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// List returns a user's favorites alphabetically
+func (r *FavoriteRepo) List(ctx context.Context, userID int64) ([]entity.FavoriteFood, error) {
+	query := `
+        SELECT food_id, food_name, brand_name, serving_id, amount, unit,
+               calories, carbs, protein, fat, created_at
+        FROM food.favorite_foods
+        WHERE user_id = $1
+        ORDER BY lower(food_name), food_id
+    `
+
+	var rows []favoriteRow
+	if err := r.db.SelectContext(ctx, &rows, query, userID); err != nil {
+		return nil, fmt.Errorf("list favorite foods error: %w", err)
+	}
+
+	favorites := make([]entity.FavoriteFood, 0, len(rows))
+	for _, row := range rows {
+		favorites = append(favorites, entity.FavoriteFood{
+			FoodID:    row.FoodID,
+			FoodName:  row.FoodName,
+			BrandName: row.BrandName,
+			ServingID: row.ServingID,
+			Amount:    row.Amount,
+			Unit:      row.Unit,
+			Calories:  row.Calories,
+			Carbs:     row.Carbs,
+			Protein:   row.Protein,
+			Fat:       row.Fat,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	return favorites, nil
+}
@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+// GlucoseRepo stores blood glucose readings
+type GlucoseRepo struct {
+	db *sqlx.DB
+}
+
+// NewGlucoseRepo creates a new blood glucose repository
+func NewGlucoseRepo(db *sqlx.DB) *GlucoseRepo {
+	return &GlucoseRepo{db: db}
+}
+
+const glucoseReadingColumns = `id, mg_dl, context, measured_at, note, created_at`
+
+type glucoseReadingRow struct {
+	ID         int64     `db:"id"`
+	MgDl       float64   `db:"mg_dl"`
+	Context    string    `db:"context"`
+	MeasuredAt time.Time `db:"measured_at"`
+	Note       string    `db:"note"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+func (row glucoseReadingRow) toEntity() entity.GlucoseReading {
+	return entity.GlucoseReading{
+		ID:         row.ID,
+		MgDl:       row.MgDl,
+		MmolL:      math.Round(row.MgDl/entity.MgDlPerMmolL*10) / 10,
+		Context:    row.Context,
+		MeasuredAt: row.MeasuredAt,
+		Note:       row.Note,
+		CreatedAt:  row.CreatedAt,
+	}
+}
+
+// Create stores a reading and returns its ID
+func (r *GlucoseRepo) Create(ctx context.Context, userID int64, reading entity.GlucoseReading) (int64, error) {
+	query := `
+        INSERT INTO body.glucose_readings (user_id, mg_dl, context, measured_at, note, created_at)
+        VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
+        RETURNING id
+    `
+
+	var id int64
+	if err := r.db.QueryRowContext(ctx, query, userID, reading.MgDl, reading.Context, reading.MeasuredAt,
+		reading.Note).Scan(&id); err != nil {
+		return 0, fmt.Errorf("create glucose reading error: %w", err)
+	}
+	return id, nil
+}
+
+// Delete removes a reading
+func (r *GlucoseRepo) Delete(ctx context.Context, userID, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM body.glucose_readings WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("delete glucose reading error: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("glucose reading %d: %w", id, entity.ErrNotFound)
+	}
+	return nil
+}
+
+// Get returns a reading by ID
+func (r *GlucoseRepo) Get(ctx context.Context, userID, id int64) (*entity.GlucoseReading, error) {
+	query := `SELECT ` + glucoseReadingColumns + ` FROM body.glucose_readings WHERE id = $1 AND user_id = $2`
+
+	var row glucoseReadingRow
+	err := r.db.GetContext(ctx, &row, query, id, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("glucose reading %d: %w", id, entity.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get glucose reading error: %w", err)
+	}
+
+	reading := row.toEntity()
+	return &reading, nil
+}
+
+// List returns a user's readings measured in [from, to) in the order they were taken
+func (r *GlucoseRepo) List(ctx context.Context, userID int64, from, to time.Time) ([]entity.GlucoseReading, error) {
+	query := `
+        SELECT ` + glucoseReadingColumns + `
+        FROM body.glucose_readings
+        WHERE user_id = $1 AND measured_at >= $2 AND measured_at < $3
+        ORDER BY measured_at, id
+    `
+
+	var rows []glucoseReadingRow
+	if err := r.db.SelectContext(ctx, &rows, query, userID, from, to); err != nil {
+		return nil, fmt.Errorf("list glucose readings error: %w", err)
+	}
+
+	readings := make([]entity.GlucoseReading, 0, len(rows))
+	for _, row := range rows {
+		readings = append(readings, row.toEntity())
+	}
+	return readings, nil
+}
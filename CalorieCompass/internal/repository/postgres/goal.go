@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+// GoalRepo stores nutrition goals and their weekday targets
+type GoalRepo struct {
+	db *sqlx.DB
+}
+
+// NewGoalRepo creates a new nutrition goal repository
+func NewGoalRepo(db *sqlx.DB) *GoalRepo {
+	return &GoalRepo{db: db}
+}
+
+type goalRow struct {
+	ID            int64     `db:"id"`
+	EffectiveFrom time.Time `db:"effective_from"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+type goalDayRow struct {
+	GoalID   int64   `db:"goal_id"`
+	Weekday  int     `db:"weekday"`
+	Label    string  `db:"label"`
+	Preset   string  `db:"preset"`
+	Calories float64 `db:"calories"`
+	Carbs    float64 `db:"carbs"`
+	Protein  float64 `db:"protein"`
+	Fat      float64 `db:"fat"`
+	NetCarbs bool    `db:"net_carbs"`
+}
+
+// Save stores a goal with its weekday targets, replacing any goal of the user starting the
+// same date, and returns its ID
+func (r *GoalRepo) Save(ctx context.Context, userID int64, goal entity.NutritionGoal) (int64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction error: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO food.nutrition_goals (user_id, effective_from, created_at)
+        VALUES ($1, $2, CURRENT_TIMESTAMP)
+        ON CONFLICT (user_id, effective_from) DO UPDATE SET created_at = CURRENT_TIMESTAMP
+        RETURNING id
+    `
+
+	var id int64
+	if err := tx.QueryRowContext(ctx, query, userID, goal.EffectiveFrom).Scan(&id); err != nil {
+		return 0, fmt.Errorf("save nutrition goal error: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM food.nutrition_goal_days WHERE goal_id = $1`, id); err != nil {
+		return 0, fmt.Errorf("delete nutrition goal days error: %w", err)
+	}
+
+	dayQuery := `
+        INSERT INTO food.nutrition_goal_days (goal_id, weekday, label, preset, calories, carbs, protein, fat, net_carbs)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+    `
+	for weekday, day := range goal.Days {
+		t := day.Target
+		if _, err := tx.ExecContext(ctx, dayQuery, id, weekday, t.Label, t.Preset, t.Calories, t.Carbs, t.Protein,
+			t.Fat, t.NetCarbs); err != nil {
+			return 0, fmt.Errorf("create nutrition goal day error: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit transaction error: %w", err)
+	}
+
+	return id, nil
+}
+
+// Delete removes a goal; the previous goal then applies to its dates
+func (r *GoalRepo) Delete(ctx context.Context, userID, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM food.nutrition_goals WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("delete nutrition goal error: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("nutrition goal %d: %w", id, entity.ErrNotFound)
+	}
+	return nil
+}
+
+// GetByID returns a user's goal, or nil if it does not exist
+func (r *GoalRepo) GetByID(ctx context.Context, userID, id int64) (*entity.NutritionGoal, error) {
+	goals, err := r.load(ctx, `WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(goals) == 0 {
+		return nil, nil
+	}
+	return &goals[0], nil
+}
+
+// List returns a user's goal history, latest first
+func (r *GoalRepo) List(ctx context.Context, userID int64) ([]entity.NutritionGoal, error) {
+	return r.load(ctx, `WHERE user_id = $1 ORDER BY effective_from DESC`, userID)
+}
+
+// ListBetween returns the goals active at some point between two dates (inclusive): the
+// goal active on from and those starting later, oldest first
+func (r *GoalRepo) ListBetween(ctx context.Context, userID int64, from, to time.Time) ([]entity.NutritionGoal, error) {
+	where := `
+        WHERE user_id = $1 AND effective_from <= $3 AND effective_from >= COALESCE(
+            (SELECT MAX(effective_from) FROM food.nutrition_goals WHERE user_id = $1 AND effective_from <= $2),
+            $2)
+        ORDER BY effective_from
+    `
+	return r.load(ctx, where, userID, from.Format(entity.DateLayout), to.Format(entity.DateLayout))
+}
+
+// load fetches goals matching the given WHERE/ORDER clause together with their weekday targets
+func (r *GoalRepo) load(ctx context.Context, where string, args ...interface{}) ([]entity.NutritionGoal, error) {
+	query := `SELECT id, effective_from, created_at FROM food.nutrition_goals ` + where
+
+	var rows []goalRow
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("get nutrition goals error: %w", err)
+	}
+	if len(rows) == 0 {
+		return []entity.NutritionGoal{}, nil
+	}
+
+	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+
+	daysQuery, daysArgs, err := sqlx.In(`
+        SELECT goal_id, weekday, label, preset, calories, carbs, protein, fat, net_carbs
+        FROM food.nutrition_goal_days
+        WHERE goal_id IN (?)
+        ORDER BY goal_id, weekday
+    `, ids)
+	if err != nil {
+		return nil, fmt.Errorf("build nutrition goal days query error: %w", err)
+	}
+
+	var dayRows []goalDayRow
+	if err := r.db.SelectContext(ctx, &dayRows, r.db.Rebind(daysQuery), daysArgs...); err != nil {
+		return nil, fmt.Errorf("get nutrition goal days error: %w", err)
+	}
+
+	days := make(map[int64][]entity.GoalDay, len(rows))
+	for _, row := range dayRows {
+		days[row.GoalID] = append(days[row.GoalID], entity.GoalDay{
+			Weekday: entity.Weekdays[row.Weekday],
+			Target: entity.NutritionTarget{
+				Label:    row.Label,
+				Preset:   row.Preset,
+				Calories: row.Calories,
+				Carbs:    row.Carbs,
+				Protein:  row.Protein,
+				Fat:      row.Fat,
+				NetCarbs: row.NetCarbs,
+			},
+		})
+	}
+
+	goals := make([]entity.NutritionGoal, 0, len(rows))
+	for _, row := range rows {
+		goals = append(goals, entity.NutritionGoal{
+			ID:            row.ID,
+			EffectiveFrom: row.EffectiveFrom.Format(entity.DateLayout),
+			Days:          days[row.ID],
+			CreatedAt:     row.CreatedAt,
+		})
+	}
+
+	return goals, nil
+}
@@ -0,0 +1,371 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+// RecipeRepo stores user recipes with their ingredients and computed nutrition
+type RecipeRepo struct {
+	db *sqlx.DB
+}
+
+// NewRecipeRepo creates a new recipe repository
+func NewRecipeRepo(db *sqlx.DB) *RecipeRepo {
+	return &RecipeRepo{db: db}
+}
+
+// recipeNutrientRow holds the nutrient columns shared by recipes and their ingredients
+type recipeNutrientRow struct {
+	MetricAmount float64 `db:"metric_amount"`
+	MetricUnit   string  `db:"metric_unit"`
+	Calories     float64 `db:"calories"`
+	Carbs        float64 `db:"carbs"`
+	Protein      float64 `db:"protein"`
+	Fat          float64 `db:"fat"`
+	SaturatedFat float64 `db:"saturated_fat"`
+	Fiber        float64 `db:"fiber"`
+	Cholesterol  float64 `db:"cholesterol"`
+	Sodium       float64 `db:"sodium"`
+	Sugar        float64 `db:"sugar"`
+	Calcium      float64 `db:"calcium"`
+	Iron         float64 `db:"iron"`
+	Potassium    float64 `db:"potassium"`
+	VitaminA     float64 `db:"vitamin_a"`
+	VitaminC     float64 `db:"vitamin_c"`
+	VitaminD     float64 `db:"vitamin_d"`
+}
+
+type recipeRow struct {
+	ID           int64     `db:"id"`
+	Name         string    `db:"name"`
+	Servings     float64   `db:"servings"`
+	YieldAmount  float64   `db:"yield_amount"`
+	YieldUnit    string    `db:"yield_unit"`
+	Instructions string    `db:"instructions"`
+	ComputedAt   time.Time `db:"computed_at"`
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+	recipeNutrientRow
+}
+
+type recipeIngredientRow struct {
+	RecipeID  int64   `db:"recipe_id"`
+	FoodID    string  `db:"food_id"`
+	FoodName  string  `db:"food_name"`
+	BrandName string  `db:"brand_name"`
+	ServingID string  `db:"serving_id"`
+	Amount    float64 `db:"amount"`
+	Unit      string  `db:"unit"`
+	recipeNutrientRow
+}
+
+const recipeNutrientColumns = `metric_amount, metric_unit, calories, carbs, protein, fat,
+        saturated_fat, fiber, cholesterol, sodium, sugar, calcium, iron, potassium, vitamin_a,
+        vitamin_c, vitamin_d`
+
+// Create inserts a recipe with its ingredients and returns the new ID
+func (r *RecipeRepo) Create(ctx context.Context, userID int64, recipe entity.Recipe) (int64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction error: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO food.recipes (
+            user_id, name, servings, yield_amount, yield_unit, instructions,
+            ` + recipeNutrientColumns + `, computed_at, created_at, updated_at
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18,
+                $19, $20, $21, $22, $23, $24, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+        RETURNING id
+    `
+
+	args := append([]interface{}{userID, recipe.Name, recipe.Servings, recipe.YieldAmount, recipe.YieldUnit, recipe.Instructions},
+		nutrientArgs(recipe.Total)...)
+	args = append(args, recipe.ComputedAt)
+
+	var id int64
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		return 0, fmt.Errorf("create recipe error: %w", err)
+	}
+
+	if err := insertRecipeIngredients(ctx, tx, id, recipe.Ingredients); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit transaction error: %w", err)
+	}
+
+	return id, nil
+}
+
+// Update replaces a recipe's fields, ingredients and computed nutrition
+func (r *RecipeRepo) Update(ctx context.Context, userID, id int64, recipe entity.Recipe) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction error: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        UPDATE food.recipes
+        SET name = $1, servings = $2, yield_amount = $3, yield_unit = $4, instructions = $5,
+            metric_amount = $6, metric_unit = $7, calories = $8, carbs = $9, protein = $10, fat = $11,
+            saturated_fat = $12, fiber = $13, cholesterol = $14, sodium = $15, sugar = $16,
+            calcium = $17, iron = $18, potassium = $19, vitamin_a = $20, vitamin_c = $21, vitamin_d = $22,
+            computed_at = $23, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $24 AND user_id = $25
+    `
+
+	args := append([]interface{}{recipe.Name, recipe.Servings, recipe.YieldAmount, recipe.YieldUnit, recipe.Instructions},
+		nutrientArgs(recipe.Total)...)
+	args = append(args, recipe.ComputedAt, id, userID)
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("update recipe error: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("recipe %d: %w", id, entity.ErrNotFound)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM food.recipe_ingredients WHERE recipe_id = $1`, id); err != nil {
+		return fmt.Errorf("delete recipe ingredients error: %w", err)
+	}
+
+	if err := insertRecipeIngredients(ctx, tx, id, recipe.Ingredients); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction error: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a recipe and its ingredients
+func (r *RecipeRepo) Delete(ctx context.Context, userID, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM food.recipes WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("delete recipe error: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("recipe %d: %w", id, entity.ErrNotFound)
+	}
+	return nil
+}
+
+// GetByID returns a user's recipe with its ingredients, or nil if it does not exist
+func (r *RecipeRepo) GetByID(ctx context.Context, userID, id int64) (*entity.Recipe, error) {
+	recipes, err := r.load(ctx, `WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(recipes) == 0 {
+		return nil, nil
+	}
+	return &recipes[0], nil
+}
+
+// List returns all recipes owned by a user
+func (r *RecipeRepo) List(ctx context.Context, userID int64) ([]entity.Recipe, error) {
+	return r.load(ctx, `WHERE user_id = $1 ORDER BY name`, userID)
+}
+
+// FindByIngredient returns the IDs of a user's recipes that use the given food
+func (r *RecipeRepo) FindByIngredient(ctx context.Context, userID int64, foodID string) ([]int64, error) {
+	query := `
+        SELECT DISTINCT r.id
+        FROM food.recipes r
+        JOIN food.recipe_ingredients i ON i.recipe_id = r.id
+        WHERE r.user_id = $1 AND i.food_id = $2
+        ORDER BY r.id
+    `
+
+	var ids []int64
+	if err := r.db.SelectContext(ctx, &ids, query, userID, foodID); err != nil {
+		return nil, fmt.Errorf("find recipes by ingredient error: %w", err)
+	}
+	return ids, nil
+}
+
+// FindStale returns the IDs of a user's recipes with a catalog or custom food ingredient that
+// changed after the recipe was last computed, e.g. through a catalog import
+func (r *RecipeRepo) FindStale(ctx context.Context, userID int64) ([]int64, error) {
+	query := `
+        SELECT r.id
+        FROM food.recipes r
+        WHERE r.user_id = $1 AND EXISTS (
+            SELECT 1
+            FROM food.recipe_ingredients i
+            LEFT JOIN catalog.foods f
+                ON f.id = CASE WHEN split_part(i.food_id, ':', 1) IN ('` + entity.FoodSourceCatalog + `', '` +
+		entity.CatalogSourceUSDA + `', '` + entity.CatalogSourceOpenFoodFacts + `')
+                          THEN split_part(i.food_id, ':', 2)::BIGINT END
+            LEFT JOIN food.custom_foods c
+                ON c.user_id = r.user_id
+               AND c.id = CASE WHEN split_part(i.food_id, ':', 1) = '` + entity.FoodSourceCustom + `'
+                          THEN split_part(i.food_id, ':', 2)::BIGINT END
+            WHERE i.recipe_id = r.id AND (f.updated_at > r.computed_at OR c.updated_at > r.computed_at)
+        )
+        ORDER BY r.id
+    `
+
+	var ids []int64
+	if err := r.db.SelectContext(ctx, &ids, query, userID); err != nil {
+		return nil, fmt.Errorf("find stale recipes error: %w", err)
+	}
+	return ids, nil
+}
+
+// MarkChecked moves a recipe's computed_at to now without changing its values, so that
+// FindStale skips it until one of its ingredients changes again
+func (r *RecipeRepo) MarkChecked(ctx context.Context, userID, id int64) error {
+	query := `UPDATE food.recipes SET computed_at = CURRENT_TIMESTAMP WHERE id = $1 AND user_id = $2`
+	if _, err := r.db.ExecContext(ctx, query, id, userID); err != nil {
+		return fmt.Errorf("mark recipe checked error: %w", err)
+	}
+	return nil
+}
+
+// load fetches recipes matching the given WHERE/ORDER clause together with their ingredients
+func (r *RecipeRepo) load(ctx context.Context, where string, args ...interface{}) ([]entity.Recipe, error) {
+	query := `
+        SELECT id, name, servings, yield_amount, yield_unit, instructions,
+               ` + recipeNutrientColumns + `, computed_at, created_at, updated_at
+        FROM food.recipes
+        ` + where
+
+	var rows []recipeRow
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("get recipes error: %w", err)
+	}
+	if len(rows) == 0 {
+		return []entity.Recipe{}, nil
+	}
+
+	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+
+	ingredientsQuery, ingredientsArgs, err := sqlx.In(`
+        SELECT recipe_id, food_id, food_name, brand_name, serving_id, amount, unit,
+               `+recipeNutrientColumns+`
+        FROM food.recipe_ingredients
+        WHERE recipe_id IN (?)
+        ORDER BY recipe_id, position, id
+    `, ids)
+	if err != nil {
+		return nil, fmt.Errorf("build recipe ingredients query error: %w", err)
+	}
+
+	var ingredientRows []recipeIngredientRow
+	if err := r.db.SelectContext(ctx, &ingredientRows, r.db.Rebind(ingredientsQuery), ingredientsArgs...); err != nil {
+		return nil, fmt.Errorf("get recipe ingredients error: %w", err)
+	}
+
+	ingredients := make(map[int64][]entity.RecipeIngredient, len(rows))
+	for _, row := range ingredientRows {
+		nutrition := row.toServing()
+		nutrition.ID = row.ServingID
+		nutrition.Description = fmt.Sprintf("%s %s", strconv.FormatFloat(row.Amount, 'f', -1, 64), row.Unit)
+		ingredients[row.RecipeID] = append(ingredients[row.RecipeID], entity.RecipeIngredient{
+			FoodID:    row.FoodID,
+			FoodName:  row.FoodName,
+			BrandName: row.BrandName,
+			ServingID: row.ServingID,
+			Amount:    row.Amount,
+			Unit:      row.Unit,
+			Nutrition: nutrition,
+		})
+	}
+
+	recipes := make([]entity.Recipe, 0, len(rows))
+	for _, row := range rows {
+		recipeIngredients := ingredients[row.ID]
+		if recipeIngredients == nil {
+			recipeIngredients = make([]entity.RecipeIngredient, 0)
+		}
+
+		recipe := entity.Recipe{
+			ID:           entity.FoodSourceRecipe + ":" + strconv.FormatInt(row.ID, 10),
+			Name:         row.Name,
+			Servings:     row.Servings,
+			YieldAmount:  row.YieldAmount,
+			YieldUnit:    row.YieldUnit,
+			Instructions: row.Instructions,
+			Ingredients:  recipeIngredients,
+			Total:        row.toServing(),
+			ComputedAt:   row.ComputedAt,
+			CreatedAt:    row.CreatedAt,
+			UpdatedAt:    row.UpdatedAt,
+		}
+		recipe.Total.Description = "whole recipe"
+		recipes = append(recipes, recipe)
+	}
+
+	return recipes, nil
+}
+
+func insertRecipeIngredients(ctx context.Context, tx *sqlx.Tx, recipeID int64, ingredients []entity.RecipeIngredient) error {
+	query := `
+        INSERT INTO food.recipe_ingredients (
+            recipe_id, position, food_id, food_name, brand_name, serving_id, amount, unit,
+            ` + recipeNutrientColumns + `
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19,
+                $20, $21, $22, $23, $24, $25)
+    `
+
+	for i, ingredient := range ingredients {
+		args := append([]interface{}{recipeID, i, ingredient.FoodID, ingredient.FoodName, ingredient.BrandName,
+			ingredient.ServingID, ingredient.Amount, ingredient.Unit}, nutrientArgs(ingredient.Nutrition)...)
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("create recipe ingredient error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// nutrientArgs lists a serving's values in recipeNutrientColumns order
+func nutrientArgs(s entity.Serving) []interface{} {
+	return []interface{}{
+		s.MetricServingAmount, s.MetricServingUnit, s.Calories, s.Carbs, s.Protein, s.Fat,
+		s.SaturatedFat, s.Fiber, s.Cholesterol, s.Sodium, s.Sugar, s.Calcium, s.Iron, s.Potassium, s.VitaminA,
+		s.VitaminC, s.VitaminD,
+	}
+}
+
+func (row recipeNutrientRow) toServing() entity.Serving {
+	return entity.Serving{
+		MetricServingAmount: row.MetricAmount,
+		MetricServingUnit:   row.MetricUnit,
+		NumberOfUnits:       1,
+		Calories:            row.Calories,
+		Carbs:               row.Carbs,
+		Protein:             row.Protein,
+		Fat:                 row.Fat,
+		SaturatedFat:        row.SaturatedFat,
+		Fiber:               row.Fiber,
+		Cholesterol:         row.Cholesterol,
+		Sodium:              row.Sodium,
+		Sugar:               row.Sugar,
+		Calcium:             row.Calcium,
+		Iron:                row.Iron,
+		Potassium:           row.Potassium,
+		VitaminA:            row.VitaminA,
+		VitaminC:            row.VitaminC,
+		VitaminD:            row.VitaminD,
+	}
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+// ReportRepo runs the aggregations behind nutrition reports
+type ReportRepo struct {
+	db *sqlx.DB
+}
+
+// NewReportRepo creates a new report repository
+func NewReportRepo(db *sqlx.DB) *ReportRepo {
+	return &ReportRepo{db: db}
+}
+
+type topFoodRow struct {
+	Nutrient string  `db:"nutrient"`
+	FoodID   string  `db:"food_id"`
+	FoodName string  `db:"food_name"`
+	Entries  int     `db:"entries"`
+	Amount   float64 `db:"amount"`
+	Share    float64 `db:"share"`
+}
+
+type dailyBurnedRow struct {
+	Date     time.Time `db:"date"`
+	Calories float64   `db:"calories"`
+}
+
+// reportNutrients are the nutrients top foods are ranked for, in report order
+var reportNutrients = []string{
+	"calories", "carbs", "protein", "fat", "saturated_fat", "fiber", "sugar", "sodium", "cholesterol",
+	"calcium", "iron", "potassium", "vitamin_a", "vitamin_c", "vitamin_d",
+}
+
+// TopFoods ranks the foods logged between two dates (inclusive) by their contribution to
+// each nutrient and returns up to limit foods per nutrient with their share of the total
+func (r *ReportRepo) TopFoods(ctx context.Context, userID int64, from, to time.Time, limit int) ([]entity.NutrientTopFoods, error) {
+	query := `
+        WITH foods AS (
+            SELECT food_id, (array_agg(food_name ORDER BY eaten_at DESC))[1] AS food_name, COUNT(*) AS entries,
+                   SUM(calories) AS calories, SUM(carbs) AS carbs, SUM(protein) AS protein, SUM(fat) AS fat,
+                   SUM(saturated_fat) AS saturated_fat, SUM(fiber) AS fiber, SUM(sugar) AS sugar,
+                   SUM(sodium) AS sodium, SUM(cholesterol) AS cholesterol, SUM(calcium) AS calcium,
+                   SUM(iron) AS iron, SUM(potassium) AS potassium, SUM(vitamin_a) AS vitamin_a,
+                   SUM(vitamin_c) AS vitamin_c, SUM(vitamin_d) AS vitamin_d
+            FROM food.diary_entries
+            WHERE user_id = $1 AND date BETWEEN $2 AND $3
+            GROUP BY food_id
+        ),
+        ranked AS (
+            SELECT n.nutrient, f.food_id, f.food_name, f.entries, n.amount,
+                   n.amount / NULLIF(SUM(n.amount) OVER (PARTITION BY n.nutrient), 0) * 100 AS share,
+                   ROW_NUMBER() OVER (PARTITION BY n.nutrient ORDER BY n.amount DESC, f.food_name) AS rank
+            FROM foods f
+            CROSS JOIN LATERAL (VALUES
+                ('calories', f.calories), ('carbs', f.carbs), ('protein', f.protein), ('fat', f.fat),
+                ('saturated_fat', f.saturated_fat), ('fiber', f.fiber), ('sugar', f.sugar),
+                ('sodium', f.sodium), ('cholesterol', f.cholesterol), ('calcium', f.calcium),
+                ('iron', f.iron), ('potassium', f.potassium), ('vitamin_a', f.vitamin_a),
+                ('vitamin_c', f.vitamin_c), ('vitamin_d', f.vitamin_d)
+            ) AS n(nutrient, amount)
+        )
+        SELECT nutrient, food_id, food_name, entries, ROUND(amount, 1) AS amount, ROUND(COALESCE(share, 0), 1) AS share
+        FROM ranked
+        WHERE rank <= $4 AND amount > 0
+        ORDER BY nutrient, rank
+    `
+
+	var rows []topFoodRow
+	err := r.db.SelectContext(ctx, &rows, query, userID, from.Format(entity.DateLayout), to.Format(entity.DateLayout), limit)
+	if err != nil {
+		return nil, fmt.Errorf("get top foods error: %w", err)
+	}
+
+	foods := make(map[string][]entity.TopFood, len(reportNutrients))
+	for _, row := range rows {
+		foods[row.Nutrient] = append(foods[row.Nutrient], entity.TopFood{
+			FoodID:   row.FoodID,
+			FoodName: row.FoodName,
+			Entries:  row.Entries,
+			Amount:   row.Amount,
+			Share:    row.Share,
+		})
+	}
+
+	result := make([]entity.NutrientTopFoods, 0, len(reportNutrients))
+	for _, nutrient := range reportNutrients {
+		nutrientFoods := foods[nutrient]
+		if nutrientFoods == nil {
+			nutrientFoods = make([]entity.TopFood, 0)
+		}
+		result = append(result, entity.NutrientTopFoods{Nutrient: nutrient, Foods: nutrientFoods})
+	}
+	return result, nil
+}
+
+// DailyBurned sums the calories burned by logged exercise per date between two dates
+// (inclusive), keyed by date. Dates without exercise are omitted.
+func (r *ReportRepo) DailyBurned(ctx context.Context, userID int64, from, to time.Time) (map[string]float64, error) {
+	query := `
+        SELECT date, SUM(calories) AS calories
+        FROM activity.exercise_entries
+        WHERE user_id = $1 AND date BETWEEN $2 AND $3
+        GROUP BY date
+    `
+
+	var rows []dailyBurnedRow
+	err := r.db.SelectContext(ctx, &rows, query, userID, from.Format(entity.DateLayout), to.Format(entity.DateLayout))
+	if err != nil {
+		return nil, fmt.Errorf("get daily burned calories error: %w", err)
+	}
+
+	burned := make(map[string]float64, len(rows))
+	for _, row := range rows {
+		burned[row.Date.Format(entity.DateLayout)] = row.Calories
+	}
+	return burned, nil
+}
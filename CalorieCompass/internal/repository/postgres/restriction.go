@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// RestrictionRepo stores users' allergen and diet restriction profiles
+type RestrictionRepo struct {
+	db *sqlx.DB
+}
+
+// NewRestrictionRepo creates a new restriction profile repository
+func NewRestrictionRepo(db *sqlx.DB) *RestrictionRepo {
+	return &RestrictionRepo{db: db}
+}
+
+// Get returns the user's restriction profile, an empty one when never saved
+func (r *RestrictionRepo) Get(ctx context.Context, userID int64) (*entity.RestrictionProfile, error) {
+	var allergens, diets pq.StringArray
+	var updatedAt time.Time
+	profile := entity.RestrictionProfile{}
+	err := r.db.QueryRowContext(ctx,
+		`SELECT allergens, diets, strict, updated_at FROM food.restriction_profiles WHERE user_id = $1`, userID).
+		Scan(&allergens, &diets, &profile.Strict, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &entity.RestrictionProfile{Allergens: []string{}, Diets: []string{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get restriction profile error: %w", err)
+	}
+
+	profile.Allergens = []string(allergens)
+	profile.Diets = []string(diets)
+	profile.UpdatedAt = &updatedAt
+	return &profile, nil
+}
+
+// Save replaces the user's restriction profile
+func (r *RestrictionRepo) Save(ctx context.Context, userID int64, profile entity.RestrictionProfile) error {
+	query := `
+        INSERT INTO food.restriction_profiles (user_id, allergens, diets, strict, updated_at)
+        VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+        ON CONFLICT (user_id) DO UPDATE
+        SET allergens = EXCLUDED.allergens, diets = EXCLUDED.diets, strict = EXCLUDED.strict,
+            updated_at = CURRENT_TIMESTAMP
+    `
+
+	if _, err := r.db.ExecContext(ctx, query, userID, pq.Array(profile.Allergens), pq.Array(profile.Diets),
+		profile.Strict); err != nil {
+		return fmt.Errorf("save restriction profile error: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the user's restriction profile
+func (r *RestrictionRepo) Delete(ctx context.Context, userID int64) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM food.restriction_profiles WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("delete restriction profile error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+// SavedMealRepo stores saved meals and their items
+type SavedMealRepo struct {
+	db *sqlx.DB
+}
+
+// NewSavedMealRepo creates a new saved meal repository
+func NewSavedMealRepo(db *sqlx.DB) *SavedMealRepo {
+	return &SavedMealRepo{db: db}
+}
+
+type savedMealRow struct {
+	ID        int64     `db:"id"`
+	Name      string    `db:"name"`
+	Meal      string    `db:"meal"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type savedMealItemRow struct {
+	SavedMealID int64   `db:"saved_meal_id"`
+	FoodID      string  `db:"food_id"`
+	FoodName    string  `db:"food_name"`
+	BrandName   string  `db:"brand_name"`
+	ServingID   string  `db:"serving_id"`
+	Amount      float64 `db:"amount"`
+	Unit        string  `db:"unit"`
+	recipeNutrientRow
+}
+
+// Create inserts a saved meal with its items and returns the new ID
+func (r *SavedMealRepo) Create(ctx context.Context, userID int64, meal entity.SavedMeal) (int64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction error: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO food.saved_meals (user_id, name, meal, created_at, updated_at)
+        VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+        RETURNING id
+    `
+
+	var id int64
+	if err := tx.QueryRowContext(ctx, query, userID, meal.Name, meal.Meal).Scan(&id); err != nil {
+		return 0, fmt.Errorf("create saved meal error: %w", err)
+	}
+
+	if err := insertSavedMealItems(ctx, tx, id, meal.Items); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit transaction error: %w", err)
+	}
+
+	return id, nil
+}
+
+// Update replaces a saved meal's name, slot and items
+func (r *SavedMealRepo) Update(ctx context.Context, userID, id int64, meal entity.SavedMeal) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction error: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        UPDATE food.saved_meals
+        SET name = $1, meal = $2, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $3 AND user_id = $4
+    `
+
+	result, err := tx.ExecContext(ctx, query, meal.Name, meal.Meal, id, userID)
+	if err != nil {
+		return fmt.Errorf("update saved meal error: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("saved meal %d: %w", id, entity.ErrNotFound)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM food.saved_meal_items WHERE saved_meal_id = $1`, id); err != nil {
+		return fmt.Errorf("delete saved meal items error: %w", err)
+	}
+
+	if err := insertSavedMealItems(ctx, tx, id, meal.Items); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction error: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a saved meal and its items. Diary entries logged from it are kept.
+func (r *SavedMealRepo) Delete(ctx context.Context, userID, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM food.saved_meals WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("delete saved meal error: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("saved meal %d: %w", id, entity.ErrNotFound)
+	}
+	return nil
+}
+
+// GetByID returns a user's saved meal with its items, or nil if it does not exist
+func (r *SavedMealRepo) GetByID(ctx context.Context, userID, id int64) (*entity.SavedMeal, error) {
+	meals, err := r.load(ctx, `WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(meals) == 0 {
+		return nil, nil
+	}
+	return &meals[0], nil
+}
+
+// List returns all saved meals of a user
+func (r *SavedMealRepo) List(ctx context.Context, userID int64) ([]entity.SavedMeal, error) {
+	return r.load(ctx, `WHERE user_id = $1 ORDER BY name, id`, userID)
+}
+
+// load fetches saved meals matching the given WHERE/ORDER clause together with their items
+func (r *SavedMealRepo) load(ctx context.Context, where string, args ...interface{}) ([]entity.SavedMeal, error) {
+	query := `SELECT id, name, meal, created_at, updated_at FROM food.saved_meals ` + where
+
+	var rows []savedMealRow
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("get saved meals error: %w", err)
+	}
+	if len(rows) == 0 {
+		return []entity.SavedMeal{}, nil
+	}
+
+	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+
+	itemsQuery, itemsArgs, err := sqlx.In(`
+        SELECT saved_meal_id, food_id, food_name, brand_name, serving_id, amount, unit,
+               `+recipeNutrientColumns+`
+        FROM food.saved_meal_items
+        WHERE saved_meal_id IN (?)
+        ORDER BY saved_meal_id, position, id
+    `, ids)
+	if err != nil {
+		return nil, fmt.Errorf("build saved meal items query error: %w", err)
+	}
+
+	var itemRows []savedMealItemRow
+	if err := r.db.SelectContext(ctx, &itemRows, r.db.Rebind(itemsQuery), itemsArgs...); err != nil {
+		return nil, fmt.Errorf("get saved meal items error: %w", err)
+	}
+
+	items := make(map[int64][]entity.SavedMealItem, len(rows))
+	for _, row := range itemRows {
+		nutrition := row.toServing()
+		nutrition.ID = row.ServingID
+		nutrition.Description = fmt.Sprintf("%s %s", strconv.FormatFloat(row.Amount, 'f', -1, 64), row.Unit)
+		items[row.SavedMealID] = append(items[row.SavedMealID], entity.SavedMealItem{
+			FoodID:    row.FoodID,
+			FoodName:  row.FoodName,
+			BrandName: row.BrandName,
+			ServingID: row.ServingID,
+			Amount:    row.Amount,
+			Unit:      row.Unit,
+			Nutrition: nutrition,
+		})
+	}
+
+	meals := make([]entity.SavedMeal, 0, len(rows))
+	for _, row := range rows {
+		mealItems := items[row.ID]
+		if mealItems == nil {
+			mealItems = make([]entity.SavedMealItem, 0)
+		}
+		meals = append(meals, entity.SavedMeal{
+			ID:        row.ID,
+			Name:      row.Name,
+			Meal:      row.Meal,
+			Items:     mealItems,
+			CreatedAt: row.CreatedAt,
+			UpdatedAt: row.UpdatedAt,
+		})
+	}
+
+	return meals, nil
+}
+
+func insertSavedMealItems(ctx context.Context, tx *sqlx.Tx, savedMealID int64, items []entity.SavedMealItem) error {
+	query := `
+        INSERT INTO food.saved_meal_items (
+            saved_meal_id, position, food_id, food_name, brand_name, serving_id, amount, unit,
+            ` + recipeNutrientColumns + `
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19,
+                $20, $21, $22, $23, $24, $25)
+    `
+
+	for i, item := range items {
+		args := append([]interface{}{savedMealID, i, item.FoodID, item.FoodName, item.BrandName,
+			item.ServingID, item.Amount, item.Unit}, nutrientArgs(item.Nutrition)...)
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("create saved meal item error: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+// WaterRepo stores water entries, user-defined containers and water settings
+type WaterRepo struct {
+	db *sqlx.DB
+}
+
+// NewWaterRepo creates a new water repository
+func NewWaterRepo(db *sqlx.DB) *WaterRepo {
+	return &WaterRepo{db: db}
+}
+
+type waterEntryRow struct {
+	ID        int64     `db:"id"`
+	Date      time.Time `db:"date"`
+	AmountMl  float64   `db:"amount_ml"`
+	Container string    `db:"container"`
+	DrunkAt   time.Time `db:"drunk_at"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type waterContainerRow struct {
+	ID       int64   `db:"id"`
+	Name     string  `db:"name"`
+	VolumeMl float64 `db:"volume_ml"`
+}
+
+const waterEntryColumns = `id, date, amount_ml, container, drunk_at, created_at`
+
+// CreateEntry logs water
+func (r *WaterRepo) CreateEntry(ctx context.Context, userID int64, entry entity.WaterEntry) (int64, error) {
+	query := `
+        INSERT INTO body.water_entries (user_id, date, amount_ml, container, drunk_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
+        RETURNING id
+    `
+
+	var id int64
+	err := r.db.QueryRowContext(ctx, query, userID, entry.Date, entry.Amount, entry.Container, entry.DrunkAt).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("create water entry error: %w", err)
+	}
+	return id, nil
+}
+
+// DeleteEntry removes a water entry
+func (r *WaterRepo) DeleteEntry(ctx context.Context, userID, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM body.water_entries WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("delete water entry error: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("water entry %d: %w", id, entity.ErrNotFound)
+	}
+	return nil
+}
+
+// GetEntry returns a water entry, or an entity.ErrNotFound error
+func (r *WaterRepo) GetEntry(ctx context.Context, userID, id int64) (*entity.WaterEntry, error) {
+	query := `SELECT ` + waterEntryColumns + ` FROM body.water_entries WHERE id = $1 AND user_id = $2`
+
+	var row waterEntryRow
+	err := r.db.GetContext(ctx, &row, query, id, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("water entry %d: %w", id, entity.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get water entry error: %w", err)
+	}
+
+	entry := row.toEntity()
+	return &entry, nil
+}
+
+// ListEntries returns the water logged between two dates (inclusive) in the order it was drunk
+func (r *WaterRepo) ListEntries(ctx context.Context, userID int64, from, to time.Time) ([]entity.WaterEntry, error) {
+	query := `
+        SELECT ` + waterEntryColumns + `
+        FROM body.water_entries
+        WHERE user_id = $1 AND date BETWEEN $2 AND $3
+        ORDER BY date, drunk_at, id
+    `
+
+	var rows []waterEntryRow
+	err := r.db.SelectContext(ctx, &rows, query, userID, from.Format(entity.DateLayout), to.Format(entity.DateLayout))
+	if err != nil {
+		return nil, fmt.Errorf("list water entries error: %w", err)
+	}
+
+	entries := make([]entity.WaterEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, row.toEntity())
+	}
+	return entries, nil
+}
+
+// ListContainers returns the user's containers ordered by volume
+func (r *WaterRepo) ListContainers(ctx context.Context, userID int64) ([]entity.WaterContainer, error) {
+	query := `SELECT id, name, volume_ml FROM body.water_containers WHERE user_id = $1 ORDER BY volume_ml, name`
+
+	var rows []waterContainerRow
+	if err := r.db.SelectContext(ctx, &rows, query, userID); err != nil {
+		return nil, fmt.Errorf("list water containers error: %w", err)
+	}
+
+	containers := make([]entity.WaterContainer, 0, len(rows))
+	for _, row := range rows {
+		containers = append(containers, entity.WaterContainer{
+			ID:     row.ID,
+			Name:   row.Name,
+			Volume: row.VolumeMl,
+			Custom: true,
+		})
+	}
+	return containers, nil
+}
+
+// CreateContainer stores a user-defined container
+func (r *WaterRepo) CreateContainer(ctx context.Context, userID int64, container entity.WaterContainer) (int64, error) {
+	query := `
+        INSERT INTO body.water_containers (user_id, name, volume_ml, created_at)
+        VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+        RETURNING id
+    `
+
+	var id int64
+	if err := r.db.QueryRowContext(ctx, query, userID, container.Name, container.Volume).Scan(&id); err != nil {
+		return 0, fmt.Errorf("create water container error: %w", err)
+	}
+	return id, nil
+}
+
+// DeleteContainer removes a user-defined container; logged water keeps its amount
+func (r *WaterRepo) DeleteContainer(ctx context.Context, userID, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM body.water_containers WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("delete water container error: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("water container %d: %w", id, entity.ErrNotFound)
+	}
+	return nil
+}
+
+// GetSettings returns the user's water settings, the defaults when never saved
+func (r *WaterRepo) GetSettings(ctx context.Context, userID int64) (*entity.WaterSettings, error) {
+	var target sql.NullFloat64
+	var settings entity.WaterSettings
+	err := r.db.QueryRowContext(ctx, `SELECT target_ml, updated_at FROM body.water_settings WHERE user_id = $1`, userID).
+		Scan(&target, &settings.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &entity.WaterSettings{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get water settings error: %w", err)
+	}
+	if target.Valid {
+		settings.Target = &target.Float64
+	}
+	return &settings, nil
+}
+
+// SaveSettings replaces the user's water settings
+func (r *WaterRepo) SaveSettings(ctx context.Context, userID int64, settings entity.WaterSettings) error {
+	query := `
+        INSERT INTO body.water_settings (user_id, target_ml, updated_at)
+        VALUES ($1, $2, CURRENT_TIMESTAMP)
+        ON CONFLICT (user_id) DO UPDATE
+        SET target_ml = EXCLUDED.target_ml, updated_at = CURRENT_TIMESTAMP
+    `
+
+	if _, err := r.db.ExecContext(ctx, query, userID, settings.Target); err != nil {
+		return fmt.Errorf("save water settings error: %w", err)
+	}
+	return nil
+}
+
+func (row waterEntryRow) toEntity() entity.WaterEntry {
+	return entity.WaterEntry{
+		ID:        row.ID,
+		Date:      row.Date.Format(entity.DateLayout),
+		Amount:    row.AmountMl,
+		Container: row.Container,
+		DrunkAt:   row.DrunkAt,
+		CreatedAt: row.CreatedAt,
+	}
+}
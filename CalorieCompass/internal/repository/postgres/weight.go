@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+// WeightRepo stores body weight entries and weight goals
+type WeightRepo struct {
+	db *sqlx.DB
+}
+
+// NewWeightRepo creates a new weight repository
+func NewWeightRepo(db *sqlx.DB) *WeightRepo {
+	return &WeightRepo{db: db}
+}
+
+type weightEntryRow struct {
+	ID        int64     `db:"id"`
+	Date      time.Time `db:"date"`
+	WeightKg  float64   `db:"weight_kg"`
+	Note      string    `db:"note"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Upsert records a weight for a date, replacing the weight already recorded that day
+func (r *WeightRepo) Upsert(ctx context.Context, userID int64, entry entity.WeightEntry) (int64, error) {
+	query := `
+        INSERT INTO body.weight_entries (user_id, date, weight_kg, note, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+        ON CONFLICT (user_id, date) DO UPDATE
+        SET weight_kg = EXCLUDED.weight_kg, note = EXCLUDED.note, updated_at = CURRENT_TIMESTAMP
+        RETURNING id
+    `
+
+	var id int64
+	if err := r.db.QueryRowContext(ctx, query, userID, entry.Date, entry.Weight, entry.Note).Scan(&id); err != nil {
+		return 0, fmt.Errorf("save weight entry error: %w", err)
+	}
+	return id, nil
+}
+
+// Delete removes a weight entry
+func (r *WeightRepo) Delete(ctx context.Context, userID, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM body.weight_entries WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("delete weight entry error: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("weight entry %d: %w", id, entity.ErrNotFound)
+	}
+	return nil
+}
+
+// GetByID returns a user's weight entry, or an entity.ErrNotFound error
+func (r *WeightRepo) GetByID(ctx context.Context, userID, id int64) (*entity.WeightEntry, error) {
+	query := `
+        SELECT id, date, weight_kg, note, created_at, updated_at
+        FROM body.weight_entries
+        WHERE id = $1 AND user_id = $2
+    `
+
+	var row weightEntryRow
+	err := r.db.GetContext(ctx, &row, query, id, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("weight entry %d: %w", id, entity.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get weight entry error: %w", err)
+	}
+
+	entry := row.toEntity()
+	return &entry, nil
+}
+
+// List returns a user's weight entries between two dates (inclusive) in date order. A zero
+// from returns the whole history up to to.
+func (r *WeightRepo) List(ctx context.Context, userID int64, from, to time.Time) ([]entity.WeightEntry, error) {
+	query := `
+        SELECT id, date, weight_kg, note, created_at, updated_at
+        FROM body.weight_entries
+        WHERE user_id = $1 AND ($2::date IS NULL OR date >= $2) AND date <= $3
+        ORDER BY date
+    `
+
+	var fromArg interface{}
+	if !from.IsZero() {
+		fromArg = from.Format(entity.DateLayout)
+	}
+
+	var rows []weightEntryRow
+	if err := r.db.SelectContext(ctx, &rows, query, userID, fromArg, to.Format(entity.DateLayout)); err != nil {
+		return nil, fmt.Errorf("list weight entries error: %w", err)
+	}
+
+	entries := make([]entity.WeightEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, row.toEntity())
+	}
+	return entries, nil
+}
+
+// Latest returns the user's last weight recorded on or before a date, or nil if none is
+func (r *WeightRepo) Latest(ctx context.Context, userID int64, date time.Time) (*entity.WeightEntry, error) {
+	query := `
+        SELECT id, date, weight_kg, note, created_at, updated_at
+        FROM body.weight_entries
+        WHERE user_id = $1 AND date <= $2
+        ORDER BY date DESC
+        LIMIT 1
+    `
+
+	var row weightEntryRow
+	err := r.db.GetContext(ctx, &row, query, userID, date.Format(entity.DateLayout))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get latest weight entry error: %w", err)
+	}
+
+	entry := row.toEntity()
+	return &entry, nil
+}
+
+// GetGoal returns the user's weight goal, or nil if none is set
+func (r *WeightRepo) GetGoal(ctx context.Context, userID int64) (*entity.WeightGoal, error) {
+	var goal entity.WeightGoal
+	err := r.db.QueryRowContext(ctx, `SELECT target_kg, updated_at FROM body.weight_goals WHERE user_id = $1`, userID).
+		Scan(&goal.TargetWeight, &goal.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get weight goal error: %w", err)
+	}
+	return &goal, nil
+}
+
+// SetGoal sets the user's target weight
+func (r *WeightRepo) SetGoal(ctx context.Context, userID int64, targetKg float64) error {
+	query := `
+        INSERT INTO body.weight_goals (user_id, target_kg, updated_at)
+        VALUES ($1, $2, CURRENT_TIMESTAMP)
+        ON CONFLICT (user_id) DO UPDATE
+        SET target_kg = EXCLUDED.target_kg, updated_at = CURRENT_TIMESTAMP
+    `
+
+	if _, err := r.db.ExecContext(ctx, query, userID, targetKg); err != nil {
+		return fmt.Errorf("set weight goal error: %w", err)
+	}
+	return nil
+}
+
+// DeleteGoal removes the user's target weight
+func (r *WeightRepo) DeleteGoal(ctx context.Context, userID int64) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM body.weight_goals WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("delete weight goal error: %w", err)
+	}
+	return nil
+}
+
+func (row weightEntryRow) toEntity() entity.WeightEntry {
+	return entity.WeightEntry{
+		ID:        row.ID,
+		Date:      row.Date.Format(entity.DateLayout),
+		Weight:    row.WeightKg,
+		Note:      row.Note,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
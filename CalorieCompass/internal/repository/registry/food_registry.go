@@ -0,0 +1,475 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/nutriscore"
+	"CalorieCompass/internal/pkg/nutrition"
+	"CalorieCompass/internal/pkg/restriction"
+)
+
+const defaultTimeout = 5 * time.Second
+
+// FoodSearcher is a provider that can only be searched, e.g. the user's custom foods,
+// whose details the food use case resolves itself
+type FoodSearcher interface {
+	SearchFoods(ctx context.Context, request entity.FoodSearchRequest) ([]entity.Food, int, error)
+}
+
+// FoodSource is a single food data provider, e.g. FatSecret or the local catalog
+type FoodSource interface {
+	FoodSearcher
+	GetFoodDetails(ctx context.Context, foodID string) (*entity.FoodDetails, error)
+}
+
+type provider struct {
+	name     string
+	priority int
+	timeout  time.Duration
+	source   FoodSearcher
+}
+
+// FoodRegistry fans food searches out to several providers and routes detail lookups
+// by the namespace prefix of the food ID (e.g. "fs:456"). It implements usecase.FoodRepository.
+type FoodRegistry struct {
+	defaultSource string
+	providers     []provider
+}
+
+// NewFoodRegistry creates an empty registry; bare food IDs are resolved against defaultSource
+func NewFoodRegistry(defaultSource string) *FoodRegistry {
+	return &FoodRegistry{
+		defaultSource: defaultSource,
+	}
+}
+
+// Register adds a provider under the given namespace. Lower priority values win when
+// results are merged. A zero timeout uses the default.
+func (r *FoodRegistry) Register(name string, priority int, timeout time.Duration, source FoodSource) {
+	r.RegisterSearch(name, priority, timeout, source)
+}
+
+// RegisterSearch adds a provider that is searched but never asked for details; IDs in its
+// namespace are not resolved by the registry
+func (r *FoodRegistry) RegisterSearch(name string, priority int, timeout time.Duration, source FoodSearcher) {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	r.providers = append(r.providers, provider{
+		name:     name,
+		priority: priority,
+		timeout:  timeout,
+		source:   source,
+	})
+	sort.SliceStable(r.providers, func(i, j int) bool {
+		return r.providers[i].priority < r.providers[j].priority
+	})
+}
+
+// Sources returns the registered provider names in priority order
+func (r *FoodRegistry) Sources() []string {
+	names := make([]string, 0, len(r.providers))
+	for _, p := range r.providers {
+		names = append(names, p.name)
+	}
+	return names
+}
+
+// filterAware is implemented by sources that apply search filters and sorting themselves
+type filterAware interface {
+	AppliesFilters() bool
+}
+
+// detailWorkers bounds concurrent detail lookups when post-filtering a provider's results
+const detailWorkers = 5
+
+// maxDetailLookups bounds the detail lookups a single search makes per provider. Foods past
+// it cannot be checked against nutrient filters and are left out. It also bounds how many
+// results of a provider that cannot sort itself are sorted: whatever the page, the pages
+// holding its first maxDetailLookups results are read and ranked together, so that a
+// deeper page never holds a food that ranks before one on an earlier page.
+const maxDetailLookups = 50
+
+// MaxSearchWindow bounds how deep into the merged results a search may page: page and
+// limit together may not reach past this many results
+const MaxSearchWindow = 500
+
+// unranked is the sort key of foods that cannot be placed in the requested order: those
+// listed only per serving when sorting by calories, whose serving is never compared with
+// per-100 g values, and those read past the ranked results of a provider that cannot sort
+// itself. They follow the ranked foods in their provider's order.
+var unranked = math.Inf(1)
+
+type searchResult struct {
+	foods []entity.Food
+	// keys holds each food's sort key, ascending in the requested order; zero for relevance
+	keys  []float64
+	total int
+	err   error
+}
+
+// SearchFoods merges the results of every provider into one list and returns the requested
+// page of it. Providers are queried concurrently, each under its own timeout, for their
+// first (page+1)*limit results; these are merged in the requested order, taking the
+// providers' results alternately in priority order for relevance, and deduplicated by name
+// and brand. Every page therefore holds at most limit foods and a food is never repeated on
+// a later page. Foods are flagged against request.Restrictions as they are fetched, and
+// left out under a strict profile. The total is the providers' combined total, scaled for
+// the foods the filters and the profile left out, less the duplicates found so far.
+// A failing provider is logged and skipped; an error is returned only when every provider
+// fails.
+func (r *FoodRegistry) SearchFoods(ctx context.Context, request entity.FoodSearchRequest) ([]entity.Food, int, error) {
+	if len(r.providers) == 0 {
+		return nil, 0, fmt.Errorf("no food providers configured")
+	}
+	if request.Page < 0 || request.Limit <= 0 {
+		return nil, 0, fmt.Errorf("%w: page must not be negative and limit must be positive", entity.ErrInvalidInput)
+	}
+	window := (request.Page + 1) * request.Limit
+	if window > MaxSearchWindow {
+		return nil, 0, fmt.Errorf("%w: only the first %d results can be paged through", entity.ErrInvalidInput, MaxSearchWindow)
+	}
+
+	results := make([]searchResult, len(r.providers))
+	var wg sync.WaitGroup
+	for i, p := range r.providers {
+		wg.Add(1)
+		go func(i int, p provider) {
+			defer wg.Done()
+			results[i] = r.search(ctx, p, request, window)
+		}(i, p)
+	}
+	wg.Wait()
+
+	totalResults := 0
+	var errs []string
+	for i, result := range results {
+		if result.err != nil {
+			name := r.providers[i].name
+			log.Printf("food provider %s failed: %s", name, result.err)
+			errs = append(errs, fmt.Sprintf("%s: %s", name, result.err))
+			continue
+		}
+		totalResults += result.total
+	}
+	if len(errs) == len(r.providers) {
+		return nil, 0, fmt.Errorf("all food providers failed: %s", strings.Join(errs, "; "))
+	}
+
+	merged := make([]entity.Food, 0, window)
+	seen := make(map[string]bool)
+	next := make([]int, len(results))
+	for len(merged) < window {
+		best := -1
+		for i, result := range results {
+			if result.err != nil || next[i] >= len(result.foods) {
+				continue
+			}
+			if best < 0 || mergesBefore(request.Sort, results, next, i, best) {
+				best = i
+			}
+		}
+		if best < 0 {
+			break
+		}
+
+		food := results[best].foods[next[best]]
+		next[best]++
+		key := dedupeKey(food)
+		if seen[key] {
+			totalResults--
+			continue
+		}
+		seen[key] = true
+
+		name := r.providers[best].name
+		food.ID = name + ":" + food.ID
+		food.Source = name
+		merged = append(merged, food)
+	}
+
+	start := request.Page * request.Limit
+	if start > len(merged) {
+		start = len(merged)
+	}
+	return merged[start:], totalResults, nil
+}
+
+// mergesBefore reports whether provider a's next food comes before provider b's in the
+// requested order. Ties, including two unranked foods, go to the food ranked higher by its
+// provider, then to a, which is the provider of higher priority when called in priority
+// order.
+func mergesBefore(order string, results []searchResult, next []int, a, b int) bool {
+	if order != entity.SortRelevance {
+		ka, kb := results[a].keys[next[a]], results[b].keys[next[b]]
+		if ka != kb {
+			return ka < kb
+		}
+	}
+	return next[a] < next[b]
+}
+
+// sortKey returns a food's key in the requested order. calories are its per-100 g/ml
+// calories, nil when only its serving is known. Protein density does not depend on the
+// serving size, so the listed values serve.
+func sortKey(order string, food entity.Food, calories *float64) float64 {
+	switch order {
+	case entity.SortCalories:
+		if calories == nil {
+			return unranked
+		}
+		return *calories
+	case entity.SortProteinDensity:
+		return -nutrition.ProteinDensity(food.Calories, food.Protein)
+	}
+	return 0
+}
+
+// sortFoods orders the results of a provider that cannot sort itself by their keys;
+// relevance keeps their order
+func sortFoods(result searchResult) {
+	indexes := make([]int, len(result.foods))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(a, b int) bool { return result.keys[indexes[a]] < result.keys[indexes[b]] })
+
+	sortedFoods := make([]entity.Food, len(result.foods))
+	sortedKeys := make([]float64, len(result.keys))
+	for to, from := range indexes {
+		sortedFoods[to] = result.foods[from]
+		sortedKeys[to] = result.keys[from]
+	}
+	copy(result.foods, sortedFoods)
+	copy(result.keys, sortedKeys)
+}
+
+// search fetches a provider's first n results, abandoning it once the provider's timeout
+// elapses
+func (r *FoodRegistry) search(ctx context.Context, p provider, request entity.FoodSearchRequest, n int) searchResult {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	done := make(chan searchResult, 1)
+	go func() {
+		done <- fetch(ctx, p, request, n)
+	}()
+
+	select {
+	case result := <-done:
+		return result
+	case <-ctx.Done():
+		return searchResult{err: fmt.Errorf("search timed out after %s: %w", p.timeout, ctx.Err())}
+	}
+}
+
+// fetch returns a provider's first n results. Sources that filter and sort themselves are
+// asked for all of them at once and only checked against the restriction profile here; when
+// a strict profile leaves foods out, the window is doubled until n are kept. Others are read
+// page by page in pages of the requested limit until n foods pass the filters, and for
+// other orders than relevance at least until the first maxDetailLookups results are read;
+// what was read is then sorted as a whole, since merging needs each provider's results in
+// order, and results read past those first ones are left unranked. Either way the total
+// is scaled by the share of the results that were kept, so pagination totals reflect the
+// filters and a strict profile instead of the unfiltered result count.
+func fetch(ctx context.Context, p provider, request entity.FoodSearchRequest, n int) searchResult {
+	if aware, ok := p.source.(filterAware); ok && aware.AppliesFilters() {
+		return fetchWindow(ctx, p, request, n)
+	}
+
+	var result searchResult
+	fetched, total := 0, 0
+	lookups := maxDetailLookups
+	ranks := request.Sort != entity.SortRelevance
+	for page := 0; (len(result.foods) < n || ranks && fetched < maxDetailLookups) && fetched < MaxSearchWindow; page++ {
+		pageRequest := request
+		pageRequest.Page = page
+		foods, pageTotal, err := p.source.SearchFoods(ctx, pageRequest)
+		if err != nil {
+			return searchResult{err: err}
+		}
+
+		filtered := postFilter(ctx, p, request, foods, &lookups)
+		if ranks && fetched >= maxDetailLookups {
+			for i := range filtered.keys {
+				filtered.keys[i] = unranked
+			}
+		}
+		result.foods = append(result.foods, filtered.foods...)
+		result.keys = append(result.keys, filtered.keys...)
+		fetched += len(foods)
+		total = pageTotal
+		if len(foods) < request.Limit || fetched >= total {
+			break
+		}
+		if lookups == 0 && (request.Filters.NeedsNutrients() || request.Restrictions.HidesFlagged()) {
+			// Later pages could not be checked against the filters or the profile
+			break
+		}
+	}
+
+	sortFoods(result)
+	result.total = scaledTotal(total, len(result.foods), fetched)
+	return result
+}
+
+// fetchWindow returns the first n results of a source that filters and sorts itself,
+// flagged against the restriction profile. Their ingredients are those their details would
+// be flagged from, so no details are fetched.
+func fetchWindow(ctx context.Context, p provider, request entity.FoodSearchRequest, n int) searchResult {
+	windowRequest := request
+	windowRequest.Page, windowRequest.Limit = 0, n
+	for {
+		foods, total, err := p.source.SearchFoods(ctx, windowRequest)
+		if err != nil {
+			return searchResult{err: err}
+		}
+
+		var result searchResult
+		for _, food := range foods {
+			food.Flags = restriction.Check(request.Restrictions, food.Name, food.Ingredients, nil)
+			if request.Restrictions.HidesFlagged() && len(food.Flags) > 0 {
+				continue
+			}
+			var calories *float64
+			if food.Per100g {
+				calories = &food.Calories
+			}
+			result.foods = append(result.foods, food)
+			result.keys = append(result.keys, sortKey(request.Sort, food, calories))
+		}
+		result.total = scaledTotal(total, len(result.foods), len(foods))
+
+		if len(result.foods) >= n || len(foods) < windowRequest.Limit || windowRequest.Limit >= MaxSearchWindow {
+			return result
+		}
+		windowRequest.Limit = min(windowRequest.Limit*2, MaxSearchWindow)
+	}
+}
+
+// scaledTotal scales a provider's total by the share of its fetched results that were kept
+func scaledTotal(total, kept, fetched int) int {
+	if fetched == 0 || kept >= fetched {
+		return total
+	}
+	return int(math.Ceil(float64(total) * float64(kept) / float64(fetched)))
+}
+
+// postFilter applies filters a provider cannot handle server-side and flags its foods
+// against the restriction profile. Nutrient filters and calorie sorting need per-100 g
+// values and a strict profile needs the declared attributes and ingredients, so details are
+// fetched with bounded concurrency, in order for as many foods as lookups still allows.
+// Foods whose details were fetched are rated and flagged from them; the others are flagged
+// from their name alone and marked unverified, which a strict profile leaves out.
+func postFilter(ctx context.Context, p provider, request entity.FoodSearchRequest, foods []entity.Food, lookups *int) searchResult {
+	filters := request.Filters
+	profile := request.Restrictions
+	needsDetails := filters.NeedsNutrients() || request.Sort == entity.SortCalories || profile.HidesFlagged()
+
+	details := make([]*entity.FoodDetails, len(foods))
+	if source, ok := p.source.(FoodSource); ok && needsDetails {
+		sem := make(chan struct{}, detailWorkers)
+		var wg sync.WaitGroup
+		for i, food := range foods {
+			if !nutrition.MatchesType(food, filters.Type) {
+				continue
+			}
+			if *lookups == 0 {
+				break
+			}
+			*lookups--
+			wg.Add(1)
+			go func(i int, foodID string) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				found, err := source.GetFoodDetails(ctx, foodID)
+				if err == nil {
+					details[i] = found
+				}
+			}(i, food.ID)
+		}
+		wg.Wait()
+	}
+
+	var filtered searchResult
+	for i, food := range foods {
+		if !nutrition.MatchesType(food, filters.Type) {
+			continue
+		}
+		var per100 *entity.Serving
+		if details[i] != nil {
+			if values, ok := nutrition.DetailsPer100(*details[i]); ok {
+				per100 = &values
+			}
+		}
+		if filters.NeedsNutrients() && (per100 == nil || !nutrition.Matches(filters, *per100)) {
+			continue
+		}
+
+		if details[i] != nil {
+			food.Flags = restriction.Check(profile, details[i].Name, details[i].Ingredients, details[i].Attributes)
+		} else {
+			food.Flags = restriction.Check(profile, food.Name, food.Ingredients, nil)
+			food.FlagsUnverified = profile.Active()
+		}
+		if profile.HidesFlagged() && (len(food.Flags) > 0 || food.FlagsUnverified) {
+			continue
+		}
+
+		var calories *float64
+		if per100 != nil {
+			calories = &per100.Calories
+			food.Quality = nutriscore.Quality(*per100)
+		}
+		filtered.foods = append(filtered.foods, food)
+		filtered.keys = append(filtered.keys, sortKey(request.Sort, food, calories))
+	}
+	return filtered
+}
+
+// GetFoodDetails resolves a namespaced food ID against the matching provider. Bare IDs use
+// the default source. Unknown namespaces are reported as not found (nil details).
+func (r *FoodRegistry) GetFoodDetails(ctx context.Context, foodID string) (*entity.FoodDetails, error) {
+	name, id, ok := strings.Cut(foodID, ":")
+	if !ok {
+		name, id = r.defaultSource, foodID
+	}
+
+	for _, p := range r.providers {
+		source, ok := p.source.(FoodSource)
+		if p.name != name || !ok {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+
+		details, err := source.GetFoodDetails(ctx, id)
+		if err != nil || details == nil {
+			return nil, err
+		}
+		details.ID = name + ":" + details.ID
+		details.Source = name
+		return details, nil
+	}
+
+	return nil, nil
+}
+
+// dedupeKey identifies the same food across providers by its normalised name and brand
+func dedupeKey(food entity.Food) string {
+	name := strings.Join(strings.Fields(strings.ToLower(food.Name)), " ")
+	brand := strings.Join(strings.Fields(strings.ToLower(food.BrandName)), " ")
+	return brand + "|" + name
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha1"
 	"encoding/base64"
@@ -13,6 +14,8 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -21,7 +24,9 @@ const (
 	oauthTokenEndpoint = "https://oauth.fatsecret.com/connect/token"
 	defaultTimeout     = 10 * time.Second
 	methodFoodSearch   = "foods.search"
-	methodFoodGet      = "food.get"
+	// food.get.v4 reports micronutrients in mg and mcg; food.get reports them as a
+	// percentage of the daily value
+	methodFoodGet      = "food.get.v4"
 	methodRecipeSearch = "recipes.search"
 	methodRecipeGet    = "recipe.get"
 	responseFormatJSON = "json"
@@ -33,8 +38,14 @@ type FatSecretService struct {
 	consumerKey    string
 	consumerSecret string
 	httpClient     *http.Client
-	accessToken    string
-	tokenExpiry    time.Time
+	// foodAttributes requests declared allergens and preferences with food details, which
+	// needs a Premier plan; attributesDenied is set once FatSecret refused them
+	foodAttributes   bool
+	attributesDenied atomic.Bool
+	// tokenMu guards the OAuth 2.0 token, which concurrent lookups share
+	tokenMu     sync.Mutex
+	accessToken string
+	tokenExpiry time.Time
 }
 
 type FatSecretResponse struct {
@@ -81,30 +92,67 @@ type FoodDetails struct {
 				Cholesterol            string `json:"cholesterol"`
 				Sodium                 string `json:"sodium"`
 				Sugar                  string `json:"sugar"`
+				Calcium                string `json:"calcium"`
+				Iron                   string `json:"iron"`
+				Potassium              string `json:"potassium"`
+				VitaminA               string `json:"vitamin_a"`
+				VitaminC               string `json:"vitamin_c"`
+				VitaminD               string `json:"vitamin_d"`
 			} `json:"serving_size"`
 		} `json:"servings"`
+		FoodAttributes struct {
+			Allergens struct {
+				Allergen []FoodAttribute `json:"allergen"`
+			} `json:"allergens"`
+			Preferences struct {
+				Preference []FoodAttribute `json:"preference"`
+			} `json:"preferences"`
+		} `json:"food_attributes"`
 	} `json:"food"`
+	Error *APIError `json:"error,omitempty"`
 }
 
-// NewFatSecretService creates a new FatSecret API service
-func NewFatSecretService(clientID, clientSecret, consumerKey, consumerSecret string) *FatSecretService {
+// APIError is an error FatSecret reports in the body of a successful response, e.g. when a
+// parameter needs a plan the credentials do not have
+type APIError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("FatSecret error %d: %s", e.Code, e.Message)
+}
+
+// FoodAttribute is an allergen or dietary preference declared for a food. Value is "1" when
+// the food contains the allergen or suits the preference, "0" when it does not and "-1" when
+// unknown.
+type FoodAttribute struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// NewFatSecretService creates a new FatSecret API service. foodAttributes requests the
+// allergens and preferences declared for foods with their details, a Premier feature.
+func NewFatSecretService(clientID, clientSecret, consumerKey, consumerSecret string, foodAttributes bool) *FatSecretService {
 	return &FatSecretService{
 		clientID:       clientID,
 		clientSecret:   clientSecret,
 		consumerKey:    consumerKey,
 		consumerSecret: consumerSecret,
 		httpClient:     &http.Client{Timeout: defaultTimeout},
+		foodAttributes: foodAttributes,
 	}
 }
 
-// SearchFoods searches for foods by query string
-func (s *FatSecretService) SearchFoods(query string, pageNumber, maxResults int) (*FatSecretResponse, error) {
+// SearchFoods searches for foods by query string; requests are abandoned when ctx is done
+func (s *FatSecretService) SearchFoods(ctx context.Context, query string, pageNumber, maxResults int) (*FatSecretResponse, error) {
 	// Try to use OAuth 2.0 first
-	err := s.ensureToken()
+	token, err := s.ensureToken(ctx)
 	if err != nil {
 		// If OAuth 2.0 fails, try OAuth 1.0
 		fmt.Printf("OAuth 2.0 failed: %s, trying OAuth 1.0\n", err)
-		return s.searchFoodsWithOAuth1(query, pageNumber, maxResults)
+		return s.searchFoodsWithOAuth1(ctx, query, pageNumber, maxResults)
 	}
 
 	// OAuth 2.0 was successful
@@ -115,7 +163,7 @@ func (s *FatSecretService) SearchFoods(query string, pageNumber, maxResults int)
 	params.Add("max_results", fmt.Sprintf("%d", maxResults))
 	params.Add("format", responseFormatJSON)
 
-	resp, err := s.makeRequestWithOAuth2(params)
+	resp, err := s.makeRequestWithOAuth2(ctx, token, params)
 	if err != nil {
 		return nil, err
 	}
@@ -129,7 +177,7 @@ func (s *FatSecretService) SearchFoods(query string, pageNumber, maxResults int)
 }
 
 // searchFoodsWithOAuth1 searches for foods using OAuth 1.0
-func (s *FatSecretService) searchFoodsWithOAuth1(query string, pageNumber, maxResults int) (*FatSecretResponse, error) {
+func (s *FatSecretService) searchFoodsWithOAuth1(ctx context.Context, query string, pageNumber, maxResults int) (*FatSecretResponse, error) {
 	params := map[string]string{
 		"method":            methodFoodSearch,
 		"search_expression": query,
@@ -138,7 +186,7 @@ func (s *FatSecretService) searchFoodsWithOAuth1(query string, pageNumber, maxRe
 		"format":            responseFormatJSON,
 	}
 
-	resp, err := s.makeRequestWithOAuth1(params)
+	resp, err := s.makeRequestWithOAuth1(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -151,23 +199,53 @@ func (s *FatSecretService) searchFoodsWithOAuth1(query string, pageNumber, maxRe
 	return &result, nil
 }
 
-// GetFoodDetails gets detailed information about a specific food
-func (s *FatSecretService) GetFoodDetails(foodID string) (*FoodDetails, error) {
+// GetFoodDetails gets detailed information about a specific food; requests are abandoned
+// when ctx is done. When food attributes are enabled they are requested too, and if
+// FatSecret refuses them the food is fetched again without; once that works they are no
+// longer requested.
+func (s *FatSecretService) GetFoodDetails(ctx context.Context, foodID string) (*FoodDetails, error) {
+	if !s.foodAttributes || s.attributesDenied.Load() {
+		return s.getFoodDetails(ctx, foodID, false)
+	}
+
+	result, err := s.getFoodDetails(ctx, foodID, true)
+	if err == nil && result.Error != nil {
+		err = result.Error
+	}
+	if err == nil || ctx.Err() != nil {
+		return result, err
+	}
+
+	result, retryErr := s.getFoodDetails(ctx, foodID, false)
+	if retryErr != nil {
+		return nil, retryErr
+	}
+	if !s.attributesDenied.Swap(true) {
+		fmt.Printf("FatSecret food attributes unavailable, no longer requesting them: %s\n", err)
+	}
+	return result, nil
+}
+
+// getFoodDetails fetches a food, with its declared attributes if requested
+func (s *FatSecretService) getFoodDetails(ctx context.Context, foodID string, attributes bool) (*FoodDetails, error) {
 	// Try to use OAuth 2.0 first
-	err := s.ensureToken()
+	token, err := s.ensureToken(ctx)
 	if err != nil {
 		// If OAuth 2.0 fails, try OAuth 1.0
 		fmt.Printf("OAuth 2.0 failed: %s, trying OAuth 1.0\n", err)
-		return s.getFoodDetailsWithOAuth1(foodID)
+		return s.getFoodDetailsWithOAuth1(ctx, foodID, attributes)
 	}
 
 	// OAuth 2.0 was successful
 	params := url.Values{}
 	params.Add("method", methodFoodGet)
 	params.Add("food_id", foodID)
+	if attributes {
+		params.Add("include_food_attributes", "true")
+	}
 	params.Add("format", responseFormatJSON)
 
-	resp, err := s.makeRequestWithOAuth2(params)
+	resp, err := s.makeRequestWithOAuth2(ctx, token, params)
 	if err != nil {
 		return nil, err
 	}
@@ -181,14 +259,17 @@ func (s *FatSecretService) GetFoodDetails(foodID string) (*FoodDetails, error) {
 }
 
 // getFoodDetailsWithOAuth1 gets detailed information about a specific food using OAuth 1.0
-func (s *FatSecretService) getFoodDetailsWithOAuth1(foodID string) (*FoodDetails, error) {
+func (s *FatSecretService) getFoodDetailsWithOAuth1(ctx context.Context, foodID string, attributes bool) (*FoodDetails, error) {
 	params := map[string]string{
 		"method":  methodFoodGet,
 		"food_id": foodID,
 		"format":  responseFormatJSON,
 	}
+	if attributes {
+		params["include_food_attributes"] = "true"
+	}
 
-	resp, err := s.makeRequestWithOAuth1(params)
+	resp, err := s.makeRequestWithOAuth1(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -201,11 +282,14 @@ func (s *FatSecretService) getFoodDetailsWithOAuth1(foodID string) (*FoodDetails
 	return &result, nil
 }
 
-// ensureToken makes sure we have a valid access token
-func (s *FatSecretService) ensureToken() error {
+// ensureToken makes sure we have a valid access token and returns it
+func (s *FatSecretService) ensureToken(ctx context.Context) (string, error) {
+	s.tokenMu.Lock()
+	defer s.tokenMu.Unlock()
+
 	// If token is still valid, return
 	if s.accessToken != "" && time.Now().Before(s.tokenExpiry) {
-		return nil
+		return s.accessToken, nil
 	}
 
 	// Get a new token
@@ -213,9 +297,9 @@ func (s *FatSecretService) ensureToken() error {
 	data.Set("grant_type", "client_credentials")
 	data.Set("scope", "basic")
 
-	req, err := http.NewRequest("POST", oauthTokenEndpoint, strings.NewReader(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", oauthTokenEndpoint, strings.NewReader(data.Encode()))
 	if err != nil {
-		return fmt.Errorf("error creating token request: %w", err)
+		return "", fmt.Errorf("error creating token request: %w", err)
 	}
 
 	req.SetBasicAuth(s.clientID, s.clientSecret)
@@ -223,13 +307,13 @@ func (s *FatSecretService) ensureToken() error {
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("error getting token: %w", err)
+		return "", fmt.Errorf("error getting token: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("error getting token: %d %s - %s", resp.StatusCode, resp.Status, string(body))
+		return "", fmt.Errorf("error getting token: %d %s - %s", resp.StatusCode, resp.Status, string(body))
 	}
 
 	var tokenResponse struct {
@@ -240,24 +324,24 @@ func (s *FatSecretService) ensureToken() error {
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
-		return fmt.Errorf("error decoding token response: %w", err)
+		return "", fmt.Errorf("error decoding token response: %w", err)
 	}
 
 	s.accessToken = tokenResponse.AccessToken
 	s.tokenExpiry = time.Now().Add(time.Duration(tokenResponse.ExpiresIn-60) * time.Second) // 60 seconds buffer
 
-	return nil
+	return s.accessToken, nil
 }
 
 // makeRequestWithOAuth2 makes an authenticated request using OAuth 2.0
-func (s *FatSecretService) makeRequestWithOAuth2(params url.Values) ([]byte, error) {
-	req, err := http.NewRequest("GET", baseURL, nil)
+func (s *FatSecretService) makeRequestWithOAuth2(ctx context.Context, token string, params url.Values) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", baseURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error creating request: %w", err)
 	}
 
 	req.URL.RawQuery = params.Encode()
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", s.accessToken))
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
@@ -279,7 +363,7 @@ func (s *FatSecretService) makeRequestWithOAuth2(params url.Values) ([]byte, err
 }
 
 // makeRequestWithOAuth1 makes an authenticated request using OAuth 1.0
-func (s *FatSecretService) makeRequestWithOAuth1(params map[string]string) ([]byte, error) {
+func (s *FatSecretService) makeRequestWithOAuth1(ctx context.Context, params map[string]string) ([]byte, error) {
 	// Add OAuth parameters
 	oauth := map[string]string{
 		"oauth_consumer_key":     s.consumerKey,
@@ -330,7 +414,7 @@ func (s *FatSecretService) makeRequestWithOAuth1(params map[string]string) ([]by
 	}
 
 	// Make request
-	req, err := http.NewRequest("GET", reqURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error creating request: %w", err)
 	}
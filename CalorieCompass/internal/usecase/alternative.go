@@ -0,0 +1,305 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/restriction"
+	"CalorieCompass/internal/pkg/units"
+)
+
+// FoodDetailsResolver resolves a food from any source
+type FoodDetailsResolver interface {
+	ResolveFoodDetails(ctx context.Context, userID int64, foodID string) (*entity.FoodDetails, error)
+}
+
+// AlternativeCatalog finds catalog foods similar to a food
+type AlternativeCatalog interface {
+	SimilarFoods(ctx context.Context, name string, catalogID int64, limit int) ([]entity.SimilarFood, error)
+}
+
+const (
+	// DefaultAlternativeLimit is the default number of alternatives
+	DefaultAlternativeLimit = 5
+	// MaxAlternativeLimit is the largest number of alternatives that can be requested
+	MaxAlternativeLimit = 20
+	// alternativeCandidates is the number of similar catalog foods compared
+	alternativeCandidates = 100
+	// alternativeMinChange is the smallest relative difference that counts as a change
+	alternativeMinChange = 0.10
+)
+
+// alternativeNutrient is a nutrient alternatives are compared on, with the direction that
+// counts as better and the smallest absolute difference per serving that counts at all
+type alternativeNutrient struct {
+	name      string
+	unit      string
+	lower     bool
+	minChange float64
+	value     func(entity.Serving) float64
+}
+
+var alternativeNutrients = []alternativeNutrient{
+	{"calories", "kcal", true, 10, func(s entity.Serving) float64 { return s.Calories }},
+	{"sugar", "g", true, 1, func(s entity.Serving) float64 { return s.Sugar }},
+	{"sodium", "mg", true, 20, func(s entity.Serving) float64 { return s.Sodium }},
+	{"fiber", "g", false, 0.5, func(s entity.Serving) float64 { return s.Fiber }},
+	{"protein", "g", false, 1, func(s entity.Serving) float64 { return s.Protein }},
+}
+
+// AlternativeUseCase finds healthier substitutes for foods
+type AlternativeUseCase struct {
+	foods        FoodDetailsResolver
+	catalog      AlternativeCatalog
+	restrictions RestrictionRepository
+}
+
+// NewAlternativeUseCase creates a new alternative use case
+func NewAlternativeUseCase(foods FoodDetailsResolver, catalog AlternativeCatalog, restrictions RestrictionRepository) *AlternativeUseCase {
+	return &AlternativeUseCase{
+		foods:        foods,
+		catalog:      catalog,
+		restrictions: restrictions,
+	}
+}
+
+// Alternatives returns catalog foods in the same category as a food and with similar names
+// that have fewer calories per gram, less sugar or sodium, or more fiber or protein. They
+// are compared over the given serving, or the food's first serving with a metric amount,
+// and only kept when their improvements outweigh what gets worse. Candidates are checked
+// against the user's restriction profile: allergens always leave them out, diet conflicts
+// only under a strict profile, and the remaining conflicts are flagged.
+func (uc *AlternativeUseCase) Alternatives(ctx context.Context, userID int64, foodID, servingID string, limit int) (*entity.FoodAlternatives, error) {
+	if limit <= 0 {
+		limit = DefaultAlternativeLimit
+	}
+	if limit > MaxAlternativeLimit {
+		return nil, fmt.Errorf("%w: limit must not exceed %d", entity.ErrInvalidInput, MaxAlternativeLimit)
+	}
+
+	profile, err := restrictionProfile(ctx, uc.restrictions, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := uc.foods.ResolveFoodDetails(ctx, userID, foodID)
+	if err != nil {
+		return nil, err
+	}
+	if details == nil {
+		return nil, fmt.Errorf("food %s: %w", foodID, entity.ErrNotFound)
+	}
+
+	serving, amount, err := comparisonServing(details.Servings, servingID)
+	if err != nil {
+		return nil, err
+	}
+
+	var catalogID int64
+	switch source, id := ParseFoodID(details.ID); source {
+	case entity.FoodSourceCatalog, entity.CatalogSourceUSDA, entity.CatalogSourceOpenFoodFacts:
+		catalogID, _ = strconv.ParseInt(id, 10, 64)
+	}
+	similar, err := uc.catalog.SimilarFoods(ctx, details.Name, catalogID, alternativeCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entity.FoodAlternatives{
+		FoodID:       details.ID,
+		FoodName:     details.Name,
+		BrandName:    details.BrandName,
+		Serving:      roundServing(serving),
+		Alternatives: []entity.FoodAlternative{},
+	}
+	if len(similar) > 0 {
+		result.Category = similar[0].Food.Category
+	}
+
+	for _, candidate := range similar {
+		food := candidate.Food
+		flags := restriction.Check(profile, food.Name, food.Ingredients, nil)
+		if restrictionExcludes(profile, flags) {
+			continue
+		}
+
+		scaled := units.MultiplyServing(entity.Serving{
+			Calories:     food.Calories,
+			Carbs:        food.Carbs,
+			Protein:      food.Protein,
+			Fat:          food.Fat,
+			SaturatedFat: food.SaturatedFat,
+			Fiber:        food.Fiber,
+			Cholesterol:  food.Cholesterol,
+			Sodium:       food.Sodium,
+			Sugar:        food.Sugar,
+			Calcium:      food.Calcium,
+			Iron:         food.Iron,
+			Potassium:    food.Potassium,
+			VitaminA:     food.VitaminA,
+			VitaminC:     food.VitaminC,
+			VitaminD:     food.VitaminD,
+		}, amount/100)
+		scaled.Description = serving.Description
+		scaled.MetricServingAmount = serving.MetricServingAmount
+		scaled.MetricServingUnit = serving.MetricServingUnit
+
+		comparison, gain := compareNutrients(serving, scaled)
+		if gain <= 0 {
+			continue
+		}
+
+		alternative := entity.FoodAlternative{
+			FoodID:       entity.FoodSourceCatalog + ":" + strconv.FormatInt(food.ID, 10),
+			FoodName:     food.Name,
+			BrandName:    food.BrandName,
+			Category:     food.Category,
+			Similarity:   round2(candidate.Similarity),
+			Score:        round2(candidate.Similarity * (1 + gain)),
+			Nutrition:    roundServing(scaled),
+			Comparison:   comparison,
+			Improvements: []string{},
+			Flags:        flags,
+		}
+		for _, c := range comparison {
+			if c.Effect == entity.EffectBetter {
+				alternative.Improvements = append(alternative.Improvements, c.Nutrient)
+			}
+		}
+		alternative.Explanation = explainAlternative(serving.Description, comparison)
+		result.Alternatives = append(result.Alternatives, alternative)
+	}
+
+	sort.SliceStable(result.Alternatives, func(i, j int) bool {
+		return result.Alternatives[i].Score > result.Alternatives[j].Score
+	})
+	if len(result.Alternatives) > limit {
+		result.Alternatives = result.Alternatives[:limit]
+	}
+	return result, nil
+}
+
+// comparisonServing returns the serving alternatives are compared over, with its amount in
+// grams or millilitres
+func comparisonServing(servings []entity.Serving, servingID string) (entity.Serving, float64, error) {
+	if servingID != "" {
+		serving, err := findServingByID(servings, servingID)
+		if err != nil {
+			return entity.Serving{}, 0, err
+		}
+		if amount, ok := metricAmount(serving); ok {
+			return serving, amount, nil
+		}
+		return entity.Serving{}, 0, fmt.Errorf("%w: serving %s has no metric amount", entity.ErrInvalidInput, servingID)
+	}
+
+	for _, serving := range servings {
+		if amount, ok := metricAmount(serving); ok {
+			return serving, amount, nil
+		}
+	}
+	return entity.Serving{}, 0, fmt.Errorf("%w: food has no serving with a metric amount", entity.ErrInvalidInput)
+}
+
+// metricAmount returns a serving's amount in grams, or millilitres for volume servings
+func metricAmount(serving entity.Serving) (float64, bool) {
+	unit, err := units.Parse(serving.MetricServingUnit)
+	if err != nil || serving.MetricServingAmount <= 0 {
+		return 0, false
+	}
+	return serving.MetricServingAmount * unit.Factor, true
+}
+
+// compareNutrients compares an alternative with the original over the same serving. The
+// gain sums the relative improvements, each capped at 100%, minus the relative losses; it is
+// 0 when nothing improves.
+func compareNutrients(original, alternative entity.Serving) ([]entity.NutrientComparison, float64) {
+	comparison := make([]entity.NutrientComparison, 0, len(alternativeNutrients))
+	gain, improved := 0.0, false
+	for _, n := range alternativeNutrients {
+		before, after := n.value(original), n.value(alternative)
+		c := entity.NutrientComparison{
+			Nutrient:    n.name,
+			Unit:        n.unit,
+			Original:    round1(before),
+			Alternative: round1(after),
+			Effect:      entity.EffectSimilar,
+		}
+
+		diff := after - before
+		relative := 1.0
+		if before > 0 {
+			relative = math.Min(math.Abs(diff)/before, 1)
+			change := round1(diff / before * 100)
+			c.Change = &change
+		}
+		if math.Abs(diff) >= n.minChange && (before == 0 || math.Abs(diff) >= before*alternativeMinChange) {
+			if (diff < 0) == n.lower {
+				c.Effect = entity.EffectBetter
+				gain += relative
+				improved = true
+			} else {
+				c.Effect = entity.EffectWorse
+				gain -= relative
+			}
+		}
+		comparison = append(comparison, c)
+	}
+
+	if !improved {
+		return comparison, 0
+	}
+	return comparison, gain
+}
+
+// explainAlternative describes what improves, then what gets worse, e.g.
+// "Per 1 cup: 35% fewer calories and 2.5 g more fiber, but 20% more sodium"
+func explainAlternative(serving string, comparison []entity.NutrientComparison) string {
+	var better, worse []string
+	for _, c := range comparison {
+		switch c.Effect {
+		case entity.EffectBetter:
+			better = append(better, describeChange(c))
+		case entity.EffectWorse:
+			worse = append(worse, describeChange(c))
+		}
+	}
+
+	explanation := "Per " + serving + ": " + joinPhrases(better)
+	if len(worse) > 0 {
+		explanation += ", but " + joinPhrases(worse)
+	}
+	return explanation
+}
+
+// describeChange phrases one nutrient difference, in percent when the original has some of
+// the nutrient
+func describeChange(c entity.NutrientComparison) string {
+	diff := c.Alternative - c.Original
+	amount := strconv.FormatFloat(math.Abs(round1(diff)), 'f', -1, 64) + " " + c.Unit
+	if c.Change != nil {
+		amount = strconv.FormatFloat(math.Abs(math.Round(*c.Change)), 'f', -1, 64) + "%"
+	}
+
+	direction := "more"
+	if diff < 0 {
+		direction = "less"
+		if c.Nutrient == "calories" {
+			direction = "fewer"
+		}
+	}
+	return amount + " " + direction + " " + c.Nutrient
+}
+
+// joinPhrases joins phrases as "a, b and c"
+func joinPhrases(phrases []string) string {
+	if len(phrases) <= 1 {
+		return strings.Join(phrases, "")
+	}
+	return strings.Join(phrases[:len(phrases)-1], ", ") + " and " + phrases[len(phrases)-1]
+}
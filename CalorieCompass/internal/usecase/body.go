@@ -0,0 +1,483 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/bodycomp"
+)
+
+// ProfileRepository defines the interface for body profile storage
+type ProfileRepository interface {
+	Get(ctx context.Context, userID int64) (*entity.Profile, error)
+	Upsert(ctx context.Context, userID int64, profile entity.Profile) error
+}
+
+// MeasurementRepository defines the interface for body measurement storage
+type MeasurementRepository interface {
+	Save(ctx context.Context, userID int64, measurements []entity.Measurement) ([]int64, error)
+	Delete(ctx context.Context, userID, id int64) error
+	List(ctx context.Context, userID int64, types []string, from, to time.Time) ([]entity.Measurement, error)
+}
+
+// DefaultSeriesPoints is the default maximum number of points of a body metric series
+const DefaultSeriesPoints = 200
+
+// bodyFatMax is the highest body fat percentage accepted as a measurement
+const bodyFatMax = 75
+
+// metricUnits maps the derived body metrics to their units
+var metricUnits = map[string]string{
+	entity.MetricWeight:         "kg",
+	entity.MetricBMI:            "kg/m²",
+	entity.MetricWaistToHeight:  "ratio",
+	entity.MetricNavyBodyFat:    "%",
+	entity.MetricFFMI:           "kg/m²",
+	entity.MetricNormalizedFFMI: "kg/m²",
+}
+
+// BodyUseCase handles business logic for the body profile, measurements and composition
+type BodyUseCase struct {
+	profiles     ProfileRepository
+	measurements MeasurementRepository
+	weights      WeightRepository
+}
+
+// NewBodyUseCase creates a new body use case
+func NewBodyUseCase(profiles ProfileRepository, measurements MeasurementRepository, weights WeightRepository) *BodyUseCase {
+	return &BodyUseCase{
+		profiles:     profiles,
+		measurements: measurements,
+		weights:      weights,
+	}
+}
+
+// GetProfile returns the user's profile, empty if it has not been filled in
+func (uc *BodyUseCase) GetProfile(ctx context.Context, userID int64) (*entity.Profile, error) {
+	profile, err := uc.profiles.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return &entity.Profile{}, nil
+	}
+	return profile, nil
+}
+
+// UpdateProfile replaces the user's profile, storing the height in cm
+func (uc *BodyUseCase) UpdateProfile(ctx context.Context, userID int64, input entity.ProfileInput) (*entity.Profile, error) {
+	profile := entity.Profile{Sex: input.Sex}
+
+	if input.BirthDate != "" {
+		birthDate, err := ParseDate(input.BirthDate)
+		if err != nil {
+			return nil, err
+		}
+		if birthDate.After(time.Now().UTC()) {
+			return nil, fmt.Errorf("%w: birth date must not be in the future", entity.ErrInvalidInput)
+		}
+		profile.BirthDate = birthDate.Format(entity.DateLayout)
+	}
+
+	if input.Height > 0 {
+		unit := input.HeightUnit
+		if unit == "" {
+			unit = "cm"
+		}
+		height, err := bodycomp.ToCentimeters(input.Height, unit)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", entity.ErrInvalidInput, err)
+		}
+		if height < 50 || height > 272 {
+			return nil, fmt.Errorf("%w: height must be between 50 and 272 cm", entity.ErrInvalidInput)
+		}
+		profile.Height = math.Round(height*10) / 10
+	}
+
+	if err := uc.profiles.Upsert(ctx, userID, profile); err != nil {
+		return nil, err
+	}
+
+	return uc.GetProfile(ctx, userID)
+}
+
+// RecordMeasurements stores measurements taken on a date in the unit of their type,
+// replacing measurements of the same types already recorded that day
+func (uc *BodyUseCase) RecordMeasurements(ctx context.Context, userID int64, input entity.MeasurementInput) ([]entity.Measurement, error) {
+	date, err := ParseDate(input.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	measurements := make([]entity.Measurement, 0, len(input.Values))
+	types := make([]string, 0, len(input.Values))
+	seen := make(map[string]bool, len(input.Values))
+	for _, value := range input.Values {
+		if seen[value.Type] {
+			return nil, fmt.Errorf("%w: %s is measured more than once", entity.ErrInvalidInput, value.Type)
+		}
+		seen[value.Type] = true
+
+		stored, err := measurementValue(value)
+		if err != nil {
+			return nil, err
+		}
+		measurements = append(measurements, entity.Measurement{
+			Date:  date.Format(entity.DateLayout),
+			Type:  value.Type,
+			Value: stored,
+			Unit:  entity.MeasurementUnits[value.Type],
+		})
+		types = append(types, value.Type)
+	}
+
+	if _, err := uc.measurements.Save(ctx, userID, measurements); err != nil {
+		return nil, err
+	}
+
+	return uc.measurements.List(ctx, userID, types, date, date)
+}
+
+// ListMeasurements returns the measurements of the given types recorded between two dates
+func (uc *BodyUseCase) ListMeasurements(ctx context.Context, userID int64, types []string, from, to time.Time) ([]entity.Measurement, error) {
+	for _, measurementType := range types {
+		if _, ok := entity.MeasurementUnits[measurementType]; !ok {
+			return nil, fmt.Errorf("%w: unknown measurement type %q", entity.ErrInvalidInput, measurementType)
+		}
+	}
+	return uc.measurements.List(ctx, userID, types, from, to)
+}
+
+// DeleteMeasurement removes a measurement
+func (uc *BodyUseCase) DeleteMeasurement(ctx context.Context, userID, id int64) error {
+	return uc.measurements.Delete(ctx, userID, id)
+}
+
+// Composition returns the latest weight and measurements recorded on or before a date with
+// the body composition metrics derived from them
+func (uc *BodyUseCase) Composition(ctx context.Context, userID int64, date time.Time) (*entity.BodyComposition, error) {
+	profile, err := uc.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	measurements, err := uc.measurements.List(ctx, userID, nil, time.Time{}, date)
+	if err != nil {
+		return nil, err
+	}
+	weights, err := uc.weights.List(ctx, userID, time.Time{}, date)
+	if err != nil {
+		return nil, err
+	}
+
+	state := newBodyState()
+	for _, measurement := range measurements {
+		state.measurements[measurement.Type] = measurement
+	}
+	if len(weights) > 0 {
+		weight := weights[len(weights)-1].Weight
+		state.weight = &weight
+	}
+
+	return state.compose(date, profile), nil
+}
+
+// Series returns a measurement type or derived metric between two dates, downsampled to at
+// most maxPoints points. Derived metrics are computed on every date one of their inputs was
+// recorded, carrying the other inputs forward from earlier dates.
+func (uc *BodyUseCase) Series(ctx context.Context, userID int64, metric string, from, to time.Time, maxPoints int) (*entity.BodySeries, error) {
+	if maxPoints <= 0 {
+		maxPoints = DefaultSeriesPoints
+	}
+
+	var samples []bodycomp.Sample
+	var err error
+	unit, measured := entity.MeasurementUnits[metric]
+	switch {
+	case measured:
+		samples, err = uc.measurementSeries(ctx, userID, metric, from, to)
+	case metric == entity.MetricWeight:
+		unit = metricUnits[metric]
+		samples, err = uc.weightSeries(ctx, userID, from, to)
+	default:
+		var ok bool
+		if unit, ok = metricUnits[metric]; !ok {
+			return nil, fmt.Errorf("%w: unknown body metric %q", entity.ErrInvalidInput, metric)
+		}
+		samples, err = uc.derivedSeries(ctx, userID, metric, from, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	series := &entity.BodySeries{
+		Metric: metric,
+		Unit:   unit,
+		Points: make([]entity.BodySeriesPoint, 0, len(samples)),
+	}
+	for _, sample := range bodycomp.Downsample(samples, maxPoints) {
+		series.Points = append(series.Points, entity.BodySeriesPoint{
+			Date:  sample.Date.Format(entity.DateLayout),
+			Value: round2(sample.Value),
+			Count: sample.Count,
+		})
+	}
+	return series, nil
+}
+
+func (uc *BodyUseCase) measurementSeries(ctx context.Context, userID int64, metric string, from, to time.Time) ([]bodycomp.Sample, error) {
+	measurements, err := uc.measurements.List(ctx, userID, []string{metric}, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]bodycomp.Sample, 0, len(measurements))
+	for _, measurement := range measurements {
+		date, err := time.Parse(entity.DateLayout, measurement.Date)
+		if err != nil {
+			return nil, fmt.Errorf("parse measurement date error: %w", err)
+		}
+		samples = append(samples, bodycomp.Sample{Date: date, Value: measurement.Value, Count: 1})
+	}
+	return samples, nil
+}
+
+func (uc *BodyUseCase) weightSeries(ctx context.Context, userID int64, from, to time.Time) ([]bodycomp.Sample, error) {
+	entries, err := uc.weights.List(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]bodycomp.Sample, 0, len(entries))
+	for _, entry := range entries {
+		date, err := time.Parse(entity.DateLayout, entry.Date)
+		if err != nil {
+			return nil, fmt.Errorf("parse weight entry date error: %w", err)
+		}
+		samples = append(samples, bodycomp.Sample{Date: date, Value: entry.Weight, Count: 1})
+	}
+	return samples, nil
+}
+
+// derivedSeries replays the whole history of weights and measurements up to to, so that
+// inputs recorded before from are carried into the range
+func (uc *BodyUseCase) derivedSeries(ctx context.Context, userID int64, metric string, from, to time.Time) ([]bodycomp.Sample, error) {
+	profile, err := uc.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if profile.Height <= 0 {
+		return nil, fmt.Errorf("%w: set a height in the profile to compute %s", entity.ErrInvalidInput, metric)
+	}
+
+	measurements, err := uc.measurements.List(ctx, userID, nil, time.Time{}, to)
+	if err != nil {
+		return nil, err
+	}
+	weights, err := uc.weights.List(ctx, userID, time.Time{}, to)
+	if err != nil {
+		return nil, err
+	}
+
+	byDate := make(map[string][]entity.Measurement)
+	weightByDate := make(map[string]float64, len(weights))
+	dates := make([]string, 0, len(measurements)+len(weights))
+	for _, measurement := range measurements {
+		if _, ok := byDate[measurement.Date]; !ok {
+			dates = append(dates, measurement.Date)
+		}
+		byDate[measurement.Date] = append(byDate[measurement.Date], measurement)
+	}
+	for _, entry := range weights {
+		if _, ok := byDate[entry.Date]; !ok {
+			dates = append(dates, entry.Date)
+			byDate[entry.Date] = nil
+		}
+		weightByDate[entry.Date] = entry.Weight
+	}
+	sort.Strings(dates)
+
+	state := newBodyState()
+	samples := make([]bodycomp.Sample, 0, len(dates))
+	for _, day := range dates {
+		for _, measurement := range byDate[day] {
+			state.measurements[measurement.Type] = measurement
+		}
+		if weight, ok := weightByDate[day]; ok {
+			state.weight = &weight
+		}
+
+		date, err := time.Parse(entity.DateLayout, day)
+		if err != nil {
+			return nil, fmt.Errorf("parse body date error: %w", err)
+		}
+		if date.Before(from) {
+			continue
+		}
+
+		if value := derivedMetric(state.compose(date, profile), metric); value != nil {
+			samples = append(samples, bodycomp.Sample{Date: date, Value: *value, Count: 1})
+		}
+	}
+	return samples, nil
+}
+
+// bodyState is the latest known weight and measurement of each type
+type bodyState struct {
+	weight       *float64
+	measurements map[string]entity.Measurement
+}
+
+func newBodyState() *bodyState {
+	return &bodyState{measurements: make(map[string]entity.Measurement)}
+}
+
+func (s *bodyState) value(measurementType string) float64 {
+	return s.measurements[measurementType].Value
+}
+
+// compose derives the body composition metrics from the state. A measured body fat takes
+// precedence over the Navy estimate and a measured lean mass over one derived from body fat.
+func (s *bodyState) compose(date time.Time, profile *entity.Profile) *entity.BodyComposition {
+	result := &entity.BodyComposition{
+		Date:         date.Format(entity.DateLayout),
+		Weight:       s.weight,
+		Measurements: make([]entity.Measurement, 0, len(s.measurements)),
+	}
+	for _, measurement := range s.measurements {
+		result.Measurements = append(result.Measurements, measurement)
+	}
+	sort.Slice(result.Measurements, func(i, j int) bool {
+		return result.Measurements[i].Type < result.Measurements[j].Type
+	})
+
+	height := profile.Height
+	hasHeight := height > 0
+	if hasHeight {
+		result.Height = &height
+	}
+	hasWeight := s.weight != nil
+	waist := s.value(entity.MeasurementWaist)
+	neck := s.value(entity.MeasurementNeck)
+	hips := s.value(entity.MeasurementHips)
+	male := profile.Sex == entity.SexMale
+
+	if hasHeight && hasWeight {
+		bmi := bodycomp.BMI(*s.weight, height)
+		result.BMI = rounded(bmi)
+		result.BMICategory = bodycomp.BMICategory(bmi)
+	}
+
+	if hasHeight && waist > 0 {
+		result.WaistToHeight = rounded(bodycomp.WaistToHeight(waist, height))
+	}
+
+	if profile.Sex != "" && hasHeight {
+		if navy, ok := bodycomp.NavyBodyFat(male, height, waist, neck, hips); ok {
+			result.NavyBodyFat = rounded(navy)
+		}
+	}
+	if bodyFat, ok := s.measurements[entity.MeasurementBodyFat]; ok {
+		result.BodyFat = rounded(bodyFat.Value)
+	} else {
+		result.BodyFat = result.NavyBodyFat
+	}
+
+	if leanMass, ok := s.measurements[entity.MeasurementLeanMass]; ok {
+		result.LeanMass = rounded(leanMass.Value)
+	} else if hasWeight && result.BodyFat != nil {
+		result.LeanMass = rounded(bodycomp.LeanMass(*s.weight, *result.BodyFat))
+	}
+
+	if hasHeight && result.LeanMass != nil {
+		ffmi, normalized := bodycomp.FFMI(*result.LeanMass, height)
+		result.FFMI = rounded(ffmi)
+		result.NormalizedFFMI = rounded(normalized)
+	}
+
+	missing := make(map[string]bool)
+	need := func(input string, ok bool) {
+		if !ok {
+			missing[input] = true
+		}
+	}
+	if result.BMI == nil {
+		need("height", hasHeight)
+		need("weight", hasWeight)
+	}
+	if result.WaistToHeight == nil {
+		need("height", hasHeight)
+		need(entity.MeasurementWaist, waist > 0)
+	}
+	if result.BodyFat == nil {
+		need("sex", profile.Sex != "")
+		need("height", hasHeight)
+		need(entity.MeasurementWaist, waist > 0)
+		need(entity.MeasurementNeck, neck > 0)
+		need(entity.MeasurementHips, male || hips > 0)
+	}
+	if result.LeanMass == nil {
+		need("weight", hasWeight)
+	}
+	for input := range missing {
+		result.Missing = append(result.Missing, input)
+	}
+	sort.Strings(result.Missing)
+
+	return result
+}
+
+// derivedMetric picks a derived metric from a body composition
+func derivedMetric(composition *entity.BodyComposition, metric string) *float64 {
+	switch metric {
+	case entity.MetricBMI:
+		return composition.BMI
+	case entity.MetricWaistToHeight:
+		return composition.WaistToHeight
+	case entity.MetricNavyBodyFat:
+		return composition.NavyBodyFat
+	case entity.MetricFFMI:
+		return composition.FFMI
+	case entity.MetricNormalizedFFMI:
+		return composition.NormalizedFFMI
+	}
+	return nil
+}
+
+// measurementValue converts a measurement to the unit its type is stored in
+func measurementValue(input entity.MeasurementValueInput) (float64, error) {
+	switch input.Type {
+	case entity.MeasurementBodyFat:
+		if input.Unit != "" && input.Unit != "%" {
+			return 0, fmt.Errorf("%w: body fat must be a percentage", entity.ErrInvalidInput)
+		}
+		if input.Value >= bodyFatMax {
+			return 0, fmt.Errorf("%w: body fat must be below %d%%", entity.ErrInvalidInput, bodyFatMax)
+		}
+		return round2(input.Value), nil
+	case entity.MeasurementLeanMass:
+		return toKilograms(input.Value, input.Unit)
+	default:
+		unit := input.Unit
+		if unit == "" {
+			unit = "cm"
+		}
+		cm, err := bodycomp.ToCentimeters(input.Value, unit)
+		if errors.Is(err, bodycomp.ErrUnknownLengthUnit) {
+			return 0, fmt.Errorf("%w: %s must be in cm, mm, m, in or ft", entity.ErrInvalidInput, input.Type)
+		}
+		if err != nil {
+			return 0, err
+		}
+		return round2(cm), nil
+	}
+}
+
+func rounded(value float64) *float64 {
+	result := round2(value)
+	return &result
+}
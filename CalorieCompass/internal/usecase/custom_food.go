@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/units"
+)
+
+// RecipeRecomputer refreshes recipes after one of their ingredient foods changed
+type RecipeRecomputer interface {
+	RecomputeForFood(ctx context.Context, userID int64, foodID string) error
+}
+
+// CustomFoodUseCase handles business logic for user-defined foods
+type CustomFoodUseCase struct {
+	repo    CustomFoodRepository
+	recipes RecipeRecomputer
+}
+
+// NewCustomFoodUseCase creates a new custom food use case
+func NewCustomFoodUseCase(repo CustomFoodRepository, recipes RecipeRecomputer) *CustomFoodUseCase {
+	return &CustomFoodUseCase{
+		repo:    repo,
+		recipes: recipes,
+	}
+}
+
+// Create validates and stores a new custom food
+func (uc *CustomFoodUseCase) Create(ctx context.Context, userID int64, input entity.CustomFoodInput) (*entity.FoodDetails, error) {
+	if err := validateCustomFood(input); err != nil {
+		return nil, err
+	}
+
+	id, err := uc.repo.Create(ctx, userID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.Get(ctx, userID, id)
+}
+
+// Update validates and replaces an existing custom food
+func (uc *CustomFoodUseCase) Update(ctx context.Context, userID, id int64, input entity.CustomFoodInput) (*entity.FoodDetails, error) {
+	if err := validateCustomFood(input); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, userID, id, input); err != nil {
+		return nil, err
+	}
+
+	details, err := uc.Get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Recipes using this food carry its old nutrients until they are recomputed
+	if err := uc.recipes.RecomputeForFood(ctx, userID, details.ID); err != nil {
+		log.Printf("recompute recipes using %s: %s", details.ID, err)
+	}
+
+	return details, nil
+}
+
+// Delete removes a custom food. Recipes using it are recomputed; those that can no longer
+// resolve it keep their previous values.
+func (uc *CustomFoodUseCase) Delete(ctx context.Context, userID, id int64) error {
+	if err := uc.repo.Delete(ctx, userID, id); err != nil {
+		return err
+	}
+
+	foodID := entity.FoodSourceCustom + ":" + strconv.FormatInt(id, 10)
+	if err := uc.recipes.RecomputeForFood(ctx, userID, foodID); err != nil {
+		log.Printf("recompute recipes using %s: %s", foodID, err)
+	}
+	return nil
+}
+
+// Get returns a single custom food with a namespaced ID
+func (uc *CustomFoodUseCase) Get(ctx context.Context, userID, id int64) (*entity.FoodDetails, error) {
+	details, err := uc.repo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if details == nil {
+		return nil, fmt.Errorf("custom food %d: %w", id, entity.ErrNotFound)
+	}
+
+	namespaced := withNamespace(*details)
+	return &namespaced, nil
+}
+
+// List returns all of a user's custom foods with namespaced IDs
+func (uc *CustomFoodUseCase) List(ctx context.Context, userID int64) ([]entity.FoodDetails, error) {
+	foods, err := uc.repo.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range foods {
+		foods[i] = withNamespace(foods[i])
+	}
+	return foods, nil
+}
+
+// validateCustomFood checks that every serving can be scaled by the unit conversion engine
+func validateCustomFood(input entity.CustomFoodInput) error {
+	if strings.TrimSpace(input.Name) == "" {
+		return fmt.Errorf("%w: name is required", entity.ErrInvalidInput)
+	}
+	if len(input.Servings) == 0 {
+		return fmt.Errorf("%w: at least one serving is required", entity.ErrInvalidInput)
+	}
+
+	for i, serving := range input.Servings {
+		if strings.TrimSpace(serving.Description) == "" {
+			return fmt.Errorf("%w: serving %d: description is required", entity.ErrInvalidInput, i)
+		}
+		if serving.MetricServingAmount <= 0 {
+			return fmt.Errorf("%w: serving %d: metric_serving_amount must be positive", entity.ErrInvalidInput, i)
+		}
+		if _, err := units.Parse(serving.MetricServingUnit); err != nil {
+			return fmt.Errorf("%w: serving %d: %s", entity.ErrInvalidInput, i, err)
+		}
+		if serving.Calories < 0 || serving.Carbs < 0 || serving.Protein < 0 || serving.Fat < 0 {
+			return fmt.Errorf("%w: serving %d: nutrients cannot be negative", entity.ErrInvalidInput, i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,346 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/nutriscore"
+	"CalorieCompass/internal/pkg/nutrition"
+	"CalorieCompass/internal/pkg/units"
+)
+
+// DiaryRepository defines the interface for food diary storage
+type DiaryRepository interface {
+	Create(ctx context.Context, userID int64, entry entity.DiaryEntry) (int64, error)
+	CreateBatch(ctx context.Context, userID int64, entries []entity.DiaryEntry) ([]int64, error)
+	Delete(ctx context.Context, userID, id int64) error
+	GetByID(ctx context.Context, userID, id int64) (*entity.DiaryEntry, error)
+	ListByDate(ctx context.Context, userID int64, date time.Time) ([]entity.DiaryEntry, error)
+	ListEatenBetween(ctx context.Context, userID int64, from, to time.Time) ([]entity.DiaryEntry, error)
+}
+
+// FoodResolver resolves nutrition and details for features that store only food IDs
+type FoodResolver interface {
+	GetFoodNutrition(ctx context.Context, request entity.FoodNutritionRequest) (*entity.FoodNutritionResponse, error)
+	GetFoodDetailsBatch(ctx context.Context, userID int64, foodIDs []string) entity.FoodBatchResponse
+	ResolveFoodDetailsBatch(ctx context.Context, userID int64, foodIDs []string) entity.FoodBatchResponse
+}
+
+// DiaryUseCase handles business logic for the food diary
+type DiaryUseCase struct {
+	repo      DiaryRepository
+	foods     FoodResolver
+	exercises ExerciseLog
+	hydration HydrationSummarizer
+	goals     GoalResolver
+}
+
+// NewDiaryUseCase creates a new diary use case
+func NewDiaryUseCase(repo DiaryRepository, foods FoodResolver, exercises ExerciseLog, hydration HydrationSummarizer, goals GoalResolver) *DiaryUseCase {
+	return &DiaryUseCase{
+		repo:      repo,
+		foods:     foods,
+		exercises: exercises,
+		hydration: hydration,
+		goals:     goals,
+	}
+}
+
+// AddEntry logs a food, storing a snapshot of its nutrients scaled to the logged amount
+func (uc *DiaryUseCase) AddEntry(ctx context.Context, userID int64, input entity.DiaryEntryInput) (*entity.DiaryEntry, error) {
+	date, err := ParseDate(input.Date)
+	if err != nil {
+		return nil, err
+	}
+	eatenAt, err := entryEatenAt(date, input.Meal, input.EatenAt)
+	if err != nil {
+		return nil, err
+	}
+
+	scaled, err := resolveNutrition(ctx, uc.foods, entity.FoodNutritionRequest{
+		UserID:    userID,
+		FoodID:    input.FoodID,
+		Amount:    input.Amount,
+		Unit:      input.Unit,
+		ServingID: input.ServingID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entry := entity.DiaryEntry{
+		Date:      date.Format(entity.DateLayout),
+		Meal:      input.Meal,
+		FoodID:    scaled.FoodID,
+		FoodName:  scaled.Name,
+		BrandName: scaled.BrandName,
+		ServingID: scaled.BaseServingID,
+		Amount:    scaled.Amount,
+		Unit:      scaled.Unit,
+		EatenAt:   eatenAt,
+		Nutrition: scaled.Nutrition,
+	}
+
+	id, err := uc.repo.Create(ctx, userID, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.repo.GetByID(ctx, userID, id)
+}
+
+// DeleteEntry removes a diary entry
+func (uc *DiaryUseCase) DeleteEntry(ctx context.Context, userID, id int64) error {
+	return uc.repo.Delete(ctx, userID, id)
+}
+
+// GetDay returns a day's entries grouped by meal slot with per-meal and daily totals, the
+// day's exercise, the balance of calories eaten and burned, the day's hydration and the
+// progress against the targets active on the date
+func (uc *DiaryUseCase) GetDay(ctx context.Context, userID int64, date time.Time) (*entity.DiaryDay, error) {
+	entries, err := uc.repo.ListByDate(ctx, userID, date)
+	if err != nil {
+		return nil, err
+	}
+	exercises, err := uc.exercises.ListEntries(ctx, userID, date, date)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := uc.exercises.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	hydration, err := uc.hydration.Summarize(ctx, userID, date, entries, exercises)
+	if err != nil {
+		return nil, err
+	}
+	target, err := uc.goals.TargetFor(ctx, userID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	day := buildDiaryDay(date, entries)
+	applyExercise(day, exercises, settings.AddBurnedCalories)
+	day.Hydration = *hydration
+	if target != nil {
+		day.Targets = dailyTargets(*target, day.Totals, day.Summary.Net)
+	}
+	return day, nil
+}
+
+// Copy copies a meal slot, or the whole day when no slot is given, to another date. Copies
+// keep their time of day and nutrient snapshots, scaled by input.Scale, and are inserted in
+// one transaction. It returns the target day.
+func (uc *DiaryUseCase) Copy(ctx context.Context, userID int64, input entity.DiaryCopyInput) (*entity.DiaryDay, error) {
+	from, err := ParseDate(input.FromDate)
+	if err != nil {
+		return nil, err
+	}
+	to, err := ParseDate(input.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	if input.ToMeal != "" && input.Meal == "" {
+		return nil, fmt.Errorf("%w: to_meal requires meal", entity.ErrInvalidInput)
+	}
+	toMeal := input.ToMeal
+	if toMeal == "" {
+		toMeal = input.Meal
+	}
+	if from.Equal(to) && toMeal == input.Meal {
+		return nil, fmt.Errorf("%w: source and target are the same", entity.ErrInvalidInput)
+	}
+	scale := input.Scale
+	if scale == 0 {
+		scale = 1
+	}
+
+	entries, err := uc.repo.ListByDate(ctx, userID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	days := int(to.Sub(from).Hours() / 24)
+	copies := make([]entity.DiaryEntry, 0, len(entries))
+	for _, entry := range entries {
+		if input.Meal != "" && entry.Meal != input.Meal {
+			continue
+		}
+		entry = scaleEntry(entry, scale)
+		entry.Date = to.Format(entity.DateLayout)
+		if toMeal != "" {
+			entry.Meal = toMeal
+		}
+		entry.EatenAt = entry.EatenAt.AddDate(0, 0, days)
+		copies = append(copies, entry)
+	}
+	if len(copies) == 0 {
+		return nil, fmt.Errorf("%w: nothing logged to copy on %s", entity.ErrInvalidInput, input.FromDate)
+	}
+
+	if _, err := uc.repo.CreateBatch(ctx, userID, copies); err != nil {
+		return nil, err
+	}
+
+	return uc.GetDay(ctx, userID, to)
+}
+
+// buildDiaryDay groups entries by meal slot; every slot is present even when empty
+func buildDiaryDay(date time.Time, entries []entity.DiaryEntry) *entity.DiaryDay {
+	day := &entity.DiaryDay{
+		Date:  date.Format(entity.DateLayout),
+		Meals: make([]entity.DiaryMeal, 0, len(entity.Meals)),
+	}
+
+	var dayTotals []entity.Serving
+	for _, meal := range entity.Meals {
+		group := entity.DiaryMeal{Meal: meal, Entries: make([]entity.DiaryEntry, 0)}
+		var mealTotals []entity.Serving
+		for _, entry := range entries {
+			if entry.Meal == meal {
+				group.Entries = append(group.Entries, entry)
+				mealTotals = append(mealTotals, entry.Nutrition)
+			}
+		}
+		group.Totals = nutrition.Sum(mealTotals...)
+		dayTotals = append(dayTotals, group.Totals)
+		day.Meals = append(day.Meals, group)
+	}
+	day.Totals = nutrition.Sum(dayTotals...)
+	day.Exercises = make([]entity.ExerciseEntry, 0)
+	day.Summary = entity.DiarySummary{
+		Consumed: day.Totals.Calories,
+		Net:      day.Totals.Calories,
+		NetCarbs: round1(nutrition.NetCarbs(day.Totals.Carbs, day.Totals.Fiber)),
+		Quality:  dietQuality(entries, day.Totals),
+	}
+
+	return day
+}
+
+// dietQuality rates a day's entries; it returns nil when nothing with energy was logged
+func dietQuality(entries []entity.DiaryEntry, totals entity.Serving) *entity.DietQuality {
+	density, ok := nutrition.NutrientDensity(totals)
+	if !ok {
+		return nil
+	}
+	density = round1(density)
+	quality := &entity.DietQuality{NutrientDensity: &density}
+
+	var weighted, scored float64
+	for _, entry := range entries {
+		per100, ok := nutrition.Per100(entry.Nutrition)
+		if !ok || entry.Nutrition.Calories <= 0 {
+			continue
+		}
+		grade := nutriscore.Compute(per100).Grade
+		weighted += float64(nutriscore.GradeValue(grade)) * entry.Nutrition.Calories
+		scored += entry.Nutrition.Calories
+	}
+	if scored > 0 {
+		average := weighted / scored
+		quality.GradeAverage = round1(average)
+		quality.Grade = nutriscore.Grades[int(math.Round(average))-1]
+		quality.ScoredShare = round1(scored / totals.Calories * 100)
+	}
+	return quality
+}
+
+// scaleEntry multiplies an entry's amount and nutrient snapshot
+func scaleEntry(entry entity.DiaryEntry, scale float64) entity.DiaryEntry {
+	entry.Amount *= scale
+	entry.Nutrition = units.MultiplyServing(entry.Nutrition, scale)
+	return entry
+}
+
+// defaultMealTimes are the times of day (UTC) given to entries logged to another day than
+// today without an eaten_at time
+var defaultMealTimes = map[string]time.Duration{
+	entity.MealBreakfast: 8 * time.Hour,
+	entity.MealLunch:     12*time.Hour + 30*time.Minute,
+	entity.MealSnack:     15*time.Hour + 30*time.Minute,
+	entity.MealDinner:    19 * time.Hour,
+}
+
+// entryEatenAt returns when food logged to a date and meal slot was eaten: the given time,
+// which must fall on the date (UTC), or else now when logging to today and the slot's usual
+// time of day on other dates
+func entryEatenAt(date time.Time, meal string, eatenAt *time.Time) (time.Time, error) {
+	day := date.Format(entity.DateLayout)
+	if eatenAt != nil {
+		if eatenAt.UTC().Format(entity.DateLayout) != day {
+			return time.Time{}, fmt.Errorf("%w: eaten_at %s is not on %s (UTC)", entity.ErrInvalidInput,
+				eatenAt.Format(time.RFC3339), day)
+		}
+		return *eatenAt, nil
+	}
+
+	now := time.Now()
+	if now.UTC().Format(entity.DateLayout) == day {
+		return now, nil
+	}
+	return date.Add(defaultMealTimes[meal]), nil
+}
+
+// ParseDate parses a calendar date in entity.DateLayout
+func ParseDate(value string) (time.Time, error) {
+	date, err := time.Parse(entity.DateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: date must be formatted as YYYY-MM-DD", entity.ErrInvalidInput)
+	}
+	return date, nil
+}
+
+// resolveNutrition scales a food to the requested amount, reporting unknown foods as
+// entity.ErrNotFound and bad units or servings as entity.ErrInvalidInput
+func resolveNutrition(ctx context.Context, foods FoodResolver, request entity.FoodNutritionRequest) (*entity.FoodNutritionResponse, error) {
+	scaled, err := foods.GetFoodNutrition(ctx, request)
+	if err != nil {
+		if errors.Is(err, units.ErrUnknownUnit) || errors.Is(err, units.ErrIncompatibleUnits) {
+			return nil, fmt.Errorf("%w: %s", entity.ErrInvalidInput, err)
+		}
+		return nil, err
+	}
+	if scaled == nil {
+		return nil, fmt.Errorf("food %s: %w", request.FoodID, entity.ErrNotFound)
+	}
+	return scaled, nil
+}
+
+// resolveAll resolves several foods concurrently. The first failure is returned, labelled
+// with its position, e.g. "ingredient 2 (fs:33691): ...".
+func resolveAll(ctx context.Context, foods FoodResolver, label string, requests []entity.FoodNutritionRequest) ([]*entity.FoodNutritionResponse, error) {
+	resolved := make([]*entity.FoodNutritionResponse, len(requests))
+	errs := make([]error, len(requests))
+	sem := make(chan struct{}, ingredientWorkers)
+	var wg sync.WaitGroup
+	for i, request := range requests {
+		wg.Add(1)
+		go func(i int, request entity.FoodNutritionRequest) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			scaled, err := resolveNutrition(ctx, foods, request)
+			if err != nil {
+				errs[i] = fmt.Errorf("%s %d (%s): %w", label, i, request.FoodID, err)
+				return
+			}
+			resolved[i] = scaled
+		}(i, request)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resolved, nil
+}
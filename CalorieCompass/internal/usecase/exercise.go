@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/activity"
+)
+
+// ExerciseRepository defines the interface for exercise storage
+type ExerciseRepository interface {
+	Search(ctx context.Context, userID int64, query, category string, limit int) ([]entity.Exercise, error)
+	GetByID(ctx context.Context, userID, id int64) (*entity.Exercise, error)
+	Create(ctx context.Context, userID int64, exercise entity.Exercise) (int64, error)
+	Update(ctx context.Context, userID int64, exercise entity.Exercise) error
+	Delete(ctx context.Context, userID, id int64) error
+	CreateEntry(ctx context.Context, userID int64, entry entity.ExerciseEntry) (int64, error)
+	DeleteEntry(ctx context.Context, userID, id int64) error
+	GetEntry(ctx context.Context, userID, id int64) (*entity.ExerciseEntry, error)
+	ListEntries(ctx context.Context, userID int64, from, to time.Time) ([]entity.ExerciseEntry, error)
+	GetSettings(ctx context.Context, userID int64) (*entity.ExerciseSettings, error)
+	SaveSettings(ctx context.Context, userID int64, settings entity.ExerciseSettings) error
+}
+
+// ExerciseLog provides the logged exercise and exercise settings the diary summary needs
+type ExerciseLog interface {
+	ListEntries(ctx context.Context, userID int64, from, to time.Time) ([]entity.ExerciseEntry, error)
+	GetSettings(ctx context.Context, userID int64) (*entity.ExerciseSettings, error)
+}
+
+// DefaultExerciseLimit is the default number of exercises returned by a search
+const DefaultExerciseLimit = 50
+
+// distanceUnits maps distance unit names to km
+var distanceUnits = map[string]float64{
+	"":   1,
+	"km": 1,
+	"m":  0.001,
+	"mi": 1.609344,
+}
+
+// ExerciseUseCase handles business logic for exercises and exercise logging
+type ExerciseUseCase struct {
+	repo    ExerciseRepository
+	weights WeightRepository
+}
+
+// NewExerciseUseCase creates a new exercise use case
+func NewExerciseUseCase(repo ExerciseRepository, weights WeightRepository) *ExerciseUseCase {
+	return &ExerciseUseCase{
+		repo:    repo,
+		weights: weights,
+	}
+}
+
+// Search returns catalog and user-defined exercises matching a name and category
+func (uc *ExerciseUseCase) Search(ctx context.Context, userID int64, query, category string, limit int) ([]entity.Exercise, error) {
+	if limit <= 0 {
+		limit = DefaultExerciseLimit
+	}
+	return uc.repo.Search(ctx, userID, query, category, limit)
+}
+
+// Create stores a user-defined exercise
+func (uc *ExerciseUseCase) Create(ctx context.Context, userID int64, input entity.ExerciseInput) (*entity.Exercise, error) {
+	exercise, err := buildExercise(input)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uc.repo.Create(ctx, userID, exercise)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.repo.GetByID(ctx, userID, id)
+}
+
+// Update replaces a user-defined exercise
+func (uc *ExerciseUseCase) Update(ctx context.Context, userID, id int64, input entity.ExerciseInput) (*entity.Exercise, error) {
+	exercise, err := buildExercise(input)
+	if err != nil {
+		return nil, err
+	}
+	exercise.ID = id
+
+	if err := uc.repo.Update(ctx, userID, exercise); err != nil {
+		return nil, err
+	}
+
+	return uc.repo.GetByID(ctx, userID, id)
+}
+
+// Delete removes a user-defined exercise
+func (uc *ExerciseUseCase) Delete(ctx context.Context, userID, id int64) error {
+	return uc.repo.Delete(ctx, userID, id)
+}
+
+// Log records an exercise with the calories burned at the user's latest weight on or before
+// the exercise date
+func (uc *ExerciseUseCase) Log(ctx context.Context, userID int64, input entity.ExerciseEntryInput) (*entity.ExerciseEntry, error) {
+	date, err := ParseDate(input.Date)
+	if err != nil {
+		return nil, err
+	}
+	if input.Duration <= 0 && input.Distance <= 0 {
+		return nil, fmt.Errorf("%w: duration or distance is required", entity.ErrInvalidInput)
+	}
+
+	exercise, err := uc.repo.GetByID(ctx, userID, input.ExerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	intensity := input.Intensity
+	if intensity == "" {
+		intensity = entity.IntensityModerate
+	}
+	met := intensityMET(*exercise, intensity)
+	duration := input.Duration
+
+	var distance *float64
+	if input.Distance > 0 {
+		factor, ok := distanceUnits[input.DistanceUnit]
+		if !ok {
+			return nil, fmt.Errorf("%w: distance unit must be km, m or mi", entity.ErrInvalidInput)
+		}
+		km := round2(input.Distance * factor)
+		distance = &km
+
+		switch {
+		case exercise.SpeedKmh <= 0 && duration <= 0:
+			return nil, fmt.Errorf("%w: %s cannot be logged by distance alone, give a duration", entity.ErrInvalidInput, exercise.Name)
+		case exercise.SpeedKmh <= 0:
+			// The distance is only informative
+		case duration > 0:
+			speed := km / (duration / 60)
+			if limit := activity.MaxSpeed(exercise.MET, exercise.SpeedKmh); limit > 0 && speed > limit {
+				return nil, fmt.Errorf("%w: %.1f km/h is not a plausible speed for %s (at most %.1f km/h)",
+					entity.ErrInvalidInput, speed, exercise.Name, limit)
+			}
+			met = activity.SpeedMET(exercise.MET, exercise.SpeedKmh, speed)
+		default:
+			duration = activity.Duration(km, intensitySpeed(*exercise, met))
+		}
+	}
+
+	weight, err := uc.weights.Latest(ctx, userID, date)
+	if err != nil {
+		return nil, err
+	}
+	if weight == nil {
+		return nil, fmt.Errorf("%w: record a weight on or before %s to compute calories burned",
+			entity.ErrInvalidInput, date.Format(entity.DateLayout))
+	}
+
+	met = math.Round(met*10) / 10
+	duration = math.Round(duration*10) / 10
+	id, err := uc.repo.CreateEntry(ctx, userID, entity.ExerciseEntry{
+		Date:       date.Format(entity.DateLayout),
+		ExerciseID: &exercise.ID,
+		Name:       exercise.Name,
+		Intensity:  intensity,
+		Duration:   duration,
+		Distance:   distance,
+		MET:        met,
+		Weight:     weight.Weight,
+		Calories:   math.Round(activity.Calories(met, weight.Weight, duration)),
+		Note:       input.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.repo.GetEntry(ctx, userID, id)
+}
+
+// DeleteEntry removes a logged exercise
+func (uc *ExerciseUseCase) DeleteEntry(ctx context.Context, userID, id int64) error {
+	return uc.repo.DeleteEntry(ctx, userID, id)
+}
+
+// ListEntries returns the exercise logged between two dates
+func (uc *ExerciseUseCase) ListEntries(ctx context.Context, userID int64, from, to time.Time) ([]entity.ExerciseEntry, error) {
+	return uc.repo.ListEntries(ctx, userID, from, to)
+}
+
+// GetSettings returns the user's exercise settings
+func (uc *ExerciseUseCase) GetSettings(ctx context.Context, userID int64) (*entity.ExerciseSettings, error) {
+	return uc.repo.GetSettings(ctx, userID)
+}
+
+// UpdateSettings replaces the user's exercise settings
+func (uc *ExerciseUseCase) UpdateSettings(ctx context.Context, userID int64, input entity.ExerciseSettingsInput) (*entity.ExerciseSettings, error) {
+	if err := uc.repo.SaveSettings(ctx, userID, entity.ExerciseSettings{AddBurnedCalories: input.AddBurnedCalories}); err != nil {
+		return nil, err
+	}
+	return uc.repo.GetSettings(ctx, userID)
+}
+
+// buildExercise validates a user-defined exercise, defaulting the light and vigorous METs to
+// the moderate MET
+func buildExercise(input entity.ExerciseInput) (entity.Exercise, error) {
+	exercise := entity.Exercise{
+		Name:        input.Name,
+		Category:    input.Category,
+		LightMET:    input.LightMET,
+		MET:         input.MET,
+		VigorousMET: input.VigorousMET,
+		SpeedKmh:    input.SpeedKmh,
+	}
+	if exercise.LightMET == 0 {
+		exercise.LightMET = exercise.MET
+	}
+	if exercise.VigorousMET == 0 {
+		exercise.VigorousMET = exercise.MET
+	}
+	if exercise.LightMET > exercise.MET || exercise.MET > exercise.VigorousMET {
+		return entity.Exercise{}, fmt.Errorf("%w: METs must not decrease from light to vigorous", entity.ErrInvalidInput)
+	}
+	return exercise, nil
+}
+
+// intensityMET returns an exercise's MET at an intensity
+func intensityMET(exercise entity.Exercise, intensity string) float64 {
+	switch intensity {
+	case entity.IntensityLight:
+		return exercise.LightMET
+	case entity.IntensityVigorous:
+		return exercise.VigorousMET
+	default:
+		return exercise.MET
+	}
+}
+
+// intensitySpeed returns the speed at which an exercise costs met, the inverse of
+// activity.SpeedMET around the exercise's reference speed
+func intensitySpeed(exercise entity.Exercise, met float64) float64 {
+	if exercise.MET <= activity.RestingMET || met <= activity.RestingMET {
+		return exercise.SpeedKmh
+	}
+	return exercise.SpeedKmh * (met - activity.RestingMET) / (exercise.MET - activity.RestingMET)
+}
+
+// applyExercise adds the day's exercise to a diary day and balances the calories eaten
+// against the calories burned
+func applyExercise(day *entity.DiaryDay, exercises []entity.ExerciseEntry, addBurned bool) {
+	day.Exercises = exercises
+	burned := 0.0
+	for _, exercise := range exercises {
+		burned += exercise.Calories
+	}
+
+	day.Summary.Burned = burned
+	day.Summary.BurnedAddedBack = addBurned
+	day.Summary.Net = day.Summary.Consumed
+	if addBurned {
+		day.Summary.Net -= burned
+	}
+}
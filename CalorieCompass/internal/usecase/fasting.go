@@ -0,0 +1,303 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"CalorieCompass/internal/entity"
+)
+
+// FastingRepository defines the interface for fasting session storage
+type FastingRepository interface {
+	Create(ctx context.Context, userID int64, session entity.FastingSession) (int64, error)
+	End(ctx context.Context, userID, id int64, endedAt time.Time) error
+	Delete(ctx context.Context, userID, id int64) error
+	Get(ctx context.Context, userID, id int64) (*entity.FastingSession, error)
+	Latest(ctx context.Context, userID int64) (*entity.FastingSession, error)
+	List(ctx context.Context, userID int64, from, to time.Time) ([]entity.FastingSession, error)
+}
+
+// fastingCalorieAllowance is the most calories a diary entry may have without breaking a
+// fast, so black coffee, tea and other near-zero drinks can be logged while fasting
+const fastingCalorieAllowance = 10
+
+// fastingProtocols are the preset fasting schedules
+var fastingProtocols = []entity.FastingProtocol{
+	{Code: "12:12", Name: "12:12", FastingHours: 12, EatingHours: 12, Description: "Overnight fast with a 12-hour eating window"},
+	{Code: "14:10", Name: "14:10", FastingHours: 14, EatingHours: 10, Description: "14-hour fast with a 10-hour eating window"},
+	{Code: "16:8", Name: "16:8", FastingHours: 16, EatingHours: 8, Description: "16-hour fast with an 8-hour eating window"},
+	{Code: "18:6", Name: "18:6", FastingHours: 18, EatingHours: 6, Description: "18-hour fast with a 6-hour eating window"},
+	{Code: "20:4", Name: "20:4", FastingHours: 20, EatingHours: 4, Description: "20-hour fast with a 4-hour eating window"},
+	{Code: "omad", Name: "One meal a day", FastingHours: 23, EatingHours: 1, Description: "23-hour fast with a single meal"},
+	{Code: "36h", Name: "36-hour fast", FastingHours: 36, EatingHours: 12, Description: "Fast from dinner until breakfast two days later"},
+	{Code: entity.FastingProtocolCustom, Name: "Custom", Description: "Fast of a chosen length"},
+}
+
+// FastingUseCase handles business logic for intermittent fasting
+type FastingUseCase struct {
+	repo  FastingRepository
+	diary DiaryRepository
+}
+
+// NewFastingUseCase creates a new fasting use case
+func NewFastingUseCase(repo FastingRepository, diary DiaryRepository) *FastingUseCase {
+	return &FastingUseCase{
+		repo:  repo,
+		diary: diary,
+	}
+}
+
+// Protocols returns the preset fasting schedules
+func (uc *FastingUseCase) Protocols() []entity.FastingProtocol {
+	return fastingProtocols
+}
+
+// Start starts a fast. Only one fast can be in progress and fasts cannot overlap.
+func (uc *FastingUseCase) Start(ctx context.Context, userID int64, input entity.FastingStartInput) (*entity.FastingSession, error) {
+	protocol, ok := findProtocol(input.Protocol)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown protocol %q", entity.ErrInvalidInput, input.Protocol)
+	}
+	hours := protocol.FastingHours
+	if input.Hours > 0 {
+		hours = input.Hours
+	}
+	if hours <= 0 {
+		return nil, fmt.Errorf("%w: hours is required for a custom fast", entity.ErrInvalidInput)
+	}
+
+	now := time.Now()
+	startedAt := now
+	if input.StartedAt != nil {
+		startedAt = *input.StartedAt
+	}
+	if startedAt.After(now) {
+		return nil, fmt.Errorf("%w: a fast cannot start in the future", entity.ErrInvalidInput)
+	}
+
+	latest, err := uc.repo.Latest(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && latest.EndedAt == nil {
+		return nil, fmt.Errorf("%w: a fast is already in progress", entity.ErrInvalidInput)
+	}
+	if latest != nil && startedAt.Before(*latest.EndedAt) {
+		return nil, fmt.Errorf("%w: a fast cannot start before the previous one ended", entity.ErrInvalidInput)
+	}
+
+	id, err := uc.repo.Create(ctx, userID, entity.FastingSession{
+		Protocol:     protocol.Code,
+		StartedAt:    startedAt,
+		PlannedHours: hours,
+		Note:         input.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.session(ctx, userID, id)
+}
+
+// End ends the fast in progress
+func (uc *FastingUseCase) End(ctx context.Context, userID int64, input entity.FastingEndInput) (*entity.FastingSession, error) {
+	active, err := uc.Current(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	endedAt := now
+	if input.EndedAt != nil {
+		endedAt = *input.EndedAt
+	}
+	if endedAt.After(now) {
+		return nil, fmt.Errorf("%w: a fast cannot end in the future", entity.ErrInvalidInput)
+	}
+	if !endedAt.After(active.StartedAt) {
+		return nil, fmt.Errorf("%w: a fast must end after it started", entity.ErrInvalidInput)
+	}
+
+	if err := uc.repo.End(ctx, userID, active.ID, endedAt); err != nil {
+		return nil, err
+	}
+
+	return uc.session(ctx, userID, active.ID)
+}
+
+// Current returns the fast in progress with its violations so far, or an
+// entity.ErrNotFound error
+func (uc *FastingUseCase) Current(ctx context.Context, userID int64) (*entity.FastingSession, error) {
+	latest, err := uc.repo.Latest(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil || latest.EndedAt != nil {
+		return nil, fmt.Errorf("active fasting session: %w", entity.ErrNotFound)
+	}
+
+	sessions := []entity.FastingSession{*latest}
+	if err := uc.evaluate(ctx, userID, sessions); err != nil {
+		return nil, err
+	}
+	return &sessions[0], nil
+}
+
+// Delete removes a fast
+func (uc *FastingUseCase) Delete(ctx context.Context, userID, id int64) error {
+	return uc.repo.Delete(ctx, userID, id)
+}
+
+// List returns the fasts started between two dates (inclusive) with their violations
+func (uc *FastingUseCase) List(ctx context.Context, userID int64, from, to time.Time) ([]entity.FastingSession, error) {
+	sessions, err := uc.repo.List(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.evaluate(ctx, userID, sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// Stats summarizes the fasts started between two dates (inclusive). The fast in progress
+// counts towards neither the completion rate nor the averages. The current streak ends on
+// the last date, or the day before when no completed fast ended on it yet.
+func (uc *FastingUseCase) Stats(ctx context.Context, userID int64, from, to time.Time) (*entity.FastingStats, error) {
+	sessions, err := uc.List(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &entity.FastingStats{
+		From: from.Format(entity.DateLayout),
+		To:   to.Format(entity.DateLayout),
+	}
+	completedDays := make(map[string]bool)
+	totalHours := 0.0
+	for _, session := range sessions {
+		stats.Violations += len(session.Violations)
+		if session.Status == entity.FastingActive {
+			continue
+		}
+
+		stats.Sessions++
+		totalHours += session.Hours
+		stats.LongestHours = math.Max(stats.LongestHours, session.Hours)
+		if session.Status == entity.FastingCompleted {
+			stats.Completed++
+			completedDays[session.EndedAt.UTC().Format(entity.DateLayout)] = true
+		}
+	}
+	if stats.Sessions > 0 {
+		stats.CompletionRate = math.Round(float64(stats.Completed)/float64(stats.Sessions)*1000) / 10
+		stats.AverageHours = math.Round(totalHours/float64(stats.Sessions)*10) / 10
+	}
+
+	// Fasts started in the range can end the day after it
+	streak := 0
+	for day := from; !day.After(to.AddDate(0, 0, 1)); day = day.AddDate(0, 0, 1) {
+		if !completedDays[day.Format(entity.DateLayout)] {
+			streak = 0
+			continue
+		}
+		streak++
+		stats.LongestStreak = max(stats.LongestStreak, streak)
+	}
+
+	day := to
+	if !completedDays[day.Format(entity.DateLayout)] {
+		day = day.AddDate(0, 0, -1)
+	}
+	for ; !day.Before(from) && completedDays[day.Format(entity.DateLayout)]; day = day.AddDate(0, 0, -1) {
+		stats.CurrentStreak++
+	}
+
+	return stats, nil
+}
+
+// session loads a fast and evaluates it
+func (uc *FastingUseCase) session(ctx context.Context, userID, id int64) (*entity.FastingSession, error) {
+	session, err := uc.repo.Get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := []entity.FastingSession{*session}
+	if err := uc.evaluate(ctx, userID, sessions); err != nil {
+		return nil, err
+	}
+	return &sessions[0], nil
+}
+
+// evaluate fills in the planned end, length, progress, status and violations of fasts
+// ordered by start. Violations are diary entries above the calorie allowance eaten after a
+// fast started and before it ended, or before now while it is in progress.
+func (uc *FastingUseCase) evaluate(ctx context.Context, userID int64, sessions []entity.FastingSession) error {
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	from, to := sessions[0].StartedAt, now
+	if last := sessions[len(sessions)-1]; last.EndedAt != nil {
+		to = *last.EndedAt
+	}
+	entries, err := uc.diary.ListEatenBetween(ctx, userID, from, to)
+	if err != nil {
+		return err
+	}
+
+	for i := range sessions {
+		session := &sessions[i]
+		end := now
+		if session.EndedAt != nil {
+			end = *session.EndedAt
+		}
+
+		session.Violations = []entity.FastingViolation{}
+		for _, entry := range entries {
+			if !entry.EatenAt.After(session.StartedAt) || !entry.EatenAt.Before(end) {
+				continue
+			}
+			if entry.Nutrition.Calories <= fastingCalorieAllowance {
+				continue
+			}
+			session.Violations = append(session.Violations, entity.FastingViolation{
+				EntryID:  entry.ID,
+				FoodName: entry.FoodName,
+				EatenAt:  entry.EatenAt,
+				Calories: entry.Nutrition.Calories,
+			})
+		}
+
+		hours := end.Sub(session.StartedAt).Hours()
+		session.PlannedEnd = session.StartedAt.Add(time.Duration(session.PlannedHours * float64(time.Hour)))
+		session.Hours = math.Round(hours*100) / 100
+		session.Progress = math.Round(hours/session.PlannedHours*1000) / 10
+
+		switch {
+		case session.EndedAt == nil:
+			session.Status = entity.FastingActive
+		case len(session.Violations) > 0:
+			session.Status = entity.FastingBroken
+		case hours < session.PlannedHours:
+			session.Status = entity.FastingEndedEarly
+		default:
+			session.Status = entity.FastingCompleted
+		}
+	}
+	return nil
+}
+
+// findProtocol looks up a preset fasting schedule by code
+func findProtocol(code string) (entity.FastingProtocol, bool) {
+	for _, protocol := range fastingProtocols {
+		if protocol.Code == code {
+			return protocol, true
+		}
+	}
+	return entity.FastingProtocol{}, false
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"CalorieCompass/internal/entity"
+)
+
+// FavoriteRepository defines the interface for favorite food storage
+type FavoriteRepository interface {
+	Upsert(ctx context.Context, userID int64, favorite entity.FavoriteFood) error
+	Delete(ctx context.Context, userID int64, foodID string) error
+	List(ctx context.Context, userID int64) ([]entity.FavoriteFood, error)
+}
+
+// RecentFoodRepository ranks foods from a user's logging history
+type RecentFoodRepository interface {
+	RecentFoods(ctx context.Context, userID int64, at time.Time, limit int) ([]entity.RecentFood, error)
+}
+
+const (
+	defaultRecentLimit = 20
+	maxRecentLimit     = 100
+)
+
+// FavoriteUseCase handles favorite and recent foods for one-tap logging
+type FavoriteUseCase struct {
+	repo       FavoriteRepository
+	recentRepo RecentFoodRepository
+	foods      FoodResolver
+}
+
+// NewFavoriteUseCase creates a new favorite food use case
+func NewFavoriteUseCase(repo FavoriteRepository, recentRepo RecentFoodRepository, foods FoodResolver) *FavoriteUseCase {
+	return &FavoriteUseCase{
+		repo:       repo,
+		recentRepo: recentRepo,
+		foods:      foods,
+	}
+}
+
+// Add stores a favorite with its default amount, replacing an existing favorite for the
+// same food. Only the food ID and a snapshot of its name and macros are kept locally.
+func (uc *FavoriteUseCase) Add(ctx context.Context, userID int64, input entity.FavoriteFoodInput) (*entity.FavoriteFood, error) {
+	if input.Amount == 0 {
+		input.Amount = 1
+	}
+	if strings.TrimSpace(input.Unit) == "" {
+		input.Unit = "serving"
+	}
+
+	scaled, err := resolveNutrition(ctx, uc.foods, entity.FoodNutritionRequest{
+		UserID:    userID,
+		FoodID:    input.FoodID,
+		Amount:    input.Amount,
+		Unit:      input.Unit,
+		ServingID: input.ServingID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	favorite := entity.FavoriteFood{
+		FoodID:    scaled.FoodID,
+		FoodName:  scaled.Name,
+		BrandName: scaled.BrandName,
+		ServingID: scaled.BaseServingID,
+		Amount:    scaled.Amount,
+		Unit:      scaled.Unit,
+		Calories:  scaled.Nutrition.Calories,
+		Carbs:     scaled.Nutrition.Carbs,
+		Protein:   scaled.Nutrition.Protein,
+		Fat:       scaled.Nutrition.Fat,
+		CreatedAt: time.Now(),
+	}
+
+	if err := uc.repo.Upsert(ctx, userID, favorite); err != nil {
+		return nil, err
+	}
+	return &favorite, nil
+}
+
+// Remove deletes a favorite by food ID; bare IDs are treated as FatSecret IDs like everywhere else
+func (uc *FavoriteUseCase) Remove(ctx context.Context, userID int64, foodID string) error {
+	source, id := ParseFoodID(foodID)
+	return uc.repo.Delete(ctx, userID, source+":"+id)
+}
+
+// List returns the user's favorites; with details set, full food details are resolved
+// through the food use case
+func (uc *FavoriteUseCase) List(ctx context.Context, userID int64, details bool) ([]entity.FavoriteFood, error) {
+	favorites, err := uc.repo.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if details && len(favorites) > 0 {
+		foodIDs := make([]string, 0, len(favorites))
+		for _, favorite := range favorites {
+			foodIDs = append(foodIDs, favorite.FoodID)
+		}
+		resolved := uc.resolveDetails(ctx, userID, foodIDs)
+		for i := range favorites {
+			favorites[i].Food = resolved[favorites[i].FoodID]
+		}
+	}
+
+	return favorites, nil
+}
+
+// Recent returns foods from the user's diary ranked by frequency, recency and closeness to
+// the time of day of at, which carries the client's time zone
+func (uc *FavoriteUseCase) Recent(ctx context.Context, userID int64, at time.Time, limit int, details bool) ([]entity.RecentFood, error) {
+	if limit <= 0 {
+		limit = defaultRecentLimit
+	}
+	if limit > maxRecentLimit {
+		return nil, fmt.Errorf("%w: limit must not exceed %d", entity.ErrInvalidInput, maxRecentLimit)
+	}
+
+	recent, err := uc.recentRepo.RecentFoods(ctx, userID, at, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if details && len(recent) > 0 {
+		foodIDs := make([]string, 0, len(recent))
+		for _, food := range recent {
+			foodIDs = append(foodIDs, food.FoodID)
+		}
+		resolved := uc.resolveDetails(ctx, userID, foodIDs)
+		for i := range recent {
+			recent[i].Food = resolved[recent[i].FoodID]
+		}
+	}
+
+	return recent, nil
+}
+
+// resolveDetails fetches details for several foods; foods that cannot be resolved are left out
+func (uc *FavoriteUseCase) resolveDetails(ctx context.Context, userID int64, foodIDs []string) map[string]*entity.FoodDetails {
+	batch := uc.foods.GetFoodDetailsBatch(ctx, userID, foodIDs)
+	resolved := make(map[string]*entity.FoodDetails, len(batch.Items))
+	for _, item := range batch.Items {
+		if item.Food != nil {
+			resolved[item.FoodID] = item.Food
+		}
+	}
+	return resolved
+}
@@ -2,30 +2,62 @@ package usecase
 
 import (
 	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/nutriscore"
+	"CalorieCompass/internal/pkg/nutrition"
+	"CalorieCompass/internal/pkg/units"
 	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 // FoodRepository defines the interface for food data operations
 type FoodRepository interface {
-	SearchFoods(ctx context.Context, query string, page, limit int) ([]entity.Food, int, error)
+	SearchFoods(ctx context.Context, request entity.FoodSearchRequest) ([]entity.Food, int, error)
 	GetFoodDetails(ctx context.Context, foodID string) (*entity.FoodDetails, error)
 }
 
+// CustomFoodRepository defines the interface for user-defined food storage
+type CustomFoodRepository interface {
+	Create(ctx context.Context, userID int64, input entity.CustomFoodInput) (int64, error)
+	Update(ctx context.Context, userID, id int64, input entity.CustomFoodInput) error
+	Delete(ctx context.Context, userID, id int64) error
+	GetByID(ctx context.Context, userID, id int64) (*entity.FoodDetails, error)
+	List(ctx context.Context, userID int64) ([]entity.FoodDetails, error)
+}
+
 // FoodUseCase handles business logic for food operations
 type FoodUseCase struct {
-	repo FoodRepository
+	repo         FoodRepository
+	customRepo   CustomFoodRepository
+	recipeRepo   RecipeRepository
+	restrictions RestrictionRepository
 }
 
 // NewFoodUseCase creates a new food use case
-func NewFoodUseCase(repo FoodRepository) *FoodUseCase {
+func NewFoodUseCase(repo FoodRepository, customRepo CustomFoodRepository, recipeRepo RecipeRepository, restrictions RestrictionRepository) *FoodUseCase {
 	return &FoodUseCase{
-		repo: repo,
+		repo:         repo,
+		customRepo:   customRepo,
+		recipeRepo:   recipeRepo,
+		restrictions: restrictions,
 	}
 }
 
-// SearchFoods searches for foods by query
+// SearchFoods searches for foods by query. For signed-in users the results include their
+// matching custom foods, and are flagged against their restriction profile while the
+// providers are searched; strict profiles leave flagged and unverified foods out, and the
+// total is scaled to match.
 func (uc *FoodUseCase) SearchFoods(ctx context.Context, request entity.FoodSearchRequest) (entity.FoodSearchResponse, error) {
-	foods, totalResults, err := uc.repo.SearchFoods(ctx, request.Query, request.Page, request.Limit)
+	profile, err := restrictionProfile(ctx, uc.restrictions, request.UserID)
+	if err != nil {
+		return entity.FoodSearchResponse{}, err
+	}
+	request.Restrictions = profile
+
+	foods, totalResults, err := uc.repo.SearchFoods(ctx, request)
 	if err != nil {
 		return entity.FoodSearchResponse{}, err
 	}
@@ -38,7 +70,239 @@ func (uc *FoodUseCase) SearchFoods(ctx context.Context, request entity.FoodSearc
 	}, nil
 }
 
-// GetFoodDetails gets detailed information about a food
-func (uc *FoodUseCase) GetFoodDetails(ctx context.Context, foodID string) (*entity.FoodDetails, error) {
+// GetFoodDetails gets detailed information about a food, rated by its Nutri-Score and
+// nutrient density when a serving has a metric amount and flagged against the user's
+// restriction profile. Flagged foods are returned even for strict profiles. IDs may be
+// namespaced as "custom:123" for the user's own foods, "recipe:12" for their recipes or by
+// provider, e.g. "fs:456"; bare IDs are FatSecret IDs.
+func (uc *FoodUseCase) GetFoodDetails(ctx context.Context, userID int64, foodID string) (*entity.FoodDetails, error) {
+	profile, err := restrictionProfile(ctx, uc.restrictions, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := uc.ResolveFoodDetails(ctx, userID, foodID)
+	if err != nil || details == nil {
+		return nil, err
+	}
+	rateDetails(profile, details)
+	return details, nil
+}
+
+// rateDetails rates a food by its Nutri-Score and flags it against the profile
+func rateDetails(profile entity.RestrictionProfile, details *entity.FoodDetails) {
+	details.Quality = nutriscore.DetailsQuality(*details)
+	flagDetails(profile, details)
+}
+
+// ResolveFoodDetails looks a food up in the source its ID names without rating or flagging
+// it, for features that only need its servings and nutrients
+func (uc *FoodUseCase) ResolveFoodDetails(ctx context.Context, userID int64, foodID string) (*entity.FoodDetails, error) {
+	source, id := ParseFoodID(foodID)
+
+	if source == entity.FoodSourceCustom {
+		customID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil || userID == 0 {
+			return nil, nil
+		}
+		details, err := uc.customRepo.GetByID(ctx, userID, customID)
+		if err != nil || details == nil {
+			return nil, err
+		}
+		namespaced := withNamespace(*details)
+		return &namespaced, nil
+	}
+
+	if source == entity.FoodSourceRecipe {
+		recipeID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil || userID == 0 {
+			return nil, nil
+		}
+		recipe, err := uc.recipeRepo.GetByID(ctx, userID, recipeID)
+		if err != nil || recipe == nil {
+			return nil, err
+		}
+		details := recipeFoodDetails(*recipe)
+		return &details, nil
+	}
+
+	// Other namespaces are routed by the food repository
 	return uc.repo.GetFoodDetails(ctx, foodID)
 }
+
+// batchWorkers bounds concurrent detail lookups for a single batch request
+const batchWorkers = 8
+
+// GetFoodDetailsBatch resolves several foods like ResolveFoodDetailsBatch and rates and
+// flags them like GetFoodDetails, loading the restriction profile once for the batch.
+func (uc *FoodUseCase) GetFoodDetailsBatch(ctx context.Context, userID int64, foodIDs []string) entity.FoodBatchResponse {
+	profile, err := restrictionProfile(ctx, uc.restrictions, userID)
+	if err != nil {
+		items := batchItems(foodIDs)
+		for i := range items {
+			items[i].Error = err.Error()
+		}
+		return entity.FoodBatchResponse{Items: items, Failed: len(items)}
+	}
+
+	response := uc.ResolveFoodDetailsBatch(ctx, userID, foodIDs)
+	for _, item := range response.Items {
+		if item.Food != nil {
+			rateDetails(profile, item.Food)
+		}
+	}
+	return response
+}
+
+// ResolveFoodDetailsBatch resolves several foods concurrently with a bounded worker pool,
+// without rating or flagging them. Duplicate IDs are fetched once. Failures are reported
+// per item so that one missing or timed-out food does not fail the whole batch.
+func (uc *FoodUseCase) ResolveFoodDetailsBatch(ctx context.Context, userID int64, foodIDs []string) entity.FoodBatchResponse {
+	items := batchItems(foodIDs)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < batchWorkers && w < len(items); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				details, err := uc.ResolveFoodDetails(ctx, userID, items[i].FoodID)
+				switch {
+				case err != nil:
+					items[i].Error = err.Error()
+				case details == nil:
+					items[i].Error = entity.ErrNotFound.Error()
+				default:
+					items[i].Food = details
+				}
+			}
+		}()
+	}
+
+	for i := range items {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	response := entity.FoodBatchResponse{Items: items}
+	for _, item := range items {
+		if item.Error != "" {
+			response.Failed++
+		}
+	}
+	return response
+}
+
+// batchItems lists the distinct, non-blank food IDs of a batch in request order
+func batchItems(foodIDs []string) []entity.FoodBatchItem {
+	items := make([]entity.FoodBatchItem, 0, len(foodIDs))
+	seen := make(map[string]bool, len(foodIDs))
+	for _, foodID := range foodIDs {
+		foodID = strings.TrimSpace(foodID)
+		if foodID == "" || seen[foodID] {
+			continue
+		}
+		seen[foodID] = true
+		items = append(items, entity.FoodBatchItem{FoodID: foodID})
+	}
+	return items
+}
+
+// ParseFoodID splits a possibly namespaced food ID into its source and source-specific ID
+func ParseFoodID(foodID string) (string, string) {
+	if source, id, ok := strings.Cut(foodID, ":"); ok {
+		return source, id
+	}
+	return entity.FoodSourceFatSecret, foodID
+}
+
+// withNamespace prefixes a custom food's ID with its source so it can be resolved later
+func withNamespace(details entity.FoodDetails) entity.FoodDetails {
+	if !strings.Contains(details.ID, ":") {
+		details.ID = details.Source + ":" + details.ID
+	}
+	return details
+}
+
+// matchesFilters applies search filters to a custom food using its per-100 g values
+func matchesFilters(food entity.Food, details entity.FoodDetails, filters entity.FoodSearchFilters) bool {
+	if !nutrition.MatchesType(food, filters.Type) {
+		return false
+	}
+	if !filters.NeedsNutrients() {
+		return true
+	}
+	per100, ok := nutrition.DetailsPer100(details)
+	return ok && nutrition.Matches(filters, per100)
+}
+
+// GetFoodNutrition scales a food's nutrients to an arbitrary amount and unit, e.g. "37 g" or "1.5 cup".
+// The unit "serving" multiplies the selected (or first) predefined serving instead.
+func (uc *FoodUseCase) GetFoodNutrition(ctx context.Context, request entity.FoodNutritionRequest) (*entity.FoodNutritionResponse, error) {
+	if math.IsNaN(request.Amount) || math.IsInf(request.Amount, 0) || request.Amount <= 0 {
+		return nil, fmt.Errorf("%w: amount must be a positive number", entity.ErrInvalidInput)
+	}
+
+	details, err := uc.ResolveFoodDetails(ctx, request.UserID, request.FoodID)
+	if err != nil || details == nil {
+		return nil, err
+	}
+	if len(details.Servings) == 0 {
+		return nil, fmt.Errorf("%w: food %s has no servings", entity.ErrInvalidInput, request.FoodID)
+	}
+
+	response := &entity.FoodNutritionResponse{
+		FoodID:    details.ID,
+		Name:      details.Name,
+		BrandName: details.BrandName,
+		Amount:    request.Amount,
+	}
+
+	if unit := strings.ToLower(strings.TrimSpace(request.Unit)); unit == "serving" || unit == "servings" {
+		serving := details.Servings[0]
+		if request.ServingID != "" {
+			if serving, err = findServingByID(details.Servings, request.ServingID); err != nil {
+				return nil, err
+			}
+		}
+		response.Unit = "serving"
+		response.BaseServingID = serving.ID
+		response.Nutrition = units.MultiplyServing(serving, request.Amount)
+		response.Nutrition.Description = fmt.Sprintf("%s x %s", strconv.FormatFloat(request.Amount, 'f', -1, 64), serving.Description)
+		return response, nil
+	}
+
+	unit, err := units.Parse(request.Unit)
+	if err != nil {
+		return nil, err
+	}
+
+	density := units.Density(details.Servings)
+	serving, err := units.FindServing(details.Servings, request.ServingID, unit, density)
+	if err != nil {
+		return nil, err
+	}
+
+	scaled, err := units.ScaleServing(serving, request.Amount, unit, density)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Unit = unit.Name
+	response.BaseServingID = serving.ID
+	response.Density = density
+	response.Nutrition = scaled
+	return response, nil
+}
+
+// findServingByID returns the serving with the given ID
+func findServingByID(servings []entity.Serving, servingID string) (entity.Serving, error) {
+	for _, serving := range servings {
+		if serving.ID == servingID {
+			return serving, nil
+		}
+	}
+	return entity.Serving{}, fmt.Errorf("%w: serving %s not found", entity.ErrInvalidInput, servingID)
+}
@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"CalorieCompass/internal/entity"
+)
+
+// GlucoseRepository defines the interface for blood glucose reading storage
+type GlucoseRepository interface {
+	Create(ctx context.Context, userID int64, reading entity.GlucoseReading) (int64, error)
+	Delete(ctx context.Context, userID, id int64) error
+	Get(ctx context.Context, userID, id int64) (*entity.GlucoseReading, error)
+	List(ctx context.Context, userID int64, from, to time.Time) ([]entity.GlucoseReading, error)
+}
+
+// GlycemicAnalyzer computes the net carbs and glycemic load of diary entries
+type GlycemicAnalyzer interface {
+	Analyze(ctx context.Context, entries []entity.DiaryEntry) ([]entity.GlycemicEntry, error)
+}
+
+const (
+	// DefaultGlucoseWindow is the default post-meal window in minutes
+	DefaultGlucoseWindow = 120
+	// glucoseMinMgDl and glucoseMaxMgDl bound the readings a meter can plausibly show
+	glucoseMinMgDl = 20
+	glucoseMaxMgDl = 600
+	// glucoseMealGap is the pause between two diary entries that starts a new meal
+	glucoseMealGap = 30 * time.Minute
+	// glucoseBaselineWindow is how long before a meal a reading still counts as its baseline
+	glucoseBaselineWindow = time.Hour
+	// glucoseMinCorrelationMeals is the number of meals needed for a correlation
+	glucoseMinCorrelationMeals = 3
+)
+
+// GlucoseUseCase handles business logic for blood glucose readings
+type GlucoseUseCase struct {
+	repo     GlucoseRepository
+	diary    DiaryRepository
+	glycemic GlycemicAnalyzer
+}
+
+// NewGlucoseUseCase creates a new blood glucose use case
+func NewGlucoseUseCase(repo GlucoseRepository, diary DiaryRepository, glycemic GlycemicAnalyzer) *GlucoseUseCase {
+	return &GlucoseUseCase{
+		repo:     repo,
+		diary:    diary,
+		glycemic: glycemic,
+	}
+}
+
+// Log records a blood glucose reading, converting mmol/L to mg/dL
+func (uc *GlucoseUseCase) Log(ctx context.Context, userID int64, input entity.GlucoseReadingInput) (*entity.GlucoseReading, error) {
+	mgDl := input.Value
+	if input.Unit == entity.GlucoseMmolL {
+		mgDl = input.Value * entity.MgDlPerMmolL
+	}
+	mgDl = round1(mgDl)
+	if mgDl < glucoseMinMgDl || mgDl > glucoseMaxMgDl {
+		return nil, fmt.Errorf("%w: glucose must be between %d and %d mg/dL (%.1f and %.1f mmol/L)",
+			entity.ErrInvalidInput, glucoseMinMgDl, glucoseMaxMgDl, glucoseMinMgDl/entity.MgDlPerMmolL,
+			glucoseMaxMgDl/entity.MgDlPerMmolL)
+	}
+
+	now := time.Now()
+	measuredAt := now
+	if input.MeasuredAt != nil {
+		measuredAt = *input.MeasuredAt
+	}
+	if measuredAt.After(now) {
+		return nil, fmt.Errorf("%w: a reading cannot be taken in the future", entity.ErrInvalidInput)
+	}
+	readingContext := input.Context
+	if readingContext == "" {
+		readingContext = entity.GlucoseRandom
+	}
+
+	id, err := uc.repo.Create(ctx, userID, entity.GlucoseReading{
+		MgDl:       mgDl,
+		Context:    readingContext,
+		MeasuredAt: measuredAt,
+		Note:       input.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.repo.Get(ctx, userID, id)
+}
+
+// Delete removes a reading
+func (uc *GlucoseUseCase) Delete(ctx context.Context, userID, id int64) error {
+	return uc.repo.Delete(ctx, userID, id)
+}
+
+// List returns the readings taken between two dates (inclusive)
+func (uc *GlucoseUseCase) List(ctx context.Context, userID int64, from, to time.Time) ([]entity.GlucoseReading, error) {
+	return uc.repo.List(ctx, userID, from, to.AddDate(0, 0, 1))
+}
+
+// Meals relates the meals eaten between two dates (inclusive) to the readings taken in the
+// window minutes after each. A meal's window ends early when the next meal starts, so that
+// every reading belongs to at most one meal.
+func (uc *GlucoseUseCase) Meals(ctx context.Context, userID int64, from, to time.Time, window int) (*entity.GlucoseMealReport, error) {
+	if window <= 0 {
+		window = DefaultGlucoseWindow
+	}
+	end := to.AddDate(0, 0, 1)
+	windowDuration := time.Duration(window) * time.Minute
+
+	entries, err := uc.diary.ListEatenBetween(ctx, userID, from, end)
+	if err != nil {
+		return nil, err
+	}
+	analyzed, err := uc.glycemic.Analyze(ctx, entries)
+	if err != nil {
+		return nil, err
+	}
+	readings, err := uc.repo.List(ctx, userID, from.Add(-glucoseBaselineWindow), end.Add(windowDuration))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entity.GlucoseMealReport{
+		From:          from.Format(entity.DateLayout),
+		To:            to.Format(entity.DateLayout),
+		WindowMinutes: window,
+		Meals:         []entity.MealGlucose{},
+	}
+
+	meals := groupMeals(analyzed)
+	for i, meal := range meals {
+		start := meal[0].EatenAt
+		windowEnd := start.Add(windowDuration)
+		if i+1 < len(meals) && meals[i+1][0].EatenAt.Before(windowEnd) {
+			windowEnd = meals[i+1][0].EatenAt
+		}
+
+		result := entity.MealGlucose{
+			Meal:     meal[0].Meal,
+			EatenAt:  start,
+			Foods:    make([]string, 0, len(meal)),
+			Totals:   glycemicTotals(meal, false),
+			Readings: []entity.GlucoseReading{},
+		}
+		for _, entry := range meal {
+			result.Foods = append(result.Foods, entry.FoodName)
+		}
+		applyReadings(&result, readings, start, windowEnd)
+		report.Meals = append(report.Meals, result)
+	}
+
+	report.Correlation = loadRiseCorrelation(report.Meals)
+	return report, nil
+}
+
+// groupMeals splits entries, ordered by when they were eaten, into runs eaten less than
+// glucoseMealGap apart
+func groupMeals(entries []entity.GlycemicEntry) [][]entity.GlycemicEntry {
+	var meals [][]entity.GlycemicEntry
+	for i, entry := range entries {
+		if i == 0 || entry.EatenAt.Sub(entries[i-1].EatenAt) >= glucoseMealGap {
+			meals = append(meals, nil)
+		}
+		meals[len(meals)-1] = append(meals[len(meals)-1], entry)
+	}
+	return meals
+}
+
+// applyReadings sets a meal's baseline from the last reading in the hour up to its start,
+// and its peak and rise from the readings after it up to windowEnd
+func applyReadings(meal *entity.MealGlucose, readings []entity.GlucoseReading, start, windowEnd time.Time) {
+	for _, reading := range readings {
+		switch {
+		case !reading.MeasuredAt.After(start):
+			if !reading.MeasuredAt.Before(start.Add(-glucoseBaselineWindow)) {
+				baseline := reading.MgDl
+				meal.Baseline = &baseline
+			}
+		case !reading.MeasuredAt.After(windowEnd):
+			meal.Readings = append(meal.Readings, reading)
+			if meal.Peak == nil || reading.MgDl > *meal.Peak {
+				peak := reading.MgDl
+				minutes := int(reading.MeasuredAt.Sub(start).Minutes())
+				meal.Peak = &peak
+				meal.MinutesToPeak = &minutes
+			}
+		}
+	}
+
+	if meal.Baseline != nil && meal.Peak != nil {
+		rise := round1(*meal.Peak - *meal.Baseline)
+		meal.Rise = &rise
+	}
+}
+
+// loadRiseCorrelation is the Pearson correlation between the glycemic load of meals and
+// their glucose rise, over the meals with a rise and a known glycemic index. It is nil with
+// fewer than glucoseMinCorrelationMeals such meals or when either value never varies.
+func loadRiseCorrelation(meals []entity.MealGlucose) *float64 {
+	var loads, rises []float64
+	for _, meal := range meals {
+		if meal.Rise != nil && meal.Totals.AverageIndex != nil {
+			loads = append(loads, meal.Totals.GlycemicLoad)
+			rises = append(rises, *meal.Rise)
+		}
+	}
+	if len(loads) < glucoseMinCorrelationMeals {
+		return nil
+	}
+
+	n := float64(len(loads))
+	var sumX, sumY float64
+	for i := range loads {
+		sumX += loads[i]
+		sumY += rises[i]
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var cov, varX, varY float64
+	for i := range loads {
+		dx, dy := loads[i]-meanX, rises[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return nil
+	}
+
+	correlation := round2(cov / math.Sqrt(varX*varY))
+	return &correlation
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/glycemic"
+	"CalorieCompass/internal/pkg/nutrition"
+)
+
+// GlycemicCatalog looks up the dataset categories of catalog foods
+type GlycemicCatalog interface {
+	Categories(ctx context.Context, ids []int64) (map[int64]string, error)
+}
+
+// GlycemicUseCase rates the carbohydrate quality of diary entries
+type GlycemicUseCase struct {
+	diary   DiaryRepository
+	catalog GlycemicCatalog
+}
+
+// NewGlycemicUseCase creates a new glycemic use case
+func NewGlycemicUseCase(diary DiaryRepository, catalog GlycemicCatalog) *GlycemicUseCase {
+	return &GlycemicUseCase{
+		diary:   diary,
+		catalog: catalog,
+	}
+}
+
+// Day returns the net carbs and glycemic load of a day's entries, per entry, per meal slot
+// and for the whole day
+func (uc *GlycemicUseCase) Day(ctx context.Context, userID int64, date time.Time) (*entity.GlycemicDay, error) {
+	entries, err := uc.diary.ListByDate(ctx, userID, date)
+	if err != nil {
+		return nil, err
+	}
+	analyzed, err := uc.Analyze(ctx, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	day := &entity.GlycemicDay{
+		Date:    date.Format(entity.DateLayout),
+		Totals:  glycemicTotals(analyzed, true),
+		Meals:   make([]entity.GlycemicMeal, 0, len(entity.Meals)),
+		Entries: analyzed,
+	}
+	for _, meal := range entity.Meals {
+		var group []entity.GlycemicEntry
+		for _, entry := range analyzed {
+			if entry.Meal == meal {
+				group = append(group, entry)
+			}
+		}
+		day.Meals = append(day.Meals, entity.GlycemicMeal{Meal: meal, Totals: glycemicTotals(group, false)})
+	}
+	return day, nil
+}
+
+// Analyze finds the glycemic index of each entry's food, by its name or, for catalog foods,
+// its dataset category, and computes the entry's net carbs and glycemic load
+func (uc *GlycemicUseCase) Analyze(ctx context.Context, entries []entity.DiaryEntry) ([]entity.GlycemicEntry, error) {
+	catalogIDs := make([]int64, len(entries))
+	var ids []int64
+	for i, entry := range entries {
+		switch source, id := ParseFoodID(entry.FoodID); source {
+		case entity.FoodSourceCatalog, entity.CatalogSourceUSDA, entity.CatalogSourceOpenFoodFacts:
+			catalogIDs[i], _ = strconv.ParseInt(id, 10, 64)
+		}
+		if catalogIDs[i] > 0 {
+			ids = append(ids, catalogIDs[i])
+		}
+	}
+	categories := map[int64]string{}
+	if len(ids) > 0 && uc.catalog != nil {
+		var err error
+		if categories, err = uc.catalog.Categories(ctx, ids); err != nil {
+			return nil, err
+		}
+	}
+
+	analyzed := make([]entity.GlycemicEntry, 0, len(entries))
+	for i, entry := range entries {
+		n := entry.Nutrition
+		result := entity.GlycemicEntry{
+			EntryID:  entry.ID,
+			Meal:     entry.Meal,
+			FoodID:   entry.FoodID,
+			FoodName: entry.FoodName,
+			EatenAt:  entry.EatenAt,
+			Carbs:    round1(n.Carbs),
+			Fiber:    round1(n.Fiber),
+			NetCarbs: round1(nutrition.NetCarbs(n.Carbs, n.Fiber)),
+		}
+		if match, ok := glycemic.Lookup(entry.FoodName, categories[catalogIDs[i]]); ok {
+			load := round1(glycemic.Load(match.Index, nutrition.NetCarbs(n.Carbs, n.Fiber)))
+			result.GlycemicIndex = &entity.GlycemicIndexMatch{
+				Index:     match.Index,
+				Class:     glycemic.IndexClass(match.Index),
+				Reference: match.Reference,
+				Source:    match.Source,
+			}
+			result.GlycemicLoad = &load
+			result.LoadClass = glycemic.LoadClass(load)
+		}
+		analyzed = append(analyzed, result)
+	}
+	return analyzed, nil
+}
+
+// glycemicTotals sums analyzed entries; daily selects the per-day load thresholds instead
+// of the per-serving ones
+func glycemicTotals(entries []entity.GlycemicEntry, daily bool) entity.GlycemicTotals {
+	var totals entity.GlycemicTotals
+	var covered, weightedIndex float64
+	for _, entry := range entries {
+		totals.Carbs += entry.Carbs
+		totals.Fiber += entry.Fiber
+		totals.NetCarbs += entry.NetCarbs
+		if entry.GlycemicIndex == nil {
+			continue
+		}
+		totals.GlycemicLoad += *entry.GlycemicLoad
+		covered += entry.NetCarbs
+		weightedIndex += float64(entry.GlycemicIndex.Index) * entry.NetCarbs
+	}
+
+	if covered > 0 {
+		average := round1(weightedIndex / covered)
+		totals.AverageIndex = &average
+	}
+	if totals.NetCarbs > 0 {
+		totals.Coverage = round1(covered / totals.NetCarbs * 100)
+	}
+	totals.Carbs = round1(totals.Carbs)
+	totals.Fiber = round1(totals.Fiber)
+	totals.NetCarbs = round1(totals.NetCarbs)
+	totals.GlycemicLoad = round1(totals.GlycemicLoad)
+	if daily {
+		totals.LoadClass = glycemic.DailyLoadClass(totals.GlycemicLoad)
+	} else {
+		totals.LoadClass = glycemic.LoadClass(totals.GlycemicLoad)
+	}
+	return totals
+}
@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/nutrition"
+)
+
+// GoalRepository defines the interface for nutrition goal storage
+type GoalRepository interface {
+	Save(ctx context.Context, userID int64, goal entity.NutritionGoal) (int64, error)
+	Delete(ctx context.Context, userID, id int64) error
+	GetByID(ctx context.Context, userID, id int64) (*entity.NutritionGoal, error)
+	List(ctx context.Context, userID int64) ([]entity.NutritionGoal, error)
+	ListBetween(ctx context.Context, userID int64, from, to time.Time) ([]entity.NutritionGoal, error)
+}
+
+// GoalResolver looks up the targets active on a date for features that evaluate intake
+type GoalResolver interface {
+	TargetFor(ctx context.Context, userID int64, date time.Time) (*entity.NutritionTarget, error)
+}
+
+// macroSplitTolerance is how far custom percentages may sum from 100
+const macroSplitTolerance = 1.0
+
+// macroPresets are the built-in calorie splits
+var macroPresets = []entity.MacroPreset{
+	{Code: entity.MacroPresetBalanced, Name: "Balanced", CarbsPct: 50, ProteinPct: 20, FatPct: 30,
+		Description: "Within the acceptable macronutrient distribution ranges for adults"},
+	{Code: entity.MacroPresetHighProtein, Name: "High protein", CarbsPct: 40, ProteinPct: 30, FatPct: 30,
+		Description: "More protein for satiety and muscle retention"},
+	{Code: entity.MacroPresetLowCarb, Name: "Low carb", CarbsPct: 25, ProteinPct: 30, FatPct: 45,
+		Description: "Fewer carbs, replaced mostly by fat"},
+	{Code: entity.MacroPresetKeto, Name: "Keto", CarbsPct: 5, ProteinPct: 20, FatPct: 75, NetCarbs: true,
+		Description: "Ketogenic split counting net carbs (carbs minus fiber)"},
+	{Code: entity.MacroPresetCustom, Name: "Custom",
+		Description: "Own split by percent of calories or in grams"},
+}
+
+// GoalUseCase handles business logic for calorie and macronutrient goals
+type GoalUseCase struct {
+	repo GoalRepository
+}
+
+// NewGoalUseCase creates a new goal use case
+func NewGoalUseCase(repo GoalRepository) *GoalUseCase {
+	return &GoalUseCase{
+		repo: repo,
+	}
+}
+
+// Presets returns the built-in macronutrient splits
+func (uc *GoalUseCase) Presets() []entity.MacroPreset {
+	return macroPresets
+}
+
+// Create sets the targets from a date on. Each weekday gets the default target unless a
+// schedule overrides it; a weekday may be in one schedule only.
+func (uc *GoalUseCase) Create(ctx context.Context, userID int64, input entity.NutritionGoalInput) (*entity.NutritionGoal, error) {
+	effectiveFrom, err := ParseDate(input.EffectiveFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := resolveTarget(input.Target)
+	if err != nil {
+		return nil, err
+	}
+	target.Label = input.Label
+
+	days := make([]entity.GoalDay, len(entity.Weekdays))
+	for i, weekday := range entity.Weekdays {
+		days[i] = entity.GoalDay{Weekday: weekday, Target: target}
+	}
+
+	scheduled := make(map[string]bool)
+	for _, schedule := range input.Schedules {
+		target, err := resolveTarget(schedule.Target)
+		if err != nil {
+			return nil, err
+		}
+		target.Label = schedule.Label
+
+		for _, weekday := range schedule.Weekdays {
+			if scheduled[weekday] {
+				return nil, fmt.Errorf("%w: %s is in more than one schedule", entity.ErrInvalidInput, weekday)
+			}
+			scheduled[weekday] = true
+			days[weekdayIndex(weekday)].Target = target
+		}
+	}
+
+	id, err := uc.repo.Save(ctx, userID, entity.NutritionGoal{
+		EffectiveFrom: effectiveFrom.Format(entity.DateLayout),
+		Days:          days,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	goal, err := uc.repo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if goal == nil {
+		return nil, fmt.Errorf("nutrition goal %d: %w", id, entity.ErrNotFound)
+	}
+	withPercents(goal)
+	return goal, nil
+}
+
+// List returns the user's goal history, latest first
+func (uc *GoalUseCase) List(ctx context.Context, userID int64) ([]entity.NutritionGoal, error) {
+	goals, err := uc.repo.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range goals {
+		withPercents(&goals[i])
+	}
+	return goals, nil
+}
+
+// Delete removes a goal
+func (uc *GoalUseCase) Delete(ctx context.Context, userID, id int64) error {
+	return uc.repo.Delete(ctx, userID, id)
+}
+
+// TargetFor returns the targets active on a date, nil when no goal had started by then
+func (uc *GoalUseCase) TargetFor(ctx context.Context, userID int64, date time.Time) (*entity.NutritionTarget, error) {
+	targets, err := uc.Targets(ctx, userID, date, date)
+	if err != nil {
+		return nil, err
+	}
+	target, ok := targets[date.Format(entity.DateLayout)]
+	if !ok {
+		return nil, nil
+	}
+	return &target, nil
+}
+
+// Targets returns the targets active on each date between two dates (inclusive), keyed by
+// date. Dates before the first goal are missing.
+func (uc *GoalUseCase) Targets(ctx context.Context, userID int64, from, to time.Time) (map[string]entity.NutritionTarget, error) {
+	goals, err := uc.repo.ListBetween(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make(map[string]entity.NutritionTarget)
+	next := 0
+	var active *entity.NutritionGoal
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		key := date.Format(entity.DateLayout)
+		for next < len(goals) && goals[next].EffectiveFrom <= key {
+			active = &goals[next]
+			withPercents(active)
+			next++
+		}
+		if active == nil || len(active.Days) != len(entity.Weekdays) {
+			continue
+		}
+		targets[key] = active.Days[date.Weekday()].Target
+	}
+	return targets, nil
+}
+
+// dailyTargets compares a day's intake with its targets. Remaining amounts are negative
+// once a target is exceeded.
+func dailyTargets(target entity.NutritionTarget, totals entity.Serving, netCalories float64) *entity.DailyTargets {
+	carbs := totals.Carbs
+	if target.NetCarbs {
+		carbs = nutrition.NetCarbs(totals.Carbs, totals.Fiber)
+	}
+	return &entity.DailyTargets{
+		Target:   target,
+		Calories: targetProgress(target.Calories, netCalories),
+		Carbs:    targetProgress(target.Carbs, carbs),
+		Protein:  targetProgress(target.Protein, totals.Protein),
+		Fat:      targetProgress(target.Fat, totals.Fat),
+	}
+}
+
+func targetProgress(target, consumed float64) entity.TargetProgress {
+	progress := entity.TargetProgress{
+		Target:    target,
+		Consumed:  round1(consumed),
+		Remaining: round1(target - consumed),
+	}
+	if target > 0 {
+		progress.Progress = round1(consumed / target * 100)
+	}
+	return progress
+}
+
+// resolveTarget turns a preset or custom split into gram targets
+func resolveTarget(input entity.MacroTargetInput) (entity.NutritionTarget, error) {
+	preset, ok := findMacroPreset(input.Preset)
+	if !ok {
+		return entity.NutritionTarget{}, fmt.Errorf("%w: unknown preset %q", entity.ErrInvalidInput, input.Preset)
+	}
+
+	target := entity.NutritionTarget{
+		Preset:   preset.Code,
+		Calories: input.Calories,
+		NetCarbs: preset.NetCarbs,
+	}
+	if input.NetCarbs != nil {
+		target.NetCarbs = *input.NetCarbs
+	}
+
+	carbsPct, proteinPct, fatPct := preset.CarbsPct, preset.ProteinPct, preset.FatPct
+	if preset.Code == entity.MacroPresetCustom && input.Split == entity.MacroSplitGrams {
+		if input.Carbs+input.Protein+input.Fat <= 0 {
+			return entity.NutritionTarget{}, fmt.Errorf("%w: a gram split needs carbs, protein or fat", entity.ErrInvalidInput)
+		}
+		target.Carbs, target.Protein, target.Fat = input.Carbs, input.Protein, input.Fat
+		if target.Calories == 0 {
+			target.Calories = math.Round(nutrition.MacroCalories(input.Carbs, input.Protein, input.Fat))
+		}
+		return target, nil
+	}
+
+	if preset.Code == entity.MacroPresetCustom {
+		carbsPct, proteinPct, fatPct = input.Carbs, input.Protein, input.Fat
+		if math.Abs(carbsPct+proteinPct+fatPct-100) > macroSplitTolerance {
+			return entity.NutritionTarget{}, fmt.Errorf("%w: carbs, protein and fat percentages must add up to 100", entity.ErrInvalidInput)
+		}
+	}
+	if target.Calories == 0 {
+		return entity.NutritionTarget{}, fmt.Errorf("%w: calories are required", entity.ErrInvalidInput)
+	}
+
+	target.Carbs = round1(nutrition.MacroGrams(target.Calories, carbsPct, nutrition.KcalPerGramCarbs))
+	target.Protein = round1(nutrition.MacroGrams(target.Calories, proteinPct, nutrition.KcalPerGramProtein))
+	target.Fat = round1(nutrition.MacroGrams(target.Calories, fatPct, nutrition.KcalPerGramFat))
+	return target, nil
+}
+
+// withPercents fills in each weekday's macronutrient shares of calories
+func withPercents(goal *entity.NutritionGoal) {
+	for i := range goal.Days {
+		t := &goal.Days[i].Target
+		t.CarbsPct = round1(nutrition.MacroPercent(t.Calories, t.Carbs, nutrition.KcalPerGramCarbs))
+		t.ProteinPct = round1(nutrition.MacroPercent(t.Calories, t.Protein, nutrition.KcalPerGramProtein))
+		t.FatPct = round1(nutrition.MacroPercent(t.Calories, t.Fat, nutrition.KcalPerGramFat))
+	}
+}
+
+// findMacroPreset looks up a built-in split by code
+func findMacroPreset(code string) (entity.MacroPreset, bool) {
+	for _, preset := range macroPresets {
+		if preset.Code == code {
+			return preset, true
+		}
+	}
+	return entity.MacroPreset{}, false
+}
+
+// weekdayIndex returns the time.Weekday of a weekday name, -1 if it is unknown
+func weekdayIndex(name string) int {
+	for i, weekday := range entity.Weekdays {
+		if weekday == name {
+			return i
+		}
+	}
+	return -1
+}
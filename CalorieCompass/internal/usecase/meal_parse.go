@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/ingredient"
+	"CalorieCompass/internal/pkg/units"
+)
+
+// FoodLookup searches foods and loads their details
+type FoodLookup interface {
+	FoodSearcher
+	ResolveFoodDetailsBatch(ctx context.Context, userID int64, foodIDs []string) entity.FoodBatchResponse
+}
+
+const (
+	// parseCandidates is the number of candidates returned for each phrase
+	parseCandidates = 3
+	// parseMaxPhrases bounds the number of foods in a single description
+	parseMaxPhrases = 20
+)
+
+// MealParseUseCase turns free-text meal descriptions into suggested diary entries
+type MealParseUseCase struct {
+	foods FoodLookup
+}
+
+// NewMealParseUseCase creates a new meal parse use case
+func NewMealParseUseCase(foods FoodLookup) *MealParseUseCase {
+	return &MealParseUseCase{
+		foods: foods,
+	}
+}
+
+// Parse splits a meal description into food phrases, searches each one and picks a serving
+// of every candidate that fits the phrase's quantity. Nothing is logged: the best candidate
+// of each phrase is returned as a diary entry for the client to confirm.
+func (uc *MealParseUseCase) Parse(ctx context.Context, userID int64, request entity.MealParseRequest) (*entity.MealParseResponse, error) {
+	if request.Date != "" {
+		if _, err := ParseDate(request.Date); err != nil {
+			return nil, err
+		}
+	}
+
+	phrases := ingredient.SplitPhrases(request.Text)
+	if len(phrases) == 0 {
+		return nil, fmt.Errorf("%w: no foods found in text", entity.ErrInvalidInput)
+	}
+	if len(phrases) > parseMaxPhrases {
+		return nil, fmt.Errorf("%w: at most %d foods can be parsed at once", entity.ErrInvalidInput, parseMaxPhrases)
+	}
+
+	lines := make([]ingredient.Line, len(phrases))
+	matches := make([][]scoredFood, len(phrases))
+	sem := make(chan struct{}, ingredientWorkers)
+	var wg sync.WaitGroup
+	for i, phrase := range phrases {
+		lines[i] = ingredient.Parse(phrase)
+		if lines[i].Food == "" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			matches[i] = uc.search(ctx, userID, lines[i].Food)
+		}(i)
+	}
+	wg.Wait()
+
+	// Load the details of every candidate in one batch to choose servings
+	var foodIDs []string
+	for _, candidates := range matches {
+		for _, candidate := range candidates {
+			foodIDs = append(foodIDs, candidate.food.ID)
+		}
+	}
+	details := make(map[string]*entity.FoodDetails, len(foodIDs))
+	if len(foodIDs) > 0 {
+		for _, item := range uc.foods.ResolveFoodDetailsBatch(ctx, userID, foodIDs).Items {
+			if item.Food != nil {
+				details[item.FoodID] = item.Food
+			}
+		}
+	}
+
+	response := &entity.MealParseResponse{Items: make([]entity.MealParseItem, len(phrases))}
+	for i, line := range lines {
+		item := entity.MealParseItem{
+			Phrase:     line.Raw,
+			Quantity:   line.Quantity,
+			Unit:       line.Unit,
+			Size:       line.Size,
+			Food:       line.Food,
+			Candidates: make([]entity.MealParseCandidate, 0, len(matches[i])),
+		}
+
+		for _, match := range matches[i] {
+			food := details[match.food.ID]
+			if food == nil {
+				continue
+			}
+			candidate, ok := pickServing(line, food.Servings)
+			if !ok {
+				continue
+			}
+			candidate.Food = match.food
+			candidate.Confidence = math.Round(match.score*candidate.Confidence*100) / 100
+			item.Candidates = append(item.Candidates, candidate)
+		}
+		sort.SliceStable(item.Candidates, func(a, b int) bool {
+			return item.Candidates[a].Confidence > item.Candidates[b].Confidence
+		})
+
+		if len(item.Candidates) == 0 {
+			response.Unresolved++
+		} else {
+			best := item.Candidates[0]
+			item.Entry = &entity.DiaryEntryInput{
+				Date:      request.Date,
+				Meal:      request.Meal,
+				FoodID:    best.Food.ID,
+				ServingID: best.Serving.ID,
+				Amount:    best.Amount,
+				Unit:      best.Unit,
+			}
+		}
+		response.Items[i] = item
+	}
+
+	return response, nil
+}
+
+// search returns the best-matching foods for a phrase; search failures leave it unresolved
+func (uc *MealParseUseCase) search(ctx context.Context, userID int64, query string) []scoredFood {
+	response, err := uc.foods.SearchFoods(ctx, entity.FoodSearchRequest{
+		UserID: userID,
+		Query:  query,
+		Limit:  importCandidates,
+	})
+	if err != nil || len(response.Foods) == 0 {
+		return nil
+	}
+
+	candidates := rankCandidates(query, response.Foods)
+	for i, candidate := range candidates {
+		if i == parseCandidates || candidate.score < importMinConfidence {
+			return candidates[:i]
+		}
+	}
+	return candidates
+}
+
+// pickServing chooses the serving that fits a parsed line and scales it. Mass and volume
+// quantities are converted through the food's metric servings; counts ("2 eggs", "a slice")
+// use the serving whose description names the count unit or size, e.g. "1 large" or
+// "1 slice". The returned candidate's Confidence is how well the serving fits (0-1).
+func pickServing(line ingredient.Line, servings []entity.Serving) (entity.MealParseCandidate, bool) {
+	if len(servings) == 0 {
+		return entity.MealParseCandidate{}, false
+	}
+
+	quantity := line.Quantity
+	fit := 1.0
+	if quantity <= 0 {
+		// "coffee with milk": assume one serving
+		quantity = 1
+		fit = 0.8
+	}
+
+	if line.Measured() {
+		unit, _ := units.Parse(line.Unit)
+		density := units.Density(servings)
+		serving, err := units.FindServing(servings, "", unit, density)
+		if err != nil {
+			return entity.MealParseCandidate{}, false
+		}
+		scaled, err := units.ScaleServing(serving, quantity, unit, density)
+		if err != nil {
+			return entity.MealParseCandidate{}, false
+		}
+		return entity.MealParseCandidate{
+			Serving:    serving,
+			Amount:     quantity,
+			Unit:       unit.Name,
+			Nutrition:  scaled,
+			Confidence: fit,
+		}, true
+	}
+
+	best, bestScore := 0, math.Inf(-1)
+	for i, serving := range servings {
+		description := strings.ToLower(serving.Description + " " + serving.MeasurementDescription)
+		score := 0.0
+		if line.Unit != "" && strings.Contains(description, line.Unit) {
+			score += 2
+		}
+		if line.Size != "" && strings.Contains(description, line.Size) {
+			score++
+		}
+		if units.IsMeasured(serving) {
+			score--
+		}
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	serving := servings[best]
+
+	// A serving that names the count unit or size fits well; a generic serving is a guess
+	switch {
+	case bestScore > 0 || (bestScore == 0 && line.Unit == "" && line.Size == ""):
+		fit *= 0.95
+	default:
+		fit *= 0.7
+	}
+
+	// "2 slices" of a "2 slices" serving is one serving
+	amount := quantity
+	if !units.IsMeasured(serving) && serving.NumberOfUnits > 0 {
+		amount = quantity / serving.NumberOfUnits
+	}
+
+	scaled := units.MultiplyServing(serving, amount)
+	scaled.Description = fmt.Sprintf("%s x %s", strconv.FormatFloat(amount, 'f', -1, 64), serving.Description)
+	return entity.MealParseCandidate{
+		Serving:    serving,
+		Amount:     amount,
+		Unit:       "serving",
+		Nutrition:  scaled,
+		Confidence: fit,
+	}, true
+}
@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/dri"
+	"CalorieCompass/internal/pkg/nutrition"
+	"CalorieCompass/internal/pkg/units"
+)
+
+// TopFoodRanker ranks logged foods by their contribution to each nutrient
+type TopFoodRanker interface {
+	TopFoods(ctx context.Context, userID int64, from, to time.Time, limit int) ([]entity.NutrientTopFoods, error)
+}
+
+const (
+	// NutrientWindowDays is the length of the rolling window intake is averaged over
+	NutrientWindowDays = 7
+	// nutrientTopFoods is the number of contributing foods listed per flagged nutrient
+	nutrientTopFoods = 5
+)
+
+// NutrientUseCase compares intake with the Dietary Reference Intakes
+type NutrientUseCase struct {
+	profiles ProfileRepository
+	intake   IntakeRepository
+	foods    TopFoodRanker
+}
+
+// NewNutrientUseCase creates a new nutrient use case
+func NewNutrientUseCase(profiles ProfileRepository, intake IntakeRepository, foods TopFoodRanker) *NutrientUseCase {
+	return &NutrientUseCase{
+		profiles: profiles,
+		intake:   intake,
+		foods:    foods,
+	}
+}
+
+// References returns the reference values for a sex and age. Without them, the values
+// come from the user's profile on date.
+func (uc *NutrientUseCase) References(ctx context.Context, userID int64, sex string, age *int, date time.Time) (*entity.NutrientReferences, error) {
+	if sex == "" || age == nil {
+		profileSex, profileAge, err := uc.profileAge(ctx, userID, date)
+		if err != nil {
+			return nil, err
+		}
+		if sex == "" {
+			sex = profileSex
+		}
+		if age == nil {
+			age = &profileAge
+		}
+	}
+
+	group, err := driGroup(*age)
+	if err != nil {
+		return nil, err
+	}
+
+	male := sex == entity.SexMale
+	result := &entity.NutrientReferences{
+		Sex:        sex,
+		Age:        *age,
+		Group:      group.Name,
+		References: make([]entity.NutrientReference, 0, len(group.Male)),
+		Ranges:     make([]entity.MacroRange, 0, len(group.Ranges)),
+	}
+	for _, ref := range group.References(male) {
+		reference := entity.NutrientReference{
+			Nutrient: ref.Nutrient,
+			Unit:     ref.Unit,
+			Type:     ref.Type,
+			Amount:   ref.Amount,
+		}
+		if limit := ref.UpperLimit; limit > 0 {
+			reference.UpperLimit = &limit
+		}
+		result.References = append(result.References, reference)
+	}
+	for _, r := range group.Ranges {
+		result.Ranges = append(result.Ranges, entity.MacroRange{Nutrient: r.Nutrient, Min: r.Min, Max: r.Max})
+	}
+	return result, nil
+}
+
+// Adequacy averages the intake over the logged days of the NutrientWindowDays ending on
+// date and compares it with the references for the user's age and sex: nutrients below
+// their RDA or AI are low, those above their upper limit over the limit, and each
+// macronutrient's share of energy is placed against its acceptable range
+func (uc *NutrientUseCase) Adequacy(ctx context.Context, userID int64, date time.Time) (*entity.NutrientAnalysis, error) {
+	references, err := uc.References(ctx, userID, "", nil, date)
+	if err != nil {
+		return nil, err
+	}
+
+	from := date.AddDate(0, 0, 1-NutrientWindowDays)
+	days, err := uc.intake.DailyIntake(ctx, userID, from, date)
+	if err != nil {
+		return nil, err
+	}
+	if len(days) == 0 {
+		return nil, fmt.Errorf("diary entries between %s and %s: %w",
+			from.Format(entity.DateLayout), date.Format(entity.DateLayout), entity.ErrNotFound)
+	}
+
+	totals := make([]entity.Serving, 0, len(days))
+	for _, day := range days {
+		totals = append(totals, day.Nutrition)
+	}
+	average := units.MultiplyServing(nutrition.Sum(totals...), 1/float64(len(days)))
+
+	topFoods, err := uc.foods.TopFoods(ctx, userID, from, date, nutrientTopFoods)
+	if err != nil {
+		return nil, err
+	}
+	contributors := make(map[string][]entity.TopFood, len(topFoods))
+	for _, nutrient := range topFoods {
+		contributors[nutrient.Nutrient] = nutrient.Foods
+	}
+
+	analysis := &entity.NutrientAnalysis{
+		From:       from.Format(entity.DateLayout),
+		To:         date.Format(entity.DateLayout),
+		DaysLogged: len(days),
+		Sex:        references.Sex,
+		Age:        references.Age,
+		Group:      references.Group,
+		Nutrients:  make([]entity.NutrientAdequacy, 0, len(references.References)),
+		Ranges:     make([]entity.MacroRangeCheck, 0, len(references.Ranges)),
+	}
+
+	for _, ref := range references.References {
+		intake := servingNutrient(average, ref.Nutrient)
+		adequacy := entity.NutrientAdequacy{
+			Nutrient:   ref.Nutrient,
+			Unit:       ref.Unit,
+			Type:       ref.Type,
+			Intake:     round1(intake),
+			Reference:  ref.Amount,
+			UpperLimit: ref.UpperLimit,
+			Percent:    round1(intake / ref.Amount * 100),
+			Status:     entity.AdequacyAdequate,
+			TopFoods:   []entity.TopFood{},
+		}
+		switch {
+		case ref.UpperLimit != nil && intake > *ref.UpperLimit:
+			adequacy.Status = entity.AdequacyOverLimit
+			adequacy.Excess = round1(intake - *ref.UpperLimit)
+		case intake < ref.Amount:
+			adequacy.Status = entity.AdequacyLow
+			adequacy.Gap = round1(ref.Amount - intake)
+		}
+		if adequacy.Status != entity.AdequacyAdequate && contributors[ref.Nutrient] != nil {
+			adequacy.TopFoods = contributors[ref.Nutrient]
+		}
+		analysis.Nutrients = append(analysis.Nutrients, adequacy)
+	}
+
+	energy := nutrition.MacroCalories(average.Carbs, average.Protein, average.Fat)
+	for _, r := range references.Ranges {
+		percent := 0.0
+		if energy > 0 {
+			percent = servingNutrient(average, r.Nutrient) * macroKcalPerGram(r.Nutrient) / energy * 100
+		}
+		check := entity.MacroRangeCheck{
+			Nutrient: r.Nutrient,
+			Min:      r.Min,
+			Max:      r.Max,
+			Percent:  round1(percent),
+			Status:   entity.RangeWithin,
+		}
+		switch {
+		case percent < r.Min:
+			check.Status = entity.RangeBelow
+		case percent > r.Max:
+			check.Status = entity.RangeAbove
+		}
+		analysis.Ranges = append(analysis.Ranges, check)
+	}
+
+	return analysis, nil
+}
+
+// profileAge returns the sex and age on date from the user's profile
+func (uc *NutrientUseCase) profileAge(ctx context.Context, userID int64, date time.Time) (string, int, error) {
+	profile, err := uc.profiles.Get(ctx, userID)
+	if err != nil {
+		return "", 0, err
+	}
+	if profile == nil || profile.Sex == "" || profile.BirthDate == "" {
+		return "", 0, fmt.Errorf("%w: set sex and birth date in the body profile", entity.ErrInvalidInput)
+	}
+
+	birthDate, err := ParseDate(profile.BirthDate)
+	if err != nil {
+		return "", 0, err
+	}
+	return profile.Sex, dri.Age(birthDate, date), nil
+}
+
+// driGroup returns the life-stage group for an age
+func driGroup(age int) (dri.Group, error) {
+	group, ok := dri.Lookup(age)
+	if !ok {
+		return dri.Group{}, fmt.Errorf("%w: reference intakes start at age %d", entity.ErrInvalidInput, dri.MinAge)
+	}
+	return group, nil
+}
+
+// servingNutrient returns the amount of a nutrient in a serving
+func servingNutrient(serving entity.Serving, nutrient string) float64 {
+	switch nutrient {
+	case dri.Protein:
+		return serving.Protein
+	case dri.Carbs:
+		return serving.Carbs
+	case dri.Fat:
+		return serving.Fat
+	case dri.Fiber:
+		return serving.Fiber
+	case dri.Sodium:
+		return serving.Sodium
+	case dri.Calcium:
+		return serving.Calcium
+	case dri.Iron:
+		return serving.Iron
+	case dri.Potassium:
+		return serving.Potassium
+	case dri.VitaminA:
+		return serving.VitaminA
+	case dri.VitaminC:
+		return serving.VitaminC
+	case dri.VitaminD:
+		return serving.VitaminD
+	}
+	return 0
+}
+
+// macroKcalPerGram returns the energy factor of a macronutrient
+func macroKcalPerGram(nutrient string) float64 {
+	if nutrient == dri.Fat {
+		return nutrition.KcalPerGramFat
+	}
+	if nutrient == dri.Protein {
+		return nutrition.KcalPerGramProtein
+	}
+	return nutrition.KcalPerGramCarbs
+}
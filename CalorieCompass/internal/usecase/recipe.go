@@ -0,0 +1,384 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/nutrition"
+	"CalorieCompass/internal/pkg/restriction"
+	"CalorieCompass/internal/pkg/units"
+)
+
+// RecipeRepository defines the interface for user recipe storage
+type RecipeRepository interface {
+	Create(ctx context.Context, userID int64, recipe entity.Recipe) (int64, error)
+	Update(ctx context.Context, userID, id int64, recipe entity.Recipe) error
+	Delete(ctx context.Context, userID, id int64) error
+	GetByID(ctx context.Context, userID, id int64) (*entity.Recipe, error)
+	List(ctx context.Context, userID int64) ([]entity.Recipe, error)
+	FindByIngredient(ctx context.Context, userID int64, foodID string) ([]int64, error)
+	FindStale(ctx context.Context, userID int64) ([]int64, error)
+	MarkChecked(ctx context.Context, userID, id int64) error
+}
+
+// ingredientWorkers bounds concurrent food lookups while computing a recipe or a saved meal
+const ingredientWorkers = 5
+
+// RecipeUseCase handles business logic for user-defined recipes
+type RecipeUseCase struct {
+	repo         RecipeRepository
+	foods        FoodResolver
+	restrictions RestrictionRepository
+}
+
+// NewRecipeUseCase creates a new recipe use case
+func NewRecipeUseCase(repo RecipeRepository, foods FoodResolver, restrictions RestrictionRepository) *RecipeUseCase {
+	return &RecipeUseCase{
+		repo:         repo,
+		foods:        foods,
+		restrictions: restrictions,
+	}
+}
+
+// Create computes a recipe's nutrition from its ingredients and stores it
+func (uc *RecipeUseCase) Create(ctx context.Context, userID int64, input entity.RecipeInput) (*entity.Recipe, error) {
+	recipe, err := uc.compute(ctx, userID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uc.repo.Create(ctx, userID, recipe)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.Get(ctx, userID, id)
+}
+
+// Update replaces a recipe and recomputes its nutrition
+func (uc *RecipeUseCase) Update(ctx context.Context, userID, id int64, input entity.RecipeInput) (*entity.Recipe, error) {
+	recipe, err := uc.compute(ctx, userID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, userID, id, recipe); err != nil {
+		return nil, err
+	}
+
+	return uc.Get(ctx, userID, id)
+}
+
+// Delete removes a recipe. Diary entries that logged it keep their nutrient snapshot.
+func (uc *RecipeUseCase) Delete(ctx context.Context, userID, id int64) error {
+	return uc.repo.Delete(ctx, userID, id)
+}
+
+// Get returns a single recipe, flagged against the user's restriction profile
+func (uc *RecipeUseCase) Get(ctx context.Context, userID, id int64) (*entity.Recipe, error) {
+	uc.refreshStale(ctx, userID)
+	return uc.get(ctx, userID, id)
+}
+
+// get returns a single recipe as stored, flagged against the user's restriction profile
+func (uc *RecipeUseCase) get(ctx context.Context, userID, id int64) (*entity.Recipe, error) {
+	recipe, err := uc.repo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if recipe == nil {
+		return nil, fmt.Errorf("recipe %d: %w", id, entity.ErrNotFound)
+	}
+	profile, err := restrictionProfile(ctx, uc.restrictions, userID)
+	if err != nil {
+		return nil, err
+	}
+	recipe.PerServing = perServing(*recipe)
+	recipe.Flags = restriction.Check(profile, recipe.Name, recipeIngredientText(*recipe), nil)
+	return recipe, nil
+}
+
+// List returns all of a user's recipes, flagged against their restriction profile
+func (uc *RecipeUseCase) List(ctx context.Context, userID int64) ([]entity.Recipe, error) {
+	uc.refreshStale(ctx, userID)
+	recipes, err := uc.repo.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := restrictionProfile(ctx, uc.restrictions, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range recipes {
+		recipes[i].PerServing = perServing(recipes[i])
+		recipes[i].Flags = restriction.Check(profile, recipes[i].Name, recipeIngredientText(recipes[i]), nil)
+	}
+	return recipes, nil
+}
+
+// Recompute re-resolves every ingredient, picking up changes to the underlying foods.
+// Ingredients whose serving no longer exists, e.g. one removed from a custom food, are
+// resolved by their amount and unit instead.
+func (uc *RecipeUseCase) Recompute(ctx context.Context, userID, id int64) (*entity.Recipe, error) {
+	stored, err := uc.get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	input := recipeInput(*stored)
+	recipe, err := uc.compute(ctx, userID, input)
+	if err != nil && uc.dropMissingServings(ctx, userID, &input) {
+		recipe, err = uc.compute(ctx, userID, input)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Update(ctx, userID, id, recipe); err != nil {
+		return nil, err
+	}
+
+	return uc.get(ctx, userID, id)
+}
+
+// RecomputeForFood recomputes every recipe of the user that uses the given food, e.g. after
+// a custom food was edited. Recipes that fail to recompute keep their previous values.
+func (uc *RecipeUseCase) RecomputeForFood(ctx context.Context, userID int64, foodID string) error {
+	ids, err := uc.repo.FindByIngredient(ctx, userID, foodID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		uc.recomputeOrSkip(ctx, userID, id, foodID+" changed")
+	}
+	return nil
+}
+
+// refreshStale recomputes the user's recipes whose catalog or custom ingredients changed since
+// they were computed, e.g. by a catalog import
+func (uc *RecipeUseCase) refreshStale(ctx context.Context, userID int64) {
+	ids, err := uc.repo.FindStale(ctx, userID)
+	if err != nil {
+		log.Printf("find stale recipes of user %d: %s", userID, err)
+		return
+	}
+
+	for _, id := range ids {
+		uc.recomputeOrSkip(ctx, userID, id, "ingredients changed")
+	}
+}
+
+// recomputeOrSkip recomputes a recipe. A failure is logged and leaves the old values, and
+// the recipe is marked as checked so that reads do not retry it until an ingredient
+// changes again.
+func (uc *RecipeUseCase) recomputeOrSkip(ctx context.Context, userID, id int64, reason string) {
+	if _, err := uc.Recompute(ctx, userID, id); err != nil {
+		log.Printf("recompute recipe %d after %s: %s", id, reason, err)
+		if err := uc.repo.MarkChecked(ctx, userID, id); err != nil {
+			log.Printf("mark recipe %d checked: %s", id, err)
+		}
+	}
+}
+
+// dropMissingServings clears the serving of each ingredient whose food no longer has it, so
+// that it is resolved by its amount and unit; it reports whether any was cleared
+func (uc *RecipeUseCase) dropMissingServings(ctx context.Context, userID int64, input *entity.RecipeInput) bool {
+	var foodIDs []string
+	for _, ingredient := range input.Ingredients {
+		if ingredient.ServingID != "" {
+			foodIDs = append(foodIDs, ingredient.FoodID)
+		}
+	}
+	if len(foodIDs) == 0 {
+		return false
+	}
+
+	servings := make(map[string][]entity.Serving, len(foodIDs))
+	for _, item := range uc.foods.ResolveFoodDetailsBatch(ctx, userID, foodIDs).Items {
+		if item.Food != nil {
+			servings[item.FoodID] = item.Food.Servings
+		}
+	}
+
+	dropped := false
+	for i, ingredient := range input.Ingredients {
+		found, ok := servings[ingredient.FoodID]
+		if ingredient.ServingID == "" || !ok {
+			continue
+		}
+		if _, err := findServingByID(found, ingredient.ServingID); err != nil {
+			input.Ingredients[i].ServingID = ""
+			dropped = true
+		}
+	}
+	return dropped
+}
+
+// compute validates a recipe and resolves each ingredient's nutrition through the food use
+// case, which also converts units. The total weight is the yield when given, otherwise the
+// sum of the ingredient weights when every ingredient has a known mass.
+func (uc *RecipeUseCase) compute(ctx context.Context, userID int64, input entity.RecipeInput) (entity.Recipe, error) {
+	if strings.TrimSpace(input.Name) == "" {
+		return entity.Recipe{}, fmt.Errorf("%w: name is required", entity.ErrInvalidInput)
+	}
+	if input.Servings <= 0 {
+		return entity.Recipe{}, fmt.Errorf("%w: servings must be positive", entity.ErrInvalidInput)
+	}
+	if len(input.Ingredients) == 0 {
+		return entity.Recipe{}, fmt.Errorf("%w: at least one ingredient is required", entity.ErrInvalidInput)
+	}
+	for i, ingredient := range input.Ingredients {
+		if source, _ := ParseFoodID(ingredient.FoodID); source == entity.FoodSourceRecipe {
+			return entity.Recipe{}, fmt.Errorf("%w: ingredient %d: recipes cannot be nested", entity.ErrInvalidInput, i)
+		}
+	}
+
+	recipe := entity.Recipe{
+		Name:         strings.TrimSpace(input.Name),
+		Servings:     input.Servings,
+		Instructions: input.Instructions,
+		Ingredients:  make([]entity.RecipeIngredient, len(input.Ingredients)),
+		ComputedAt:   time.Now(),
+	}
+
+	var yield units.Unit
+	if input.YieldAmount > 0 {
+		var err error
+		if yield, err = units.Parse(input.YieldUnit); err != nil {
+			return entity.Recipe{}, fmt.Errorf("%w: yield_unit: %s", entity.ErrInvalidInput, err)
+		}
+		recipe.YieldAmount = input.YieldAmount
+		recipe.YieldUnit = yield.Name
+	}
+
+	requests := make([]entity.FoodNutritionRequest, 0, len(input.Ingredients))
+	for _, ingredient := range input.Ingredients {
+		requests = append(requests, entity.FoodNutritionRequest{
+			UserID:    userID,
+			FoodID:    ingredient.FoodID,
+			Amount:    ingredient.Amount,
+			Unit:      ingredient.Unit,
+			ServingID: ingredient.ServingID,
+		})
+	}
+	resolved, err := resolveAll(ctx, uc.foods, "ingredient", requests)
+	if err != nil {
+		return entity.Recipe{}, err
+	}
+	for i, scaled := range resolved {
+		recipe.Ingredients[i] = entity.RecipeIngredient{
+			FoodID:    scaled.FoodID,
+			FoodName:  scaled.Name,
+			BrandName: scaled.BrandName,
+			ServingID: scaled.BaseServingID,
+			Amount:    scaled.Amount,
+			Unit:      scaled.Unit,
+			Nutrition: scaled.Nutrition,
+		}
+	}
+
+	servings := make([]entity.Serving, 0, len(recipe.Ingredients))
+	for _, ingredient := range recipe.Ingredients {
+		servings = append(servings, ingredient.Nutrition)
+	}
+	recipe.Total = nutrition.Sum(servings...)
+	recipe.Total.Description = "whole recipe"
+
+	if input.YieldAmount > 0 {
+		base := units.Gram
+		if yield.Kind == units.Volume {
+			base = units.Milliliter
+		}
+		amount, err := units.Convert(input.YieldAmount, yield, base, 0)
+		if err != nil {
+			return entity.Recipe{}, fmt.Errorf("%w: yield_unit: %s", entity.ErrInvalidInput, err)
+		}
+		recipe.Total.MetricServingAmount = amount
+		recipe.Total.MetricServingUnit = base.Name
+	} else if grams, ok := ingredientGrams(recipe.Ingredients); ok {
+		recipe.Total.MetricServingAmount = grams
+		recipe.Total.MetricServingUnit = units.Gram.Name
+	}
+
+	recipe.PerServing = perServing(recipe)
+	return recipe, nil
+}
+
+// ingredientGrams sums the ingredient weights; it reports false if any ingredient has no mass
+func ingredientGrams(ingredients []entity.RecipeIngredient) (float64, bool) {
+	total := 0.0
+	for _, ingredient := range ingredients {
+		unit, err := units.Parse(ingredient.Nutrition.MetricServingUnit)
+		if err != nil || unit.Kind != units.Mass {
+			return 0, false
+		}
+		grams, err := units.Convert(ingredient.Nutrition.MetricServingAmount, unit, units.Gram, 0)
+		if err != nil {
+			return 0, false
+		}
+		total += grams
+	}
+	return total, true
+}
+
+// perServing divides a recipe's total nutrition by its number of servings
+func perServing(recipe entity.Recipe) entity.Serving {
+	serving := units.MultiplyServing(recipe.Total, 1/recipe.Servings)
+	serving.ID = "serving"
+	serving.Description = "1 serving"
+	serving.MeasurementDescription = "serving"
+	serving.NumberOfUnits = 1
+	return serving
+}
+
+// recipeInput turns a stored recipe back into the input it was computed from
+func recipeInput(recipe entity.Recipe) entity.RecipeInput {
+	input := entity.RecipeInput{
+		Name:         recipe.Name,
+		Servings:     recipe.Servings,
+		YieldAmount:  recipe.YieldAmount,
+		YieldUnit:    recipe.YieldUnit,
+		Instructions: recipe.Instructions,
+		Ingredients:  make([]entity.RecipeIngredientInput, 0, len(recipe.Ingredients)),
+	}
+	for _, ingredient := range recipe.Ingredients {
+		input.Ingredients = append(input.Ingredients, entity.RecipeIngredientInput{
+			FoodID:    ingredient.FoodID,
+			ServingID: ingredient.ServingID,
+			Amount:    ingredient.Amount,
+			Unit:      ingredient.Unit,
+		})
+	}
+	return input
+}
+
+// recipeFoodDetails presents a recipe as a food so it can be logged to the diary as a single
+// item. It always has a per-serving entry and, when the total weight is known, that serving
+// carries its metric amount so that gram or millilitre amounts can be logged too.
+func recipeFoodDetails(recipe entity.Recipe) entity.FoodDetails {
+	return entity.FoodDetails{
+		ID:          recipe.ID,
+		Name:        recipe.Name,
+		Source:      entity.FoodSourceRecipe,
+		Servings:    []entity.Serving{perServing(recipe)},
+		Ingredients: recipeIngredientText(recipe),
+	}
+}
+
+// recipeIngredientText lists a recipe's ingredient names (with brands) as an ingredient list
+func recipeIngredientText(recipe entity.Recipe) string {
+	names := make([]string, 0, len(recipe.Ingredients))
+	for _, ingredient := range recipe.Ingredients {
+		name := ingredient.FoodName
+		if ingredient.BrandName != "" {
+			name += " (" + ingredient.BrandName + ")"
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/ingredient"
+	"CalorieCompass/internal/pkg/schemaorg"
+)
+
+// FoodSearcher searches foods across all sources
+type FoodSearcher interface {
+	SearchFoods(ctx context.Context, request entity.FoodSearchRequest) (entity.FoodSearchResponse, error)
+}
+
+const (
+	// importCandidates is the number of search results scored for each ingredient line
+	importCandidates = 10
+	// importAlternatives is the number of runner-up matches returned with each line
+	importAlternatives = 3
+	// importMinConfidence is the reported confidence below which a line is left unmatched
+	importMinConfidence = 0.35
+	// importMaxLines bounds the work done for a single import
+	importMaxLines = 100
+)
+
+// RecipeImportUseCase turns pasted recipe content into a draft recipe
+type RecipeImportUseCase struct {
+	foods FoodSearcher
+}
+
+// NewRecipeImportUseCase creates a new recipe import use case
+func NewRecipeImportUseCase(foods FoodSearcher) *RecipeImportUseCase {
+	return &RecipeImportUseCase{
+		foods: foods,
+	}
+}
+
+// Import parses schema.org Recipe JSON-LD, HTML containing it, or a plain-text ingredient list
+// and matches every ingredient line to a food. Nothing is stored: the returned draft is meant
+// to be reviewed and then submitted as a regular recipe.
+func (uc *RecipeImportUseCase) Import(ctx context.Context, userID int64, request entity.RecipeImportRequest) (*entity.RecipeImportDraft, error) {
+	format := request.Format
+	if format == "" || format == entity.RecipeImportAuto {
+		format = detectImportFormat(request.Content)
+	}
+
+	var source schemaorg.Recipe
+	switch format {
+	case entity.RecipeImportJSONLD, entity.RecipeImportHTML:
+		recipe, err := schemaorg.ExtractRecipe(request.Content)
+		if errors.Is(err, schemaorg.ErrNoRecipe) {
+			return nil, fmt.Errorf("%w: %s", entity.ErrInvalidInput, err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("extract recipe error: %w", err)
+		}
+		source = *recipe
+	default:
+		source.Ingredients = ingredientLines(request.Content)
+	}
+
+	if len(source.Ingredients) == 0 {
+		return nil, fmt.Errorf("%w: no ingredient lines found", entity.ErrInvalidInput)
+	}
+	if len(source.Ingredients) > importMaxLines {
+		return nil, fmt.Errorf("%w: at most %d ingredient lines can be imported", entity.ErrInvalidInput, importMaxLines)
+	}
+
+	draft := &entity.RecipeImportDraft{
+		Format: format,
+		Recipe: entity.RecipeInput{
+			Name:         firstNonEmpty(request.Name, source.Name, "Imported recipe"),
+			Servings:     request.Servings,
+			Instructions: strings.Join(source.Instructions, "\n"),
+		},
+		Ingredients: make([]entity.ImportedIngredient, len(source.Ingredients)),
+	}
+	if draft.Recipe.Servings <= 0 {
+		draft.Recipe.Servings = yieldServings(source.Yield)
+	}
+
+	sem := make(chan struct{}, ingredientWorkers)
+	var wg sync.WaitGroup
+	for i, raw := range source.Ingredients {
+		wg.Add(1)
+		go func(i int, raw string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			draft.Ingredients[i] = uc.matchLine(ctx, userID, ingredient.Parse(raw))
+		}(i, raw)
+	}
+	wg.Wait()
+
+	for _, imported := range draft.Ingredients {
+		if imported.Ingredient == nil {
+			draft.Unmatched++
+			continue
+		}
+		draft.Recipe.Ingredients = append(draft.Recipe.Ingredients, *imported.Ingredient)
+	}
+
+	return draft, nil
+}
+
+// matchLine searches for the line's food and scores each result by name similarity. Search
+// failures leave the line unmatched rather than failing the whole import.
+func (uc *RecipeImportUseCase) matchLine(ctx context.Context, userID int64, line ingredient.Line) entity.ImportedIngredient {
+	imported := entity.ImportedIngredient{
+		Line:     line.Raw,
+		Quantity: line.Quantity,
+		Unit:     line.Unit,
+		Food:     line.Food,
+		Note:     line.Note,
+	}
+	if line.Food == "" {
+		return imported
+	}
+
+	response, err := uc.foods.SearchFoods(ctx, entity.FoodSearchRequest{
+		UserID: userID,
+		Query:  line.Food,
+		Limit:  importCandidates,
+	})
+	if err != nil || len(response.Foods) == 0 {
+		return imported
+	}
+
+	candidates := rankCandidates(line.Food, response.Foods)
+	best := candidates[0]
+	for _, candidate := range candidates[1:] {
+		if len(imported.Alternatives) == importAlternatives || candidate.score < importMinConfidence {
+			break
+		}
+		imported.Alternatives = append(imported.Alternatives, candidate.food)
+	}
+
+	confidence := best.score
+	amount, unit := line.Quantity, line.Unit
+	if !line.Measured() {
+		// Counts ("2 eggs", "1 can") are logged as servings of the matched food, which
+		// may not be the same size
+		unit = "serving"
+		confidence *= 0.85
+	}
+	if amount <= 0 {
+		amount = 1
+		confidence *= 0.8
+	}
+	imported.Confidence = math.Round(confidence*100) / 100
+
+	// Lines are matched on the reported confidence, so a vague line needs a closer name match
+	if imported.Confidence < importMinConfidence {
+		return imported
+	}
+
+	match := best.food
+	imported.Match = &match
+	imported.Ingredient = &entity.RecipeIngredientInput{
+		FoodID: match.ID,
+		Amount: amount,
+		Unit:   unit,
+	}
+	return imported
+}
+
+type scoredFood struct {
+	food  entity.Food
+	score float64
+}
+
+// rankCandidates scores search results against the query. Generic foods get a small edge
+// over branded ones since recipes rarely name brands, and ties keep the search order.
+func rankCandidates(query string, foods []entity.Food) []scoredFood {
+	candidates := make([]scoredFood, len(foods))
+	for i, food := range foods {
+		score := ingredient.Similarity(query, food.Name)
+		if food.BrandName != "" {
+			score *= 0.9
+		}
+		// Later search results are slightly less likely to be what the user meant
+		score -= float64(i) * 0.005
+		candidates[i] = scoredFood{food: food, score: math.Max(score, 0)}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+	return candidates
+}
+
+// detectImportFormat guesses the format of pasted content
+func detectImportFormat(content string) string {
+	trimmed := strings.TrimSpace(content)
+	switch {
+	case strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "["):
+		return entity.RecipeImportJSONLD
+	case strings.Contains(strings.ToLower(trimmed), "application/ld+json"):
+		return entity.RecipeImportHTML
+	default:
+		return entity.RecipeImportText
+	}
+}
+
+// ingredientLines splits a plain-text ingredient list into lines, skipping blank lines and
+// headings such as "Ingredients" or "For the sauce:"
+func ingredientLines(content string) []string {
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasSuffix(line, ":") || strings.EqualFold(line, "ingredients") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// yieldServings reads the number of servings from a recipeYield such as "4 servings" or
+// "Serves 6-8", defaulting to 1
+func yieldServings(yield string) float64 {
+	tokens := ingredient.Tokenize(yield)
+	for i := range tokens {
+		if quantity, _ := ingredient.ParseQuantity(tokens[i:]); quantity > 0 {
+			return quantity
+		}
+	}
+	return 1
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,291 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/nutrition"
+	"CalorieCompass/internal/pkg/units"
+)
+
+// ReportRepository runs the aggregations behind nutrition reports
+type ReportRepository interface {
+	TopFoods(ctx context.Context, userID int64, from, to time.Time, limit int) ([]entity.NutrientTopFoods, error)
+	DailyBurned(ctx context.Context, userID int64, from, to time.Time) (map[string]float64, error)
+}
+
+// GoalSchedule provides the targets active on each date of a range
+type GoalSchedule interface {
+	Targets(ctx context.Context, userID int64, from, to time.Time) (map[string]entity.NutritionTarget, error)
+}
+
+const (
+	// DefaultReportTopFoods is the default number of top foods listed per nutrient
+	DefaultReportTopFoods = 5
+	// ReportMaxDays is the longest range a report may cover
+	ReportMaxDays = 366
+	// reportTolerance is how far, as a fraction of the target, an intake may be from its
+	// target and still count as adherent
+	reportTolerance = 0.10
+	// reportCompleteMeals is the number of meal slots a day needs to count as completely logged
+	reportCompleteMeals = 3
+	// reportRankedDays is the number of best and worst days listed
+	reportRankedDays = 3
+)
+
+// ReportUseCase builds nutrition reports over date ranges
+type ReportUseCase struct {
+	repo      ReportRepository
+	intake    IntakeRepository
+	exercises ExerciseLog
+	goals     GoalSchedule
+}
+
+// NewReportUseCase creates a new report use case
+func NewReportUseCase(repo ReportRepository, intake IntakeRepository, exercises ExerciseLog, goals GoalSchedule) *ReportUseCase {
+	return &ReportUseCase{
+		repo:      repo,
+		intake:    intake,
+		exercises: exercises,
+		goals:     goals,
+	}
+}
+
+// reportDay is a logged day with its net calories and the target active on it
+type reportDay struct {
+	intake entity.DailyIntake
+	net    float64
+	target *entity.NutritionTarget
+}
+
+// Report reviews the diary between two dates (inclusive), split into periods of the given
+// granularity. Per-day and per-food sums are aggregated in the database; the report only
+// combines one row per logged day.
+func (uc *ReportUseCase) Report(ctx context.Context, userID int64, from, to time.Time, granularity string, top int) (*entity.NutritionReport, error) {
+	if days := int(to.Sub(from).Hours()/24) + 1; days > ReportMaxDays {
+		return nil, fmt.Errorf("%w: a report can cover at most %d days", entity.ErrInvalidInput, ReportMaxDays)
+	}
+	if top <= 0 {
+		top = DefaultReportTopFoods
+	}
+
+	intake, err := uc.intake.DailyIntake(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	burned, err := uc.repo.DailyBurned(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := uc.exercises.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := uc.goals.Targets(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	topFoods, err := uc.repo.TopFoods(ctx, userID, from, to, top)
+	if err != nil {
+		return nil, err
+	}
+
+	days := make([]reportDay, 0, len(intake))
+	for _, day := range intake {
+		logged := reportDay{intake: day, net: day.Nutrition.Calories}
+		if settings.AddBurnedCalories {
+			logged.net -= burned[day.Date]
+		}
+		if target, ok := targets[day.Date]; ok {
+			logged.target = &target
+		}
+		days = append(days, logged)
+	}
+
+	report := &entity.NutritionReport{
+		From:         from.Format(entity.DateLayout),
+		To:           to.Format(entity.DateLayout),
+		Granularity:  granularity,
+		TopFoods:     topFoods,
+		Completeness: completeness(from, to, days),
+	}
+	report.Average, report.Distribution, report.Adherence = summarizeDays(days)
+	report.BestDays, report.WorstDays = rankDays(days)
+
+	report.Periods = make([]entity.ReportPeriod, 0)
+	next := 0
+	for _, bounds := range reportPeriods(from, to, granularity) {
+		start, end := bounds[0].Format(entity.DateLayout), bounds[1].Format(entity.DateLayout)
+		first := next
+		for next < len(days) && days[next].intake.Date <= end {
+			next++
+		}
+
+		period := entity.ReportPeriod{
+			Start:      start,
+			End:        end,
+			Days:       int(bounds[1].Sub(bounds[0]).Hours()/24) + 1,
+			DaysLogged: next - first,
+		}
+		period.Average, period.Distribution, period.Adherence = summarizeDays(days[first:next])
+		report.Periods = append(report.Periods, period)
+	}
+
+	return report, nil
+}
+
+// summarizeDays averages logged days and measures adherence on those with a target
+func summarizeDays(days []reportDay) (entity.Serving, entity.MacroDistribution, *entity.ReportAdherence) {
+	if len(days) == 0 {
+		return entity.Serving{Description: "daily average"}, entity.MacroDistribution{}, nil
+	}
+
+	totals := make([]entity.Serving, 0, len(days))
+	adherence := &entity.ReportAdherence{}
+	var calories, carbs, protein, fat int
+	for _, day := range days {
+		n := day.intake.Nutrition
+		totals = append(totals, n)
+		if day.target == nil {
+			continue
+		}
+
+		t := day.target
+		adherence.Days++
+		dayCarbs := n.Carbs
+		if t.NetCarbs {
+			dayCarbs = nutrition.NetCarbs(n.Carbs, n.Fiber)
+		}
+		calories += onTarget(day.net, t.Calories)
+		carbs += onTarget(dayCarbs, t.Carbs)
+		protein += onTarget(n.Protein, t.Protein)
+		fat += onTarget(n.Fat, t.Fat)
+	}
+
+	average := roundServing(units.MultiplyServing(nutrition.Sum(totals...), 1/float64(len(days))))
+	average.Description = "daily average"
+
+	distribution := entity.MacroDistribution{}
+	if energy := nutrition.MacroCalories(average.Carbs, average.Protein, average.Fat); energy > 0 {
+		distribution.CarbsPct = round1(average.Carbs * nutrition.KcalPerGramCarbs / energy * 100)
+		distribution.ProteinPct = round1(average.Protein * nutrition.KcalPerGramProtein / energy * 100)
+		distribution.FatPct = round1(average.Fat * nutrition.KcalPerGramFat / energy * 100)
+	}
+
+	if adherence.Days == 0 {
+		return average, distribution, nil
+	}
+	share := func(count int) float64 {
+		return round1(float64(count) / float64(adherence.Days) * 100)
+	}
+	adherence.Calories = share(calories)
+	adherence.Carbs = share(carbs)
+	adherence.Protein = share(protein)
+	adherence.Fat = share(fat)
+	return average, distribution, adherence
+}
+
+// rankDays orders the logged days with a calorie target by how far their net calories were
+// from it and returns the closest and the furthest, each without the other's days
+func rankDays(days []reportDay) ([]entity.ReportDay, []entity.ReportDay) {
+	ranked := make([]entity.ReportDay, 0, len(days))
+	for _, day := range days {
+		if day.target == nil || day.target.Calories <= 0 {
+			continue
+		}
+		ranked = append(ranked, entity.ReportDay{
+			Date:      day.intake.Date,
+			Calories:  math.Round(day.net),
+			Target:    day.target.Calories,
+			Deviation: round1((day.net - day.target.Calories) / day.target.Calories * 100),
+		})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return math.Abs(ranked[i].Deviation) < math.Abs(ranked[j].Deviation)
+	})
+
+	best := ranked[:min(reportRankedDays, len(ranked)/2)]
+	worst := make([]entity.ReportDay, 0, len(best))
+	for i := len(ranked) - 1; i >= len(ranked)-len(best); i-- {
+		worst = append(worst, ranked[i])
+	}
+	return append([]entity.ReportDay{}, best...), worst
+}
+
+// completeness counts the logged and completely logged days of a range
+func completeness(from, to time.Time, days []reportDay) entity.LoggingCompleteness {
+	result := entity.LoggingCompleteness{
+		Days:       int(to.Sub(from).Hours()/24) + 1,
+		DaysLogged: len(days),
+	}
+	meals := 0
+	for _, day := range days {
+		meals += day.intake.Meals
+		if day.intake.Meals >= reportCompleteMeals {
+			result.CompleteDays++
+		}
+	}
+	result.LoggedPct = round1(float64(result.DaysLogged) / float64(result.Days) * 100)
+	result.CompletePct = round1(float64(result.CompleteDays) / float64(result.Days) * 100)
+	if len(days) > 0 {
+		result.AverageMeals = round1(float64(meals) / float64(len(days)))
+	}
+	return result
+}
+
+// reportPeriods splits a range into days, Monday-to-Sunday weeks or calendar months, with
+// the first and last period clipped to the range
+func reportPeriods(from, to time.Time, granularity string) [][2]time.Time {
+	var periods [][2]time.Time
+	for start := from; !start.After(to); {
+		var next time.Time
+		switch granularity {
+		case entity.GranularityWeek:
+			next = start.AddDate(0, 0, 7-(int(start.Weekday())+6)%7)
+		case entity.GranularityMonth:
+			next = time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, start.Location())
+		default:
+			next = start.AddDate(0, 0, 1)
+		}
+
+		end := next.AddDate(0, 0, -1)
+		if end.After(to) {
+			end = to
+		}
+		periods = append(periods, [2]time.Time{start, end})
+		start = next
+	}
+	return periods
+}
+
+// onTarget returns 1 when value is within reportTolerance of a positive target, else 0
+func onTarget(value, target float64) int {
+	if target > 0 && math.Abs(value-target) <= target*reportTolerance {
+		return 1
+	}
+	return 0
+}
+
+// roundServing rounds a serving's nutrients to one decimal
+func roundServing(serving entity.Serving) entity.Serving {
+	serving.Calories = round1(serving.Calories)
+	serving.Carbs = round1(serving.Carbs)
+	serving.Protein = round1(serving.Protein)
+	serving.Fat = round1(serving.Fat)
+	serving.SaturatedFat = round1(serving.SaturatedFat)
+	serving.Fiber = round1(serving.Fiber)
+	serving.Cholesterol = round1(serving.Cholesterol)
+	serving.Sodium = round1(serving.Sodium)
+	serving.Sugar = round1(serving.Sugar)
+	serving.Calcium = round1(serving.Calcium)
+	serving.Iron = round1(serving.Iron)
+	serving.Potassium = round1(serving.Potassium)
+	serving.VitaminA = round1(serving.VitaminA)
+	serving.VitaminC = round1(serving.VitaminC)
+	serving.VitaminD = round1(serving.VitaminD)
+	return serving
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/restriction"
+)
+
+// RestrictionRepository defines the interface for restriction profile storage
+type RestrictionRepository interface {
+	Get(ctx context.Context, userID int64) (*entity.RestrictionProfile, error)
+	Save(ctx context.Context, userID int64, profile entity.RestrictionProfile) error
+	Delete(ctx context.Context, userID int64) error
+}
+
+// RestrictionUseCase handles business logic for allergen and diet restriction profiles
+type RestrictionUseCase struct {
+	repo RestrictionRepository
+}
+
+// NewRestrictionUseCase creates a new restriction use case
+func NewRestrictionUseCase(repo RestrictionRepository) *RestrictionUseCase {
+	return &RestrictionUseCase{repo: repo}
+}
+
+// Options lists the allergen and diet codes a profile accepts
+func (uc *RestrictionUseCase) Options() entity.RestrictionOptions {
+	return entity.RestrictionOptions{Allergens: entity.Allergens, Diets: entity.Diets}
+}
+
+// Get returns the user's restriction profile
+func (uc *RestrictionUseCase) Get(ctx context.Context, userID int64) (*entity.RestrictionProfile, error) {
+	return uc.repo.Get(ctx, userID)
+}
+
+// Update replaces the user's restriction profile. Codes are case-insensitive, duplicates are
+// dropped and the lists are kept in the order of entity.Allergens and entity.Diets.
+func (uc *RestrictionUseCase) Update(ctx context.Context, userID int64, input entity.RestrictionProfileInput) (*entity.RestrictionProfile, error) {
+	allergens, err := restrictionCodes(input.Allergens, entity.Allergens, "allergen")
+	if err != nil {
+		return nil, err
+	}
+	diets, err := restrictionCodes(input.Diets, entity.Diets, "diet")
+	if err != nil {
+		return nil, err
+	}
+
+	profile := entity.RestrictionProfile{Allergens: allergens, Diets: diets, Strict: input.Strict}
+	if err := uc.repo.Save(ctx, userID, profile); err != nil {
+		return nil, err
+	}
+	return uc.repo.Get(ctx, userID)
+}
+
+// Delete clears the user's restriction profile
+func (uc *RestrictionUseCase) Delete(ctx context.Context, userID int64) error {
+	return uc.repo.Delete(ctx, userID)
+}
+
+// restrictionCodes validates codes against the known ones and returns them in their order
+func restrictionCodes(values, known []string, kind string) ([]string, error) {
+	selected := make(map[string]bool, len(values))
+	for _, value := range values {
+		code := strings.ToLower(strings.TrimSpace(value))
+		if !containsString(known, code) {
+			return nil, fmt.Errorf("%w: unknown %s %q, expected one of %s", entity.ErrInvalidInput, kind, value,
+				strings.Join(known, ", "))
+		}
+		selected[code] = true
+	}
+
+	codes := make([]string, 0, len(selected))
+	for _, code := range known {
+		if selected[code] {
+			codes = append(codes, code)
+		}
+	}
+	return codes, nil
+}
+
+// restrictionProfile loads the profile foods are flagged against; anonymous users and
+// users without a profile have an inactive one
+func restrictionProfile(ctx context.Context, repo RestrictionRepository, userID int64) (entity.RestrictionProfile, error) {
+	if userID == 0 || repo == nil {
+		return entity.RestrictionProfile{}, nil
+	}
+	profile, err := repo.Get(ctx, userID)
+	if err != nil || profile == nil {
+		return entity.RestrictionProfile{}, err
+	}
+	return *profile, nil
+}
+
+// flagDetails flags a food from its declared attributes, ingredients and name
+func flagDetails(profile entity.RestrictionProfile, details *entity.FoodDetails) {
+	details.Flags = restriction.Check(profile, details.Name, details.Ingredients, details.Attributes)
+}
+
+// restrictionExcludes reports whether flags keep a food out of suggestions and alternatives:
+// allergens always do, diet conflicts only under a strict profile
+func restrictionExcludes(profile entity.RestrictionProfile, flags []entity.FoodFlag) bool {
+	for _, flag := range flags {
+		if flag.Kind == entity.RestrictionAllergen || profile.Strict {
+			return true
+		}
+	}
+	return false
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/nutrition"
+)
+
+// SavedMealRepository defines the interface for saved meal storage
+type SavedMealRepository interface {
+	Create(ctx context.Context, userID int64, meal entity.SavedMeal) (int64, error)
+	Update(ctx context.Context, userID, id int64, meal entity.SavedMeal) error
+	Delete(ctx context.Context, userID, id int64) error
+	GetByID(ctx context.Context, userID, id int64) (*entity.SavedMeal, error)
+	List(ctx context.Context, userID int64) ([]entity.SavedMeal, error)
+}
+
+// SavedMealUseCase handles business logic for saved meals
+type SavedMealUseCase struct {
+	repo  SavedMealRepository
+	diary DiaryRepository
+	days  DiaryDayReader
+	foods FoodResolver
+}
+
+// NewSavedMealUseCase creates a new saved meal use case
+func NewSavedMealUseCase(repo SavedMealRepository, diary DiaryRepository, days DiaryDayReader, foods FoodResolver) *SavedMealUseCase {
+	return &SavedMealUseCase{
+		repo:  repo,
+		diary: diary,
+		days:  days,
+		foods: foods,
+	}
+}
+
+// Create resolves each item's nutrition and stores the saved meal
+func (uc *SavedMealUseCase) Create(ctx context.Context, userID int64, input entity.SavedMealInput) (*entity.SavedMeal, error) {
+	meal, err := uc.build(ctx, userID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uc.repo.Create(ctx, userID, meal)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.Get(ctx, userID, id)
+}
+
+// CreateFromDiary saves the foods logged in a meal slot as a saved meal, reusing the
+// diary's nutrient snapshots
+func (uc *SavedMealUseCase) CreateFromDiary(ctx context.Context, userID int64, input entity.SavedMealFromDiaryInput) (*entity.SavedMeal, error) {
+	date, err := ParseDate(input.Date)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(input.Name) == "" {
+		return nil, fmt.Errorf("%w: name is required", entity.ErrInvalidInput)
+	}
+
+	entries, err := uc.diary.ListByDate(ctx, userID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	meal := entity.SavedMeal{Name: strings.TrimSpace(input.Name), Meal: input.Meal}
+	for _, entry := range entries {
+		if entry.Meal != input.Meal {
+			continue
+		}
+		meal.Items = append(meal.Items, entity.SavedMealItem{
+			FoodID:    entry.FoodID,
+			FoodName:  entry.FoodName,
+			BrandName: entry.BrandName,
+			ServingID: entry.ServingID,
+			Amount:    entry.Amount,
+			Unit:      entry.Unit,
+			Nutrition: entry.Nutrition,
+		})
+	}
+	if len(meal.Items) == 0 {
+		return nil, fmt.Errorf("%w: nothing logged for %s on %s", entity.ErrInvalidInput, input.Meal, input.Date)
+	}
+
+	id, err := uc.repo.Create(ctx, userID, meal)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.Get(ctx, userID, id)
+}
+
+// Update replaces a saved meal and re-resolves its items
+func (uc *SavedMealUseCase) Update(ctx context.Context, userID, id int64, input entity.SavedMealInput) (*entity.SavedMeal, error) {
+	meal, err := uc.build(ctx, userID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, userID, id, meal); err != nil {
+		return nil, err
+	}
+
+	return uc.Get(ctx, userID, id)
+}
+
+// Delete removes a saved meal
+func (uc *SavedMealUseCase) Delete(ctx context.Context, userID, id int64) error {
+	return uc.repo.Delete(ctx, userID, id)
+}
+
+// Get returns a single saved meal with its totals
+func (uc *SavedMealUseCase) Get(ctx context.Context, userID, id int64) (*entity.SavedMeal, error) {
+	meal, err := uc.repo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if meal == nil {
+		return nil, fmt.Errorf("saved meal %d: %w", id, entity.ErrNotFound)
+	}
+	meal.Totals = savedMealTotals(*meal)
+	return meal, nil
+}
+
+// List returns all of a user's saved meals with their totals
+func (uc *SavedMealUseCase) List(ctx context.Context, userID int64) ([]entity.SavedMeal, error) {
+	meals, err := uc.repo.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range meals {
+		meals[i].Totals = savedMealTotals(meals[i])
+	}
+	return meals, nil
+}
+
+// Log adds every item of a saved meal to the diary in one transaction and returns the day
+func (uc *SavedMealUseCase) Log(ctx context.Context, userID, id int64, input entity.LogSavedMealInput) (*entity.DiaryDay, error) {
+	date, err := ParseDate(input.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	meal, err := uc.Get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	slot := input.Meal
+	if slot == "" {
+		slot = meal.Meal
+	}
+	if slot == "" {
+		return nil, fmt.Errorf("%w: meal is required", entity.ErrInvalidInput)
+	}
+	scale := input.Scale
+	if scale == 0 {
+		scale = 1
+	}
+	eatenAt, err := entryEatenAt(date, slot, input.EatenAt)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]entity.DiaryEntry, 0, len(meal.Items))
+	for _, item := range meal.Items {
+		entries = append(entries, scaleEntry(entity.DiaryEntry{
+			Date:      date.Format(entity.DateLayout),
+			Meal:      slot,
+			FoodID:    item.FoodID,
+			FoodName:  item.FoodName,
+			BrandName: item.BrandName,
+			ServingID: item.ServingID,
+			Amount:    item.Amount,
+			Unit:      item.Unit,
+			EatenAt:   eatenAt,
+			Nutrition: item.Nutrition,
+		}, scale))
+	}
+
+	if _, err := uc.diary.CreateBatch(ctx, userID, entries); err != nil {
+		return nil, err
+	}
+
+	return uc.days.GetDay(ctx, userID, date)
+}
+
+// build validates a saved meal and snapshots each item's nutrition
+func (uc *SavedMealUseCase) build(ctx context.Context, userID int64, input entity.SavedMealInput) (entity.SavedMeal, error) {
+	if strings.TrimSpace(input.Name) == "" {
+		return entity.SavedMeal{}, fmt.Errorf("%w: name is required", entity.ErrInvalidInput)
+	}
+	if len(input.Items) == 0 {
+		return entity.SavedMeal{}, fmt.Errorf("%w: at least one item is required", entity.ErrInvalidInput)
+	}
+
+	requests := make([]entity.FoodNutritionRequest, 0, len(input.Items))
+	for _, item := range input.Items {
+		requests = append(requests, entity.FoodNutritionRequest{
+			UserID:    userID,
+			FoodID:    item.FoodID,
+			Amount:    item.Amount,
+			Unit:      item.Unit,
+			ServingID: item.ServingID,
+		})
+	}
+	resolved, err := resolveAll(ctx, uc.foods, "item", requests)
+	if err != nil {
+		return entity.SavedMeal{}, err
+	}
+
+	meal := entity.SavedMeal{
+		Name:  strings.TrimSpace(input.Name),
+		Meal:  input.Meal,
+		Items: make([]entity.SavedMealItem, 0, len(resolved)),
+	}
+	for _, scaled := range resolved {
+		meal.Items = append(meal.Items, entity.SavedMealItem{
+			FoodID:    scaled.FoodID,
+			FoodName:  scaled.Name,
+			BrandName: scaled.BrandName,
+			ServingID: scaled.BaseServingID,
+			Amount:    scaled.Amount,
+			Unit:      scaled.Unit,
+			Nutrition: scaled.Nutrition,
+		})
+	}
+	return meal, nil
+}
+
+// savedMealTotals sums the nutrition of a saved meal's items
+func savedMealTotals(meal entity.SavedMeal) entity.Serving {
+	servings := make([]entity.Serving, 0, len(meal.Items))
+	for _, item := range meal.Items {
+		servings = append(servings, item.Nutrition)
+	}
+	totals := nutrition.Sum(servings...)
+	totals.Description = "whole meal"
+	return totals
+}
@@ -0,0 +1,316 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/nutrition"
+	"CalorieCompass/internal/pkg/restriction"
+	"CalorieCompass/internal/pkg/units"
+)
+
+// DiaryDayReader provides a diary day with its progress against the day's targets
+type DiaryDayReader interface {
+	GetDay(ctx context.Context, userID int64, date time.Time) (*entity.DiaryDay, error)
+}
+
+// SuggestionCatalog finds catalog foods whose macro split matches a budget
+type SuggestionCatalog interface {
+	SuggestFoods(ctx context.Context, budget entity.MacroBudget, netCarbs bool, filters entity.FoodSearchFilters, limit int) ([]entity.FoodDetails, error)
+}
+
+const (
+	// DefaultSuggestionLimit is the default number of suggestions
+	DefaultSuggestionLimit = 10
+	// MaxSuggestionLimit is the largest number of suggestions that can be requested
+	MaxSuggestionLimit = 50
+	// suggestionHistoryFoods is the number of foods taken from the logging history
+	suggestionHistoryFoods = 20
+	// suggestionCatalogFoods is the number of foods taken from the catalog
+	suggestionCatalogFoods = 20
+	// suggestionStep is the step, in servings, suggested amounts are rounded to
+	suggestionStep = 0.25
+	// suggestionMaxServings is the largest suggested amount in servings
+	suggestionMaxServings = 4
+	// suggestionOvershootPenalty weighs going over the budget against falling short of it
+	suggestionOvershootPenalty = 2
+)
+
+// suggestionSourceRank orders candidates with equal fit, the user's own foods first
+var suggestionSourceRank = map[string]int{
+	entity.SuggestionSourceFavorite: 0,
+	entity.SuggestionSourceHistory:  1,
+	entity.SuggestionSourceCatalog:  2,
+}
+
+// SuggestionUseCase recommends foods that fill what is left of the day's targets
+type SuggestionUseCase struct {
+	diary        DiaryDayReader
+	favorites    FavoriteRepository
+	recent       RecentFoodRepository
+	catalog      SuggestionCatalog
+	foods        FoodResolver
+	restrictions RestrictionRepository
+}
+
+// NewSuggestionUseCase creates a new suggestion use case
+func NewSuggestionUseCase(diary DiaryDayReader, favorites FavoriteRepository, recent RecentFoodRepository, catalog SuggestionCatalog, foods FoodResolver, restrictions RestrictionRepository) *SuggestionUseCase {
+	return &SuggestionUseCase{
+		diary:        diary,
+		favorites:    favorites,
+		recent:       recent,
+		catalog:      catalog,
+		foods:        foods,
+		restrictions: restrictions,
+	}
+}
+
+// suggestionCandidate is a food that may be suggested, with the serving it is scaled by and
+// its conflicts with the user's restriction profile
+type suggestionCandidate struct {
+	details entity.FoodDetails
+	source  string
+	serving entity.Serving
+	flags   []entity.FoodFlag
+}
+
+// Suggest ranks the user's favorites, recently logged foods and catalog foods by how well a
+// scaled serving of each fills the calories and macros remaining on the date. Foods that do
+// not match the request's filters are never suggested, nor are foods flagged for one of the
+// user's allergens or, under a strict profile, diets; other diet conflicts are flagged.
+func (uc *SuggestionUseCase) Suggest(ctx context.Context, request entity.SuggestionRequest) (*entity.SuggestionResponse, error) {
+	if request.Limit <= 0 {
+		request.Limit = DefaultSuggestionLimit
+	}
+	if request.Limit > MaxSuggestionLimit {
+		return nil, fmt.Errorf("%w: limit must not exceed %d", entity.ErrInvalidInput, MaxSuggestionLimit)
+	}
+
+	day, err := uc.diary.GetDay(ctx, request.UserID, request.Date)
+	if err != nil {
+		return nil, err
+	}
+	if day.Targets == nil {
+		return nil, fmt.Errorf("%w: set a nutrition goal to get suggestions", entity.ErrInvalidInput)
+	}
+
+	targets := day.Targets
+	remaining := entity.MacroBudget{
+		Calories: targets.Calories.Remaining,
+		Carbs:    targets.Carbs.Remaining,
+		Protein:  targets.Protein.Remaining,
+		Fat:      targets.Fat.Remaining,
+	}
+	response := &entity.SuggestionResponse{
+		Date:        day.Date,
+		Target:      targets.Target,
+		Remaining:   remaining,
+		Suggestions: []entity.FoodSuggestion{},
+	}
+	if remaining.Calories <= 0 {
+		return response, nil
+	}
+
+	candidates, err := uc.candidates(ctx, request, remaining, targets.Target.NetCarbs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		amount, fit := fitServing(candidate.serving, remaining, targets.Target.NetCarbs)
+		if amount == 0 {
+			continue
+		}
+
+		scaled := roundServing(units.MultiplyServing(candidate.serving, amount))
+		scaled.Description = fmt.Sprintf("%s x %s", strconv.FormatFloat(amount, 'f', -1, 64), candidate.serving.Description)
+		carbs := scaled.Carbs
+		if targets.Target.NetCarbs {
+			carbs = nutrition.NetCarbs(scaled.Carbs, scaled.Fiber)
+		}
+
+		response.Suggestions = append(response.Suggestions, entity.FoodSuggestion{
+			FoodID:    candidate.details.ID,
+			FoodName:  candidate.details.Name,
+			BrandName: candidate.details.BrandName,
+			Source:    candidate.source,
+			ServingID: candidate.serving.ID,
+			Amount:    amount,
+			Unit:      "serving",
+			Nutrition: scaled,
+			Fit:       fit,
+			Remaining: entity.MacroBudget{
+				Calories: round1(remaining.Calories - scaled.Calories),
+				Carbs:    round1(remaining.Carbs - carbs),
+				Protein:  round1(remaining.Protein - scaled.Protein),
+				Fat:      round1(remaining.Fat - scaled.Fat),
+			},
+			Flags: candidate.flags,
+		})
+	}
+
+	sort.SliceStable(response.Suggestions, func(i, j int) bool {
+		a, b := response.Suggestions[i], response.Suggestions[j]
+		if a.Fit != b.Fit {
+			return a.Fit > b.Fit
+		}
+		return suggestionSourceRank[a.Source] < suggestionSourceRank[b.Source]
+	})
+	if len(response.Suggestions) > request.Limit {
+		response.Suggestions = response.Suggestions[:request.Limit]
+	}
+	return response, nil
+}
+
+// candidates gathers the distinct foods that may be suggested: favorites at their default
+// serving, foods recently logged before the date at their last logged serving and catalog
+// foods at their labelled serving, or 100 g without one. Each is checked against the user's
+// restriction profile, which is loaded once.
+func (uc *SuggestionUseCase) candidates(ctx context.Context, request entity.SuggestionRequest, remaining entity.MacroBudget, netCarbs bool) ([]suggestionCandidate, error) {
+	profile, err := restrictionProfile(ctx, uc.restrictions, request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	favorites, err := uc.favorites.List(ctx, request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	// Recent foods are weighted towards the current time of day, on the requested date
+	now := time.Now().UTC()
+	at := request.Date.Add(now.Sub(now.Truncate(24 * time.Hour)))
+	recent, err := uc.recent.RecentFoods(ctx, request.UserID, at, suggestionHistoryFoods)
+	if err != nil {
+		return nil, err
+	}
+	catalog, err := uc.catalog.SuggestFoods(ctx, remaining, netCarbs, request.Filters, suggestionCatalogFoods)
+	if err != nil {
+		return nil, err
+	}
+
+	type personalFood struct {
+		foodID    string
+		servingID string
+		source    string
+	}
+	personal := make([]personalFood, 0, len(favorites)+len(recent))
+	for _, favorite := range favorites {
+		personal = append(personal, personalFood{favorite.FoodID, favorite.ServingID, entity.SuggestionSourceFavorite})
+	}
+	for _, food := range recent {
+		personal = append(personal, personalFood{food.FoodID, food.ServingID, entity.SuggestionSourceHistory})
+	}
+
+	foodIDs := make([]string, 0, len(personal))
+	for _, food := range personal {
+		foodIDs = append(foodIDs, food.foodID)
+	}
+	resolved := make(map[string]*entity.FoodDetails, len(foodIDs))
+	if len(foodIDs) > 0 {
+		for _, item := range uc.foods.ResolveFoodDetailsBatch(ctx, request.UserID, foodIDs).Items {
+			if item.Food != nil {
+				resolved[item.FoodID] = item.Food
+			}
+		}
+	}
+
+	seen := make(map[string]bool, len(personal)+len(catalog))
+	candidates := make([]suggestionCandidate, 0, len(personal)+len(catalog))
+	add := func(details entity.FoodDetails, servingID, source string) {
+		if seen[details.ID] || len(details.Servings) == 0 || !suggestable(details, request) {
+			return
+		}
+		seen[details.ID] = true
+
+		flags := restriction.Check(profile, details.Name, details.Ingredients, details.Attributes)
+		if restrictionExcludes(profile, flags) {
+			return
+		}
+
+		serving, err := findServingByID(details.Servings, servingID)
+		if err != nil {
+			serving = details.Servings[0]
+		}
+		candidates = append(candidates, suggestionCandidate{details: details, source: source, serving: serving, flags: flags})
+	}
+
+	for _, food := range personal {
+		if details := resolved[food.foodID]; details != nil {
+			add(*details, food.servingID, food.source)
+		}
+	}
+	for _, details := range catalog {
+		add(details, entity.CatalogServingID, entity.SuggestionSourceCatalog)
+	}
+
+	return candidates, nil
+}
+
+// suggestable reports whether a food matches the request's filters
+func suggestable(details entity.FoodDetails, request entity.SuggestionRequest) bool {
+	food := entity.Food{Type: entity.FoodTypeGeneric}
+	if details.BrandName != "" {
+		food.Type = entity.FoodTypeBrand
+	}
+	return matchesFilters(food, details, request.Filters)
+}
+
+// fitServing picks the number of servings, in suggestionStep steps, whose calories and
+// macros come closest to budget without going over its calories, and scores the fit from 0
+// to 100. Each remaining amount contributes its relative error, overshoot weighing
+// suggestionOvershootPenalty times as much; a macro that is already used up contributes
+// the energy the food adds from it, relative to the remaining calories. It returns 0 for
+// foods without energy and for foods of which even one step exceeds the calories left.
+func fitServing(serving entity.Serving, budget entity.MacroBudget, netCarbs bool) (float64, float64) {
+	if serving.Calories <= 0 || budget.Calories <= 0 {
+		return 0, 0
+	}
+
+	carbs := serving.Carbs
+	if netCarbs {
+		carbs = nutrition.NetCarbs(serving.Carbs, serving.Fiber)
+	}
+
+	// Each term's error at k servings is a*k - b
+	type term struct{ a, b float64 }
+	terms := []term{{serving.Calories / budget.Calories, 1}}
+	macros := []struct{ grams, remaining, kcalPerGram float64 }{
+		{carbs, budget.Carbs, nutrition.KcalPerGramCarbs},
+		{serving.Protein, budget.Protein, nutrition.KcalPerGramProtein},
+		{serving.Fat, budget.Fat, nutrition.KcalPerGramFat},
+	}
+	for _, macro := range macros {
+		if macro.remaining > 0 {
+			terms = append(terms, term{macro.grams / macro.remaining, 1})
+		} else {
+			terms = append(terms, term{macro.grams * macro.kcalPerGram / budget.Calories, 0})
+		}
+	}
+
+	var ab, aa float64
+	for _, t := range terms {
+		ab += t.a * t.b
+		aa += t.a * t.a
+	}
+	limit := math.Min(math.Floor(budget.Calories/serving.Calories/suggestionStep)*suggestionStep, suggestionMaxServings)
+	if limit < suggestionStep {
+		return 0, 0
+	}
+	amount := math.Round(ab/aa/suggestionStep) * suggestionStep
+	amount = math.Max(suggestionStep, math.Min(amount, limit))
+
+	var sum float64
+	for _, t := range terms {
+		e := t.a*amount - t.b
+		if e > 0 {
+			e *= suggestionOvershootPenalty
+		}
+		sum += e * e
+	}
+	fit := math.Max(0, 1-math.Sqrt(sum/float64(len(terms)))) * 100
+	return amount, round1(fit)
+}
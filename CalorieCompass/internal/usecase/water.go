@@ -0,0 +1,351 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/hydration"
+	"CalorieCompass/internal/pkg/units"
+)
+
+// WaterRepository defines the interface for water log storage
+type WaterRepository interface {
+	CreateEntry(ctx context.Context, userID int64, entry entity.WaterEntry) (int64, error)
+	DeleteEntry(ctx context.Context, userID, id int64) error
+	GetEntry(ctx context.Context, userID, id int64) (*entity.WaterEntry, error)
+	ListEntries(ctx context.Context, userID int64, from, to time.Time) ([]entity.WaterEntry, error)
+	ListContainers(ctx context.Context, userID int64) ([]entity.WaterContainer, error)
+	CreateContainer(ctx context.Context, userID int64, container entity.WaterContainer) (int64, error)
+	DeleteContainer(ctx context.Context, userID, id int64) error
+	GetSettings(ctx context.Context, userID int64) (*entity.WaterSettings, error)
+	SaveSettings(ctx context.Context, userID int64, settings entity.WaterSettings) error
+}
+
+// HydrationSummarizer totals a day's hydration for the diary summary
+type HydrationSummarizer interface {
+	Summarize(ctx context.Context, userID int64, date time.Time, entries []entity.DiaryEntry, exercises []entity.ExerciseEntry) (*entity.HydrationSummary, error)
+}
+
+// waterMaxAmount is the largest amount of water in ml a single entry or container may hold
+const waterMaxAmount = 5000
+
+// defaultContainers are available to every user; user containers with the same name take
+// precedence
+var defaultContainers = []entity.WaterContainer{
+	{Name: "glass", Volume: 250},
+	{Name: "mug", Volume: 350},
+	{Name: "bottle", Volume: 500},
+	{Name: "large bottle", Volume: 1000},
+}
+
+// WaterUseCase handles business logic for water logging and hydration targets
+type WaterUseCase struct {
+	repo      WaterRepository
+	weights   WeightRepository
+	profiles  ProfileRepository
+	diary     DiaryRepository
+	exercises ExerciseLog
+}
+
+// NewWaterUseCase creates a new water use case
+func NewWaterUseCase(repo WaterRepository, weights WeightRepository, profiles ProfileRepository, diary DiaryRepository, exercises ExerciseLog) *WaterUseCase {
+	return &WaterUseCase{
+		repo:      repo,
+		weights:   weights,
+		profiles:  profiles,
+		diary:     diary,
+		exercises: exercises,
+	}
+}
+
+// Log records water drunk, given as an amount or as a number of containers
+func (uc *WaterUseCase) Log(ctx context.Context, userID int64, input entity.WaterEntryInput) (*entity.WaterEntry, error) {
+	date, err := ParseDate(input.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := entity.WaterEntry{
+		Date:    date.Format(entity.DateLayout),
+		DrunkAt: time.Now(),
+	}
+	if input.DrunkAt != nil {
+		entry.DrunkAt = *input.DrunkAt
+	}
+
+	switch {
+	case input.Container != "" && input.Amount > 0:
+		return nil, fmt.Errorf("%w: give either an amount or a container", entity.ErrInvalidInput)
+	case input.Container != "":
+		container, err := uc.findContainer(ctx, userID, input.Container)
+		if err != nil {
+			return nil, err
+		}
+		count := input.Count
+		if count == 0 {
+			count = 1
+		}
+		entry.Container = container.Name
+		entry.Amount = container.Volume * count
+	case input.Amount > 0:
+		entry.Amount, err = toMilliliters(input.Amount, input.Unit)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: amount or container is required", entity.ErrInvalidInput)
+	}
+	if entry.Amount > waterMaxAmount {
+		return nil, fmt.Errorf("%w: at most %d ml can be logged at once", entity.ErrInvalidInput, waterMaxAmount)
+	}
+
+	id, err := uc.repo.CreateEntry(ctx, userID, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.repo.GetEntry(ctx, userID, id)
+}
+
+// DeleteEntry removes a water entry
+func (uc *WaterUseCase) DeleteEntry(ctx context.Context, userID, id int64) error {
+	return uc.repo.DeleteEntry(ctx, userID, id)
+}
+
+// Day returns the water logged on a date with the day's hydration summary
+func (uc *WaterUseCase) Day(ctx context.Context, userID int64, date time.Time) (*entity.HydrationDay, error) {
+	entries, err := uc.repo.ListEntries(ctx, userID, date, date)
+	if err != nil {
+		return nil, err
+	}
+	diaryEntries, err := uc.diary.ListByDate(ctx, userID, date)
+	if err != nil {
+		return nil, err
+	}
+	exercises, err := uc.exercises.ListEntries(ctx, userID, date, date)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := uc.summarize(ctx, userID, date, entries, diaryEntries, exercises)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.HydrationDay{
+		Date:    date.Format(entity.DateLayout),
+		Entries: entries,
+		Summary: *summary,
+	}, nil
+}
+
+// Summarize totals a day's hydration from the water log and the given diary entries and
+// exercise
+func (uc *WaterUseCase) Summarize(ctx context.Context, userID int64, date time.Time, entries []entity.DiaryEntry, exercises []entity.ExerciseEntry) (*entity.HydrationSummary, error) {
+	water, err := uc.repo.ListEntries(ctx, userID, date, date)
+	if err != nil {
+		return nil, err
+	}
+	return uc.summarize(ctx, userID, date, water, entries, exercises)
+}
+
+// ListContainers returns the built-in containers followed by the user's containers
+func (uc *WaterUseCase) ListContainers(ctx context.Context, userID int64) ([]entity.WaterContainer, error) {
+	custom, err := uc.repo.ListContainers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	containers := make([]entity.WaterContainer, 0, len(defaultContainers)+len(custom))
+	containers = append(containers, defaultContainers...)
+	return append(containers, custom...), nil
+}
+
+// CreateContainer stores a user-defined container
+func (uc *WaterUseCase) CreateContainer(ctx context.Context, userID int64, input entity.WaterContainerInput) (*entity.WaterContainer, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", entity.ErrInvalidInput)
+	}
+	volume, err := toMilliliters(input.Volume, input.Unit)
+	if err != nil {
+		return nil, err
+	}
+	if volume > waterMaxAmount {
+		return nil, fmt.Errorf("%w: a container holds at most %d ml", entity.ErrInvalidInput, waterMaxAmount)
+	}
+
+	custom, err := uc.repo.ListContainers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, container := range custom {
+		if strings.EqualFold(container.Name, name) {
+			return nil, fmt.Errorf("%w: a container named %q already exists", entity.ErrInvalidInput, container.Name)
+		}
+	}
+
+	container := entity.WaterContainer{Name: name, Volume: volume, Custom: true}
+	if container.ID, err = uc.repo.CreateContainer(ctx, userID, container); err != nil {
+		return nil, err
+	}
+	return &container, nil
+}
+
+// DeleteContainer removes a user-defined container
+func (uc *WaterUseCase) DeleteContainer(ctx context.Context, userID, id int64) error {
+	return uc.repo.DeleteContainer(ctx, userID, id)
+}
+
+// GetSettings returns the user's water settings
+func (uc *WaterUseCase) GetSettings(ctx context.Context, userID int64) (*entity.WaterSettings, error) {
+	return uc.repo.GetSettings(ctx, userID)
+}
+
+// UpdateSettings sets or clears the user's fixed daily water target
+func (uc *WaterUseCase) UpdateSettings(ctx context.Context, userID int64, input entity.WaterSettingsInput) (*entity.WaterSettings, error) {
+	var settings entity.WaterSettings
+	if input.Target != nil {
+		target, err := toMilliliters(*input.Target, input.Unit)
+		if err != nil {
+			return nil, err
+		}
+		if target < 500 || target > 10000 {
+			return nil, fmt.Errorf("%w: target must be between 500 and 10000 ml", entity.ErrInvalidInput)
+		}
+		target = math.Round(target)
+		settings.Target = &target
+	}
+
+	if err := uc.repo.SaveSettings(ctx, userID, settings); err != nil {
+		return nil, err
+	}
+	return uc.repo.GetSettings(ctx, userID)
+}
+
+// findContainer looks a container up by name, user containers first
+func (uc *WaterUseCase) findContainer(ctx context.Context, userID int64, name string) (*entity.WaterContainer, error) {
+	containers, err := uc.repo.ListContainers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, container := range append(containers, defaultContainers...) {
+		if strings.EqualFold(container.Name, strings.TrimSpace(name)) {
+			return &container, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: unknown container %q", entity.ErrInvalidInput, name)
+}
+
+// summarize computes the hydration totals and the day's target. The target is the user's
+// fixed target or one derived from the latest weight, plus extra water for exercise.
+func (uc *WaterUseCase) summarize(ctx context.Context, userID int64, date time.Time, water []entity.WaterEntry, entries []entity.DiaryEntry, exercises []entity.ExerciseEntry) (*entity.HydrationSummary, error) {
+	settings, err := uc.repo.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &entity.HydrationSummary{}
+	for _, entry := range water {
+		summary.Water += entry.Amount
+	}
+	for _, entry := range entries {
+		ml, beverage, ok := entryWater(entry)
+		if !ok {
+			continue
+		}
+		if beverage {
+			summary.Beverages += ml
+		} else {
+			summary.Foods += ml
+		}
+	}
+	summary.Water = math.Round(summary.Water)
+	summary.Beverages = math.Round(summary.Beverages)
+	summary.Foods = math.Round(summary.Foods)
+	summary.Total = summary.Water + summary.Beverages + summary.Foods
+
+	if settings.Target != nil {
+		summary.Target = *settings.Target
+		summary.TargetSource = entity.WaterTargetCustom
+	} else {
+		weight, err := uc.weights.Latest(ctx, userID, date)
+		if err != nil {
+			return nil, err
+		}
+		profile, err := uc.profiles.Get(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		male := profile != nil && profile.Sex == entity.SexMale
+		summary.TargetSource = entity.WaterTargetDefault
+		weightKg := 0.0
+		if weight != nil {
+			weightKg = weight.Weight
+			summary.TargetSource = entity.WaterTargetWeight
+		}
+		summary.Target = hydration.BaseTarget(weightKg, male)
+	}
+
+	minutes := 0.0
+	for _, exercise := range exercises {
+		minutes += exercise.Duration
+	}
+	summary.Exercise = hydration.ExerciseExtra(minutes)
+	summary.Target += summary.Exercise
+
+	summary.Remaining = math.Max(0, summary.Target-summary.Total)
+	summary.Progress = math.Round(summary.Total/summary.Target*1000) / 10
+
+	return summary, nil
+}
+
+// entryWater estimates the water in a diary entry from its metric amount, falling back to
+// the logged amount when it is a mass or volume. Drinks, measured by volume, always count;
+// foods only when they are mostly water.
+func entryWater(entry entity.DiaryEntry) (float64, bool, bool) {
+	amount, unitName := entry.Nutrition.MetricServingAmount, entry.Nutrition.MetricServingUnit
+	if amount <= 0 || unitName == "" {
+		amount, unitName = entry.Amount, entry.Unit
+	}
+	unit, err := units.Parse(unitName)
+	if err != nil || amount <= 0 {
+		return 0, false, false
+	}
+
+	n := entry.Nutrition
+	if unit.Kind == units.Volume {
+		ml, err := units.Convert(amount, unit, units.Milliliter, 0)
+		if err != nil {
+			return 0, false, false
+		}
+		return hydration.FoodWater(ml, n.Carbs, n.Protein, n.Fat), true, true
+	}
+
+	grams, err := units.Convert(amount, unit, units.Gram, 0)
+	if err != nil {
+		return 0, false, false
+	}
+	water := hydration.FoodWater(grams, n.Carbs, n.Protein, n.Fat)
+	if water < grams*hydration.HighWaterFraction {
+		return 0, false, false
+	}
+	return water, false, true
+}
+
+// toMilliliters converts a volume to ml, defaulting to ml
+func toMilliliters(amount float64, unitName string) (float64, error) {
+	if unitName == "" {
+		return amount, nil
+	}
+	unit, err := units.Parse(unitName)
+	if err != nil || unit.Kind != units.Volume {
+		return 0, fmt.Errorf("%w: unit must be a volume such as ml, l, cup or fl oz", entity.ErrInvalidInput)
+	}
+	return units.Convert(amount, unit, units.Milliliter, 0)
+}
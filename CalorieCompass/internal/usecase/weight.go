@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"CalorieCompass/internal/entity"
+	"CalorieCompass/internal/pkg/bodyweight"
+	"CalorieCompass/internal/pkg/units"
+)
+
+// WeightRepository defines the interface for body weight storage
+type WeightRepository interface {
+	Upsert(ctx context.Context, userID int64, entry entity.WeightEntry) (int64, error)
+	Delete(ctx context.Context, userID, id int64) error
+	GetByID(ctx context.Context, userID, id int64) (*entity.WeightEntry, error)
+	List(ctx context.Context, userID int64, from, to time.Time) ([]entity.WeightEntry, error)
+	Latest(ctx context.Context, userID int64, date time.Time) (*entity.WeightEntry, error)
+	GetGoal(ctx context.Context, userID int64) (*entity.WeightGoal, error)
+	SetGoal(ctx context.Context, userID int64, targetKg float64) error
+	DeleteGoal(ctx context.Context, userID int64) error
+}
+
+// IntakeRepository provides the nutrition logged in the diary per day
+type IntakeRepository interface {
+	DailyIntake(ctx context.Context, userID int64, from, to time.Time) ([]entity.DailyIntake, error)
+}
+
+const (
+	// weightRateDays is the window of trend days the weekly rate is fitted over
+	weightRateDays = 28
+	// DefaultTDEEWindow is the default number of days intake and trend change are compared over
+	DefaultTDEEWindow = 28
+	// tdeeMinDays is the minimum number of trend days and logged diary days for a TDEE estimate
+	tdeeMinDays = 7
+)
+
+// WeightUseCase handles business logic for body weight tracking
+type WeightUseCase struct {
+	repo   WeightRepository
+	intake IntakeRepository
+}
+
+// NewWeightUseCase creates a new weight use case
+func NewWeightUseCase(repo WeightRepository, intake IntakeRepository) *WeightUseCase {
+	return &WeightUseCase{
+		repo:   repo,
+		intake: intake,
+	}
+}
+
+// Record stores the weight for a date, replacing any weight already recorded that day
+func (uc *WeightUseCase) Record(ctx context.Context, userID int64, input entity.WeightEntryInput) (*entity.WeightEntry, error) {
+	date, err := ParseDate(input.Date)
+	if err != nil {
+		return nil, err
+	}
+	weight, err := toKilograms(input.Weight, input.Unit)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uc.repo.Upsert(ctx, userID, entity.WeightEntry{
+		Date:   date.Format(entity.DateLayout),
+		Weight: weight,
+		Note:   input.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.repo.GetByID(ctx, userID, id)
+}
+
+// Delete removes a weight entry
+func (uc *WeightUseCase) Delete(ctx context.Context, userID, id int64) error {
+	return uc.repo.Delete(ctx, userID, id)
+}
+
+// List returns the weights recorded between two dates
+func (uc *WeightUseCase) List(ctx context.Context, userID int64, from, to time.Time) ([]entity.WeightEntry, error) {
+	return uc.repo.List(ctx, userID, from, to)
+}
+
+// SetGoal sets the user's target weight
+func (uc *WeightUseCase) SetGoal(ctx context.Context, userID int64, input entity.WeightGoalInput) (*entity.WeightGoal, error) {
+	target, err := toKilograms(input.TargetWeight, input.Unit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.SetGoal(ctx, userID, target); err != nil {
+		return nil, err
+	}
+
+	return uc.repo.GetGoal(ctx, userID)
+}
+
+// DeleteGoal removes the user's target weight
+func (uc *WeightUseCase) DeleteGoal(ctx context.Context, userID int64) error {
+	return uc.repo.DeleteGoal(ctx, userID)
+}
+
+// Trend returns the smoothed weight series between two dates together with the weekly rate,
+// the projected goal date and a TDEE estimate over the last window days. The trend is
+// computed from the whole history so that it is already settled at from.
+func (uc *WeightUseCase) Trend(ctx context.Context, userID int64, from, to time.Time, window int) (*entity.WeightTrend, error) {
+	if window <= 0 {
+		window = DefaultTDEEWindow
+	}
+
+	entries, err := uc.repo.List(ctx, userID, time.Time{}, to)
+	if err != nil {
+		return nil, err
+	}
+	goal, err := uc.repo.GetGoal(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entity.WeightTrend{Points: make([]entity.WeightTrendPoint, 0), Goal: goal}
+	if len(entries) == 0 {
+		return result, nil
+	}
+
+	readings := make([]bodyweight.Reading, 0, len(entries))
+	for _, entry := range entries {
+		date, err := time.Parse(entity.DateLayout, entry.Date)
+		if err != nil {
+			return nil, fmt.Errorf("parse weight entry date error: %w", err)
+		}
+		readings = append(readings, bodyweight.Reading{Date: date, Weight: entry.Weight})
+	}
+
+	points := bodyweight.Trend(readings)
+	for _, point := range points {
+		if point.Date.Before(from) {
+			continue
+		}
+		trendPoint := entity.WeightTrendPoint{
+			Date:  point.Date.Format(entity.DateLayout),
+			Trend: round2(point.Trend),
+		}
+		if point.Measured {
+			weight := point.Weight
+			trendPoint.Weight = &weight
+		}
+		result.Points = append(result.Points, trendPoint)
+	}
+
+	last := points[len(points)-1]
+	result.CurrentTrend = round2(last.Trend)
+	rate := bodyweight.WeeklyRate(points, weightRateDays)
+	result.WeeklyRate = round2(rate)
+
+	if goal != nil {
+		if date, ok := bodyweight.ProjectGoal(last.Date, last.Trend, goal.TargetWeight, rate); ok {
+			projected := date.Format(entity.DateLayout)
+			result.ProjectedDate = &projected
+		}
+	}
+
+	result.TDEE, err = uc.estimateTDEE(ctx, userID, points, window)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// estimateTDEE compares the calories logged over the last window days of the trend with the
+// trend change over the same days. Days without diary entries are left out of the average
+// intake. It returns nil when there is too little data.
+func (uc *WeightUseCase) estimateTDEE(ctx context.Context, userID int64, points []bodyweight.Point, window int) (*entity.TDEEEstimate, error) {
+	if len(points) <= window {
+		window = len(points) - 1
+	}
+	if window < tdeeMinDays {
+		return nil, nil
+	}
+
+	end := points[len(points)-1]
+	start := points[len(points)-1-window]
+
+	days, err := uc.intake.DailyIntake(ctx, userID, start.Date.AddDate(0, 0, 1), end.Date)
+	if err != nil {
+		return nil, err
+	}
+	if len(days) < tdeeMinDays {
+		return nil, nil
+	}
+
+	total := 0.0
+	for _, day := range days {
+		total += day.Nutrition.Calories
+	}
+	average := total / float64(len(days))
+	change := end.Trend - start.Trend
+
+	return &entity.TDEEEstimate{
+		Calories:      math.Round(bodyweight.TDEE(average, change, window)),
+		AverageIntake: math.Round(average),
+		TrendChange:   round2(change),
+		WindowDays:    window,
+		LoggedDays:    len(days),
+	}, nil
+}
+
+// toKilograms converts a body weight in kg or lb to kg
+func toKilograms(weight float64, unit string) (float64, error) {
+	if unit == "" {
+		return round2(weight), nil
+	}
+	from, err := units.Parse(unit)
+	if err != nil || from.Kind != units.Mass {
+		return 0, fmt.Errorf("%w: unit must be kg or lb", entity.ErrInvalidInput)
+	}
+	kg, err := units.Convert(weight, from, units.Kilogram, 0)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", entity.ErrInvalidInput, err)
+	}
+	return round2(kg), nil
+}
+
+func round1(value float64) float64 {
+	return math.Round(value*10) / 10
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
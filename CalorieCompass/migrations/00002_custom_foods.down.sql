@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS food.idx_custom_food_servings_food_id;
+DROP INDEX IF EXISTS food.idx_custom_foods_user_id;
+DROP TABLE IF EXISTS food.custom_food_servings;
+DROP TABLE IF EXISTS food.custom_foods;
+DROP SCHEMA IF EXISTS food;
\ No newline at end of file
@@ -0,0 +1,33 @@
+CREATE SCHEMA IF NOT EXISTS food;
+
+CREATE TABLE IF NOT EXISTS food.custom_foods (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    brand_name VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS food.custom_food_servings (
+    id SERIAL PRIMARY KEY,
+    custom_food_id INTEGER NOT NULL REFERENCES food.custom_foods(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    description VARCHAR(255) NOT NULL,
+    metric_serving_amount NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    metric_serving_unit VARCHAR(10) NOT NULL DEFAULT 'g',
+    number_of_units NUMERIC(10, 3) NOT NULL DEFAULT 1,
+    measurement_description VARCHAR(100) NOT NULL DEFAULT '',
+    calories NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    carbs NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    protein NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    fat NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    saturated_fat NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    fiber NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    cholesterol NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    sodium NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    sugar NUMERIC(10, 3) NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_custom_foods_user_id ON food.custom_foods(user_id);
+CREATE INDEX IF NOT EXISTS idx_custom_food_servings_food_id ON food.custom_food_servings(custom_food_id);
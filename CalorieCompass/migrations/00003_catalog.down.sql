@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS catalog.idx_catalog_import_runs_file;
+DROP INDEX IF EXISTS catalog.idx_catalog_foods_name;
+DROP TABLE IF EXISTS catalog.import_runs;
+DROP TABLE IF EXISTS catalog.foods;
+DROP SCHEMA IF EXISTS catalog;
\ No newline at end of file
@@ -0,0 +1,42 @@
+CREATE SCHEMA IF NOT EXISTS catalog;
+
+CREATE TABLE IF NOT EXISTS catalog.foods (
+    id BIGSERIAL PRIMARY KEY,
+    source VARCHAR(10) NOT NULL,
+    source_id VARCHAR(64) NOT NULL,
+    name VARCHAR(512) NOT NULL,
+    brand_name VARCHAR(255) NOT NULL DEFAULT '',
+    category VARCHAR(255) NOT NULL DEFAULT '',
+    is_liquid BOOLEAN NOT NULL DEFAULT FALSE,
+    serving_size NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    serving_description VARCHAR(255) NOT NULL DEFAULT '',
+    calories NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    carbs NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    protein NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    fat NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    saturated_fat NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    fiber NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    cholesterol NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    sodium NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    sugar NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    content_hash VARCHAR(40) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (source, source_id)
+);
+
+CREATE TABLE IF NOT EXISTS catalog.import_runs (
+    id SERIAL PRIMARY KEY,
+    source VARCHAR(10) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    file_size BIGINT NOT NULL,
+    file_modified_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    checkpoint BIGINT NOT NULL DEFAULT 0,
+    imported BIGINT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'running',
+    started_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_catalog_foods_name ON catalog.foods(lower(name));
+CREATE INDEX IF NOT EXISTS idx_catalog_import_runs_file ON catalog.import_runs(source, file_name, file_size, file_modified_at);
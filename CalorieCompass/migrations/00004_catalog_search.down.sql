@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS food.food_usage;
+DROP INDEX IF EXISTS catalog.idx_catalog_foods_brand_trgm;
+DROP INDEX IF EXISTS catalog.idx_catalog_foods_name_trgm;
+DROP INDEX IF EXISTS catalog.idx_catalog_foods_search_vector;
+ALTER TABLE catalog.foods DROP COLUMN IF EXISTS search_vector;
\ No newline at end of file
@@ -0,0 +1,21 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE catalog.foods ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(brand_name, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(category, '')), 'C')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_catalog_foods_search_vector ON catalog.foods USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_catalog_foods_name_trgm ON catalog.foods USING GIN (lower(name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_catalog_foods_brand_trgm ON catalog.foods USING GIN (lower(brand_name) gin_trgm_ops);
+
+-- How often each user logs a food, used to boost search ranking
+CREATE TABLE IF NOT EXISTS food.food_usage (
+    user_id INTEGER NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    food_id VARCHAR(100) NOT NULL,
+    log_count INTEGER NOT NULL DEFAULT 0,
+    last_logged_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, food_id)
+);
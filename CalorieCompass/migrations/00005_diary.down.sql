@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS food.favorite_foods;
+DROP INDEX IF EXISTS food.idx_diary_entries_user_eaten_at;
+DROP INDEX IF EXISTS food.idx_diary_entries_user_date;
+DROP TABLE IF EXISTS food.diary_entries;
\ No newline at end of file
@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS food.diary_entries (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    meal VARCHAR(20) NOT NULL,
+    food_id VARCHAR(100) NOT NULL,
+    food_name VARCHAR(255) NOT NULL,
+    brand_name VARCHAR(255) NOT NULL DEFAULT '',
+    serving_id VARCHAR(100) NOT NULL DEFAULT '',
+    amount NUMERIC(10, 3) NOT NULL,
+    unit VARCHAR(20) NOT NULL,
+    eaten_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    calories NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    carbs NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    protein NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    fat NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    saturated_fat NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    fiber NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    cholesterol NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    sodium NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    sugar NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_diary_entries_user_date ON food.diary_entries(user_id, date);
+CREATE INDEX IF NOT EXISTS idx_diary_entries_user_eaten_at ON food.diary_entries(user_id, eaten_at);
+
+CREATE TABLE IF NOT EXISTS food.favorite_foods (
+    user_id INTEGER NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    food_id VARCHAR(100) NOT NULL,
+    food_name VARCHAR(255) NOT NULL,
+    brand_name VARCHAR(255) NOT NULL DEFAULT '',
+    serving_id VARCHAR(100) NOT NULL DEFAULT '',
+    amount NUMERIC(10, 3) NOT NULL DEFAULT 1,
+    unit VARCHAR(20) NOT NULL DEFAULT 'serving',
+    calories NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    carbs NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    protein NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    fat NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, food_id)
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS food.idx_recipe_ingredients_food_id;
+DROP INDEX IF EXISTS food.idx_recipe_ingredients_recipe_id;
+DROP INDEX IF EXISTS food.idx_recipes_user_id;
+DROP TABLE IF EXISTS food.recipe_ingredients;
+DROP TABLE IF EXISTS food.recipes;
\ No newline at end of file
@@ -0,0 +1,51 @@
+CREATE TABLE IF NOT EXISTS food.recipes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    servings NUMERIC(10, 3) NOT NULL,
+    yield_amount NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    yield_unit VARCHAR(20) NOT NULL DEFAULT '',
+    instructions TEXT NOT NULL DEFAULT '',
+    -- Nutrients of the whole recipe, recomputed from the ingredients
+    metric_amount NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    metric_unit VARCHAR(10) NOT NULL DEFAULT '',
+    calories NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    carbs NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    protein NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    fat NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    saturated_fat NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    fiber NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    cholesterol NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    sodium NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    sugar NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    computed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS food.recipe_ingredients (
+    id SERIAL PRIMARY KEY,
+    recipe_id INTEGER NOT NULL REFERENCES food.recipes(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    food_id VARCHAR(100) NOT NULL,
+    food_name VARCHAR(255) NOT NULL,
+    brand_name VARCHAR(255) NOT NULL DEFAULT '',
+    serving_id VARCHAR(100) NOT NULL DEFAULT '',
+    amount NUMERIC(10, 3) NOT NULL,
+    unit VARCHAR(20) NOT NULL,
+    metric_amount NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    metric_unit VARCHAR(10) NOT NULL DEFAULT '',
+    calories NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    carbs NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    protein NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    fat NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    saturated_fat NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    fiber NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    cholesterol NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    sodium NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    sugar NUMERIC(10, 3) NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_recipes_user_id ON food.recipes(user_id);
+CREATE INDEX IF NOT EXISTS idx_recipe_ingredients_recipe_id ON food.recipe_ingredients(recipe_id);
+CREATE INDEX IF NOT EXISTS idx_recipe_ingredients_food_id ON food.recipe_ingredients(food_id);
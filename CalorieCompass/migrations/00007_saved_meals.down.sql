@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS food.idx_saved_meal_items_saved_meal_id;
+DROP INDEX IF EXISTS food.idx_saved_meals_user_id;
+DROP TABLE IF EXISTS food.saved_meal_items;
+DROP TABLE IF EXISTS food.saved_meals;
\ No newline at end of file
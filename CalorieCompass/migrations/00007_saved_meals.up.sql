@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS food.saved_meals (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    meal VARCHAR(20) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS food.saved_meal_items (
+    id SERIAL PRIMARY KEY,
+    saved_meal_id INTEGER NOT NULL REFERENCES food.saved_meals(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    food_id VARCHAR(100) NOT NULL,
+    food_name VARCHAR(255) NOT NULL,
+    brand_name VARCHAR(255) NOT NULL DEFAULT '',
+    serving_id VARCHAR(100) NOT NULL DEFAULT '',
+    amount NUMERIC(10, 3) NOT NULL,
+    unit VARCHAR(20) NOT NULL,
+    metric_amount NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    metric_unit VARCHAR(10) NOT NULL DEFAULT '',
+    calories NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    carbs NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    protein NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    fat NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    saturated_fat NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    fiber NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    cholesterol NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    sodium NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    sugar NUMERIC(10, 3) NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_meals_user_id ON food.saved_meals(user_id);
+CREATE INDEX IF NOT EXISTS idx_saved_meal_items_saved_meal_id ON food.saved_meal_items(saved_meal_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS body.weight_goals;
+DROP TABLE IF EXISTS body.weight_entries;
+DROP SCHEMA IF EXISTS body;
\ No newline at end of file
@@ -0,0 +1,18 @@
+CREATE SCHEMA IF NOT EXISTS body;
+
+CREATE TABLE IF NOT EXISTS body.weight_entries (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    weight_kg NUMERIC(6, 2) NOT NULL,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, date)
+);
+
+CREATE TABLE IF NOT EXISTS body.weight_goals (
+    user_id INTEGER PRIMARY KEY REFERENCES auth.users(id) ON DELETE CASCADE,
+    target_kg NUMERIC(6, 2) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS body.idx_measurements_user_type_date;
+DROP TABLE IF EXISTS body.measurements;
+DROP TABLE IF EXISTS body.profiles;
\ No newline at end of file
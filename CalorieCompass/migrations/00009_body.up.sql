@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS body.profiles (
+    user_id INTEGER PRIMARY KEY REFERENCES auth.users(id) ON DELETE CASCADE,
+    sex VARCHAR(10) NOT NULL DEFAULT '',
+    birth_date DATE,
+    height_cm NUMERIC(5, 1) NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS body.measurements (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    value NUMERIC(7, 2) NOT NULL,
+    unit VARCHAR(5) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, date, type)
+);
+
+CREATE INDEX IF NOT EXISTS idx_measurements_user_type_date ON body.measurements(user_id, type, date);
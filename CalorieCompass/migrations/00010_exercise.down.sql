@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS activity.settings;
+DROP INDEX IF EXISTS activity.idx_exercise_entries_user_date;
+DROP TABLE IF EXISTS activity.exercise_entries;
+DROP INDEX IF EXISTS activity.idx_exercises_name;
+DROP INDEX IF EXISTS activity.idx_exercises_user_id;
+DROP TABLE IF EXISTS activity.exercises;
+DROP SCHEMA IF EXISTS activity;
\ No newline at end of file
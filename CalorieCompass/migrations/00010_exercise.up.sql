@@ -0,0 +1,84 @@
+CREATE SCHEMA IF NOT EXISTS activity;
+
+-- Exercises with user_id NULL form the shared catalog, the rest are user-defined.
+-- MET values follow the 2011 Compendium of Physical Activities. speed_kmh is the speed the
+-- moderate MET applies to for activities that can be logged by distance, 0 otherwise.
+CREATE TABLE IF NOT EXISTS activity.exercises (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES auth.users(id) ON DELETE CASCADE,
+    code VARCHAR(10) NOT NULL DEFAULT '',
+    name VARCHAR(255) NOT NULL,
+    category VARCHAR(50) NOT NULL DEFAULT '',
+    met_light NUMERIC(4, 1) NOT NULL,
+    met NUMERIC(4, 1) NOT NULL,
+    met_vigorous NUMERIC(4, 1) NOT NULL,
+    speed_kmh NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_exercises_user_id ON activity.exercises(user_id);
+CREATE INDEX IF NOT EXISTS idx_exercises_name ON activity.exercises(lower(name));
+
+CREATE TABLE IF NOT EXISTS activity.exercise_entries (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    exercise_id INTEGER REFERENCES activity.exercises(id) ON DELETE SET NULL,
+    date DATE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    intensity VARCHAR(10) NOT NULL,
+    duration_min NUMERIC(6, 1) NOT NULL,
+    distance_km NUMERIC(7, 2),
+    met NUMERIC(4, 1) NOT NULL,
+    weight_kg NUMERIC(6, 2) NOT NULL,
+    calories NUMERIC(8, 1) NOT NULL,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_exercise_entries_user_date ON activity.exercise_entries(user_id, date);
+
+CREATE TABLE IF NOT EXISTS activity.settings (
+    user_id INTEGER PRIMARY KEY REFERENCES auth.users(id) ON DELETE CASCADE,
+    add_burned_calories BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO activity.exercises (code, name, category, met_light, met, met_vigorous, speed_kmh) VALUES
+    ('17200', 'Walking', 'walking', 2.8, 3.5, 5.0, 4.8),
+    ('17165', 'Walking the dog', 'walking', 2.5, 3.0, 3.5, 0),
+    ('17080', 'Hiking', 'walking', 5.3, 6.0, 7.8, 0),
+    ('17133', 'Stair climbing', 'walking', 4.0, 5.0, 8.8, 0),
+    ('12050', 'Running', 'running', 7.0, 9.8, 11.8, 9.7),
+    ('01030', 'Cycling', 'cycling', 6.8, 8.0, 10.0, 20.9),
+    ('02010', 'Stationary cycling', 'cycling', 3.5, 6.8, 8.8, 0),
+    ('18250', 'Swimming laps', 'water', 5.8, 8.3, 10.0, 2.7),
+    ('18090', 'Kayaking', 'water', 3.5, 5.0, 12.5, 0),
+    ('02072', 'Rowing machine', 'conditioning', 4.8, 7.0, 8.5, 0),
+    ('02048', 'Elliptical trainer', 'conditioning', 4.0, 5.0, 6.5, 0),
+    ('02065', 'Stair machine', 'conditioning', 4.0, 9.0, 9.0, 0),
+    ('02040', 'Circuit training', 'conditioning', 4.3, 8.0, 8.0, 0),
+    ('02022', 'Calisthenics', 'conditioning', 2.8, 3.8, 8.0, 0),
+    ('15551', 'Jumping rope', 'conditioning', 8.8, 11.8, 12.3, 0),
+    ('02054', 'Weight training', 'strength', 3.5, 3.5, 6.0, 0),
+    ('02150', 'Yoga', 'flexibility', 2.0, 2.5, 4.0, 0),
+    ('02105', 'Pilates', 'flexibility', 2.5, 3.0, 3.8, 0),
+    ('02101', 'Stretching', 'flexibility', 2.3, 2.3, 2.8, 0),
+    ('03015', 'Aerobic dance', 'dancing', 5.0, 7.3, 9.5, 0),
+    ('03025', 'Social dancing', 'dancing', 3.0, 5.5, 7.8, 0),
+    ('15055', 'Basketball', 'sports', 4.5, 6.5, 8.0, 0),
+    ('15605', 'Soccer', 'sports', 5.0, 7.0, 10.0, 0),
+    ('15675', 'Tennis', 'sports', 6.0, 7.3, 8.0, 0),
+    ('15030', 'Badminton', 'sports', 4.5, 5.5, 7.0, 0),
+    ('15710', 'Volleyball', 'sports', 3.0, 6.0, 8.0, 0),
+    ('15660', 'Table tennis', 'sports', 4.0, 4.0, 4.0, 0),
+    ('15255', 'Golf', 'sports', 3.5, 4.8, 5.3, 0),
+    ('15100', 'Boxing', 'sports', 5.5, 7.8, 12.8, 0),
+    ('15430', 'Martial arts', 'sports', 5.3, 10.3, 10.3, 0),
+    ('15535', 'Rock climbing', 'sports', 5.8, 7.5, 8.0, 0),
+    ('19080', 'Cross-country skiing', 'winter', 6.8, 9.0, 12.5, 7.2),
+    ('19150', 'Downhill skiing', 'winter', 4.3, 5.3, 8.0, 0),
+    ('19030', 'Ice skating', 'winter', 5.5, 7.0, 9.0, 0),
+    ('08245', 'Gardening', 'household', 2.3, 3.8, 5.0, 0),
+    ('08120', 'Mowing the lawn', 'household', 4.5, 5.0, 6.0, 0),
+    ('05020', 'House cleaning', 'household', 2.3, 3.3, 3.8, 0);
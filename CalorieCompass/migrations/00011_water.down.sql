@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS body.water_settings;
+DROP INDEX IF EXISTS body.idx_water_containers_user_id;
+DROP TABLE IF EXISTS body.water_containers;
+DROP INDEX IF EXISTS body.idx_water_entries_user_date;
+DROP TABLE IF EXISTS body.water_entries;
+ALTER TABLE food.diary_entries DROP COLUMN IF EXISTS metric_unit;
+ALTER TABLE food.diary_entries DROP COLUMN IF EXISTS metric_amount;
\ No newline at end of file
@@ -0,0 +1,30 @@
+ALTER TABLE food.diary_entries ADD COLUMN IF NOT EXISTS metric_amount NUMERIC(10, 3) NOT NULL DEFAULT 0;
+ALTER TABLE food.diary_entries ADD COLUMN IF NOT EXISTS metric_unit VARCHAR(10) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS body.water_entries (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    amount_ml NUMERIC(7, 1) NOT NULL,
+    container VARCHAR(50) NOT NULL DEFAULT '',
+    drunk_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_water_entries_user_date ON body.water_entries(user_id, date);
+
+CREATE TABLE IF NOT EXISTS body.water_containers (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    volume_ml NUMERIC(6, 1) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_water_containers_user_id ON body.water_containers(user_id);
+
+CREATE TABLE IF NOT EXISTS body.water_settings (
+    user_id INTEGER PRIMARY KEY REFERENCES auth.users(id) ON DELETE CASCADE,
+    target_ml NUMERIC(6, 0),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS body.idx_fasting_sessions_user_active;
+DROP INDEX IF EXISTS body.idx_fasting_sessions_user_started;
+DROP TABLE IF EXISTS body.fasting_sessions;
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS body.fasting_sessions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    protocol VARCHAR(20) NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    planned_hours NUMERIC(5, 2) NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_fasting_sessions_user_started ON body.fasting_sessions(user_id, started_at);
+
+-- At most one fast in progress per user
+CREATE UNIQUE INDEX IF NOT EXISTS idx_fasting_sessions_user_active ON body.fasting_sessions(user_id) WHERE ended_at IS NULL;
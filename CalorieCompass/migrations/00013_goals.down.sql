@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS food.nutrition_goal_days;
+DROP TABLE IF EXISTS food.nutrition_goals;
\ No newline at end of file
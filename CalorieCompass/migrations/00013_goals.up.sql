@@ -0,0 +1,23 @@
+-- A goal applies from its effective date until the next goal starts, so past days keep
+-- being evaluated against the targets that were active on them
+CREATE TABLE IF NOT EXISTS food.nutrition_goals (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    effective_from DATE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, effective_from)
+);
+
+-- One row per weekday (0 = Sunday) with the resolved daily targets
+CREATE TABLE IF NOT EXISTS food.nutrition_goal_days (
+    goal_id INTEGER NOT NULL REFERENCES food.nutrition_goals(id) ON DELETE CASCADE,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    label VARCHAR(30) NOT NULL DEFAULT '',
+    preset VARCHAR(20) NOT NULL,
+    calories NUMERIC(6, 0) NOT NULL,
+    carbs NUMERIC(6, 1) NOT NULL,
+    protein NUMERIC(6, 1) NOT NULL,
+    fat NUMERIC(6, 1) NOT NULL,
+    net_carbs BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (goal_id, weekday)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS food.restriction_profiles;
+ALTER TABLE catalog.foods DROP COLUMN IF EXISTS ingredients;
\ No newline at end of file
@@ -0,0 +1,11 @@
+-- Ingredient lists from the datasets, checked against users' allergens and diets
+ALTER TABLE catalog.foods ADD COLUMN IF NOT EXISTS ingredients TEXT NOT NULL DEFAULT '';
+
+-- Allergens and diets a user avoids; strict profiles hide conflicting foods from searches
+CREATE TABLE IF NOT EXISTS food.restriction_profiles (
+    user_id INTEGER PRIMARY KEY REFERENCES auth.users(id) ON DELETE CASCADE,
+    allergens TEXT[] NOT NULL DEFAULT '{}',
+    diets TEXT[] NOT NULL DEFAULT '{}',
+    strict BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
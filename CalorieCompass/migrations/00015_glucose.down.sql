@@ -0,0 +1 @@
+DROP TABLE IF EXISTS body.glucose_readings;
\ No newline at end of file
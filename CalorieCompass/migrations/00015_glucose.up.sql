@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS body.glucose_readings (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    mg_dl NUMERIC(5, 1) NOT NULL,
+    context VARCHAR(20) NOT NULL DEFAULT 'random',
+    measured_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_glucose_readings_user_measured ON body.glucose_readings(user_id, measured_at);
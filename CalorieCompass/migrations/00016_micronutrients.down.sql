@@ -0,0 +1,47 @@
+ALTER TABLE food.saved_meal_items
+    DROP COLUMN IF EXISTS vitamin_d,
+    DROP COLUMN IF EXISTS vitamin_c,
+    DROP COLUMN IF EXISTS vitamin_a,
+    DROP COLUMN IF EXISTS potassium,
+    DROP COLUMN IF EXISTS iron,
+    DROP COLUMN IF EXISTS calcium;
+
+ALTER TABLE food.recipe_ingredients
+    DROP COLUMN IF EXISTS vitamin_d,
+    DROP COLUMN IF EXISTS vitamin_c,
+    DROP COLUMN IF EXISTS vitamin_a,
+    DROP COLUMN IF EXISTS potassium,
+    DROP COLUMN IF EXISTS iron,
+    DROP COLUMN IF EXISTS calcium;
+
+ALTER TABLE food.recipes
+    DROP COLUMN IF EXISTS vitamin_d,
+    DROP COLUMN IF EXISTS vitamin_c,
+    DROP COLUMN IF EXISTS vitamin_a,
+    DROP COLUMN IF EXISTS potassium,
+    DROP COLUMN IF EXISTS iron,
+    DROP COLUMN IF EXISTS calcium;
+
+ALTER TABLE food.diary_entries
+    DROP COLUMN IF EXISTS vitamin_d,
+    DROP COLUMN IF EXISTS vitamin_c,
+    DROP COLUMN IF EXISTS vitamin_a,
+    DROP COLUMN IF EXISTS potassium,
+    DROP COLUMN IF EXISTS iron,
+    DROP COLUMN IF EXISTS calcium;
+
+ALTER TABLE food.custom_food_servings
+    DROP COLUMN IF EXISTS vitamin_d,
+    DROP COLUMN IF EXISTS vitamin_c,
+    DROP COLUMN IF EXISTS vitamin_a,
+    DROP COLUMN IF EXISTS potassium,
+    DROP COLUMN IF EXISTS iron,
+    DROP COLUMN IF EXISTS calcium;
+
+ALTER TABLE catalog.foods
+    DROP COLUMN IF EXISTS vitamin_d,
+    DROP COLUMN IF EXISTS vitamin_c,
+    DROP COLUMN IF EXISTS vitamin_a,
+    DROP COLUMN IF EXISTS potassium,
+    DROP COLUMN IF EXISTS iron,
+    DROP COLUMN IF EXISTS calcium;
@@ -0,0 +1,47 @@
+ALTER TABLE catalog.foods
+    ADD COLUMN IF NOT EXISTS calcium NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS iron NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS potassium NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS vitamin_a NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS vitamin_c NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS vitamin_d NUMERIC(10, 3) NOT NULL DEFAULT 0;
+
+ALTER TABLE food.custom_food_servings
+    ADD COLUMN IF NOT EXISTS calcium NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS iron NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS potassium NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS vitamin_a NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS vitamin_c NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS vitamin_d NUMERIC(10, 3) NOT NULL DEFAULT 0;
+
+ALTER TABLE food.diary_entries
+    ADD COLUMN IF NOT EXISTS calcium NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS iron NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS potassium NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS vitamin_a NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS vitamin_c NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS vitamin_d NUMERIC(10, 3) NOT NULL DEFAULT 0;
+
+ALTER TABLE food.recipes
+    ADD COLUMN IF NOT EXISTS calcium NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS iron NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS potassium NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS vitamin_a NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS vitamin_c NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS vitamin_d NUMERIC(10, 3) NOT NULL DEFAULT 0;
+
+ALTER TABLE food.recipe_ingredients
+    ADD COLUMN IF NOT EXISTS calcium NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS iron NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS potassium NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS vitamin_a NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS vitamin_c NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS vitamin_d NUMERIC(10, 3) NOT NULL DEFAULT 0;
+
+ALTER TABLE food.saved_meal_items
+    ADD COLUMN IF NOT EXISTS calcium NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS iron NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS potassium NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS vitamin_a NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS vitamin_c NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS vitamin_d NUMERIC(10, 3) NOT NULL DEFAULT 0;